@@ -23,7 +23,7 @@ import (
 )
 
 func TestDataSourceConfigRateList(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: destroyDataSourceConfigRateListConfig,
@@ -21,7 +21,7 @@ import (
 
 func TestDataSourceConfigRule(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRule")
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: func(s *terraform.State) error { return destroyDataSourceConfigRuleConfig(objName) },
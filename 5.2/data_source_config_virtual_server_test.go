@@ -23,7 +23,7 @@ import (
 
 func TestDataSourceConfigVirtualServer(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestVirtualServer")
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: func(s *terraform.State) error { return destroyDataSourceConfigVirtualServerConfig(objName) },
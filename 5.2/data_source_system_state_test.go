@@ -18,7 +18,7 @@ import (
 
 func TestDataSourceSystemState(t *testing.T) {
    var validError = regexp.MustCompile("^(ok|warn|error)$")
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		Steps: []resource.TestStep{
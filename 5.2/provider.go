@@ -5,8 +5,10 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	vtm "github.com/pulse-vadc/go-vtm/5.2"
 )
@@ -38,6 +40,40 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("VTM_VERIFY_SSL_CERT", true),
 				Description: "Check that vTM REST interface SSL certificate is trusted",
 			},
+			"request_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VTM_REQUEST_TIMEOUT", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in seconds for each request to the REST API (0 for no timeout)",
+			},
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VTM_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a failed request to the REST API is retried",
+			},
+			"retry_backoff_min": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Delay in seconds before the first retry, doubled with jitter for each further retry",
+			},
+			"retry_backoff_max": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum delay in seconds between retries",
+			},
+			"retryable_status_codes": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(400, 599)},
+				Description: "HTTP status codes which are retried (default: 429, 502, 503, 504)",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"vtm_backups_full":          resourceSystemBackupsFull(),
@@ -240,7 +276,22 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	password := d.Get("password").(string)
 	verifySslCert := d.Get("verify_ssl_cert").(bool)
 
-	tm, contactable, contactErr := vtm.NewVirtualTrafficManager(baseUrl, username, password, verifySslCert, true)
+	options := vtm.DefaultConnectionOptions()
+	options.RequestTimeout = time.Duration(d.Get("request_timeout").(int)) * time.Second
+	options.MaxRetries = d.Get("max_retries").(int)
+	options.RetryBackoffMin = time.Duration(d.Get("retry_backoff_min").(int)) * time.Second
+	options.RetryBackoffMax = time.Duration(d.Get("retry_backoff_max").(int)) * time.Second
+	if codes := d.Get("retryable_status_codes").(*schema.Set); codes.Len() > 0 {
+		options.RetryableStatusCodes = []int{}
+		for _, code := range codes.List() {
+			options.RetryableStatusCodes = append(options.RetryableStatusCodes, code.(int))
+		}
+	}
+	if options.RetryBackoffMax < options.RetryBackoffMin {
+		return nil, fmt.Errorf("retry_backoff_max (%d) must not be less than retry_backoff_min (%d)", d.Get("retry_backoff_max").(int), d.Get("retry_backoff_min").(int))
+	}
+
+	tm, contactable, contactErr := vtm.NewVirtualTrafficManagerWithOptions(baseUrl, username, password, verifySslCert, true, options)
 	if contactable != true {
 		return nil, fmt.Errorf("Failed to connect to Virtual Traffic Manager at '%v': %v", baseUrl, contactErr.ErrorText)
 	}
@@ -336,7 +336,7 @@ func resourceActionCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceActionObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_action '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -352,7 +352,7 @@ func resourceActionUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceActionObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_action '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
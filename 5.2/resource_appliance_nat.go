@@ -383,7 +383,7 @@ func resourceApplianceNatUpdate(d *schema.ResourceData, tm interface{}) error {
 
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_nat: %s %s", applyErr.ErrorText, info)
 	}
 	d.SetId("nat")
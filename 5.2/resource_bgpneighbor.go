@@ -159,7 +159,7 @@ func resourceBgpneighborCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceBgpneighborObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_bgpneighbor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -175,7 +175,7 @@ func resourceBgpneighborUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceBgpneighborObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_bgpneighbor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
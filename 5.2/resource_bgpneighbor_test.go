@@ -21,7 +21,7 @@ import (
 func TestResourceBgpneighbor(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestBgpneighbor")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckBgpneighborDestroy,
@@ -155,7 +155,7 @@ func resourceCloudApiCredentialCreate(d *schema.ResourceData, tm interface{}) er
 	resourceCloudApiCredentialObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_cloud_api_credential '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -171,7 +171,7 @@ func resourceCloudApiCredentialUpdate(d *schema.ResourceData, tm interface{}) er
 	resourceCloudApiCredentialObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_cloud_api_credential '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceDnsServerZone(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestDnsServerZone")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDnsServerZoneDestroy,
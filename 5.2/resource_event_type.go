@@ -378,7 +378,7 @@ func resourceEventTypeCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceEventTypeObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_event_type '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -394,7 +394,7 @@ func resourceEventTypeUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceEventTypeObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_event_type '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
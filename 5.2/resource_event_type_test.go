@@ -21,7 +21,7 @@ import (
 func TestResourceEventType(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestEventType")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckEventTypeDestroy,
@@ -21,7 +21,7 @@ import (
 func TestResourceGlbService(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestGlbService")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckGlbServiceDestroy,
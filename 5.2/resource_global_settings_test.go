@@ -16,7 +16,7 @@ import (
 )
 
 func TestResourceGlobalSettings(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
 		Providers: testAccProviders,
 		Steps: []resource.TestStep{
@@ -21,7 +21,7 @@ import (
 func TestResourceKerberosKeytab(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestKerberosKeytab")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckKerberosKeytabDestroy,
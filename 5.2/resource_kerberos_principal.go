@@ -134,7 +134,7 @@ func resourceKerberosPrincipalCreate(d *schema.ResourceData, tm interface{}) err
 	resourceKerberosPrincipalObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_principal '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -150,7 +150,7 @@ func resourceKerberosPrincipalUpdate(d *schema.ResourceData, tm interface{}) err
 	resourceKerberosPrincipalObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_principal '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -134,7 +134,7 @@ func resourceLocationCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceLocationObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_location '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -150,7 +150,7 @@ func resourceLocationUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceLocationObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_location '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
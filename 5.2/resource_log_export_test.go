@@ -21,7 +21,7 @@ import (
 func TestResourceLogExport(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestLogExport")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckLogExportDestroy,
@@ -27,7 +27,7 @@ var objName string
 func TestResourcePoolEnhanced(t *testing.T) {
 	objName = acctest.RandomWithPrefix("TestPool")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckPoolEnhancedDestroy,
@@ -83,7 +83,7 @@ func TestResourcePoolEnhanced(t *testing.T) {
 
 	invalidStateRegex, _ := regexp.Compile("active disabled draining")
 	duplicateNodeRegex, _ := regexp.Compile("invalid.*?duplicates were found")
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckPoolEnhancedDestroy,
@@ -329,7 +329,7 @@ func resourceProtectionCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceProtectionObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_protection '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -345,7 +345,7 @@ func resourceProtectionUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceProtectionObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_protection '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
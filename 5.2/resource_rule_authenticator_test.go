@@ -21,7 +21,7 @@ import (
 func TestResourceRuleAuthenticator(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRuleAuthenticator")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckRuleAuthenticatorDestroy,
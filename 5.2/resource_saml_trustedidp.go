@@ -131,7 +131,7 @@ func resourceSamlTrustedidpCreate(d *schema.ResourceData, tm interface{}) error
 	resourceSamlTrustedidpObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_trustedidp '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -147,7 +147,7 @@ func resourceSamlTrustedidpUpdate(d *schema.ResourceData, tm interface{}) error
 	resourceSamlTrustedidpObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_trustedidp '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceSamlTrustedidp(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestSamlTrustedidp")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSamlTrustedidpDestroy,
@@ -121,7 +121,7 @@ func resourceSslClientKeyCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceSslClientKeyObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_client_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -137,7 +137,7 @@ func resourceSslClientKeyUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceSslClientKeyObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_client_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
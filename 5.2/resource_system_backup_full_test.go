@@ -21,7 +21,7 @@ import (
 func TestResourceSystemBackupsFull(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestBackupFull")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSystemBackupsFullDestroy,
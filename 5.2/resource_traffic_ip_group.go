@@ -314,7 +314,7 @@ func resourceTrafficIpGroupCreate(d *schema.ResourceData, tm interface{}) error
 	resourceTrafficIpGroupObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_traffic_ip_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -330,7 +330,7 @@ func resourceTrafficIpGroupUpdate(d *schema.ResourceData, tm interface{}) error
 	resourceTrafficIpGroupObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_traffic_ip_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceVirtualServer(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestVirtualServer")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckVirtualServerDestroy,
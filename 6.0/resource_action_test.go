@@ -21,7 +21,7 @@ import (
 func TestResourceAction(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestAction")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckActionDestroy,
@@ -116,7 +116,7 @@ func resourceAptimizerScopeCreate(d *schema.ResourceData, tm interface{}) error
 	resourceAptimizerScopeObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_scope '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -132,7 +132,7 @@ func resourceAptimizerScopeUpdate(d *schema.ResourceData, tm interface{}) error
 	resourceAptimizerScopeObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_scope '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
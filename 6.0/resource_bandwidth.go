@@ -117,7 +117,7 @@ func resourceBandwidthCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceBandwidthObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_bandwidth '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -133,7 +133,7 @@ func resourceBandwidthUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceBandwidthObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_bandwidth '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
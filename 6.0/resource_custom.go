@@ -134,7 +134,7 @@ func resourceCustomCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceCustomObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_custom '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -150,7 +150,7 @@ func resourceCustomUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceCustomObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_custom '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
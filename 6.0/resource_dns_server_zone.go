@@ -104,7 +104,7 @@ func resourceDnsServerZoneCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceDnsServerZoneObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_zone '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -120,7 +120,7 @@ func resourceDnsServerZoneUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceDnsServerZoneObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_zone '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
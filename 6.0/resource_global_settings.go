@@ -2796,7 +2796,7 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_global_setting: %s %s", applyErr.ErrorText, info)
 	}
 	d.SetId("global_setting")
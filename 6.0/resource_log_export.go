@@ -190,7 +190,7 @@ func resourceLogExportCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceLogExportObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_log_export '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -206,7 +206,7 @@ func resourceLogExportUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceLogExportObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_log_export '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
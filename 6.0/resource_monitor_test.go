@@ -21,7 +21,7 @@ import (
 func TestResourceMonitor(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestMonitor")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckMonitorDestroy,
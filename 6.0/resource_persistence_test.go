@@ -21,7 +21,7 @@ import (
 func TestResourcePersistence(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestPersistence")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckPersistenceDestroy,
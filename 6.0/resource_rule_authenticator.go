@@ -191,7 +191,7 @@ func resourceRuleAuthenticatorCreate(d *schema.ResourceData, tm interface{}) err
 	resourceRuleAuthenticatorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_rule_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -207,7 +207,7 @@ func resourceRuleAuthenticatorUpdate(d *schema.ResourceData, tm interface{}) err
 	resourceRuleAuthenticatorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_rule_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -129,7 +129,7 @@ func resourceServiceLevelMonitorCreate(d *schema.ResourceData, tm interface{}) e
 	resourceServiceLevelMonitorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_service_level_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -145,7 +145,7 @@ func resourceServiceLevelMonitorUpdate(d *schema.ResourceData, tm interface{}) e
 	resourceServiceLevelMonitorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_service_level_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceSslClientKey(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestSslClientKey")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSslClientKeyDestroy,
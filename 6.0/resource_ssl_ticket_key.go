@@ -143,7 +143,7 @@ func resourceSslTicketKeyCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceSslTicketKeyObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_ticket_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -159,7 +159,7 @@ func resourceSslTicketKeyUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceSslTicketKeyObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_ticket_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceTrafficIpGroup(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestTrafficIpGroup")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckTrafficIpGroupDestroy,
@@ -166,7 +166,7 @@ func resourceUserGroupCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceUserGroupObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_user_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -182,7 +182,7 @@ func resourceUserGroupUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceUserGroupObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_user_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -2102,7 +2102,7 @@ func resourceVirtualServerCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceVirtualServerObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_virtual_server '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -2118,7 +2118,7 @@ func resourceVirtualServerUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceVirtualServerObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_virtual_server '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
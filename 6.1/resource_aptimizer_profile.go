@@ -136,7 +136,7 @@ func resourceAptimizerProfileCreate(d *schema.ResourceData, tm interface{}) erro
 	resourceAptimizerProfileObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_profile '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -152,7 +152,7 @@ func resourceAptimizerProfileUpdate(d *schema.ResourceData, tm interface{}) erro
 	resourceAptimizerProfileObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_profile '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceAptimizerScope(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestAptimizerScope")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAptimizerScopeDestroy,
@@ -21,7 +21,7 @@ import (
 func TestResourceDnsServerZoneFile(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestDnsServerZoneFile")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDnsServerZoneFileDestroy,
@@ -372,7 +372,7 @@ func resourceGlbServiceCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceGlbServiceObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_glb_service '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -388,7 +388,7 @@ func resourceGlbServiceUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceGlbServiceObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_glb_service '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
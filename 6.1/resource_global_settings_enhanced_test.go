@@ -20,7 +20,7 @@ import (
 )
 
 func TestResourceGlobalSettingsEnhanced(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: dummyCheckGlobalSettingsEnhancedDeleted,
@@ -407,7 +407,7 @@ func resourceMonitorCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceMonitorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -423,7 +423,7 @@ func resourceMonitorUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceMonitorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
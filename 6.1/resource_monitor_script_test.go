@@ -21,7 +21,7 @@ import (
 func TestResourceMonitorScript(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestMonitorScript")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckMonitorScriptDestroy,
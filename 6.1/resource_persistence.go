@@ -195,7 +195,7 @@ func resourcePersistenceCreate(d *schema.ResourceData, tm interface{}) error {
 	resourcePersistenceObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_persistence '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -211,7 +211,7 @@ func resourcePersistenceUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourcePersistenceObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_persistence '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -1070,7 +1070,7 @@ func resourcePoolCreate(d *schema.ResourceData, tm interface{}) error {
 	resourcePoolObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_pool '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -1086,7 +1086,7 @@ func resourcePoolUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourcePoolObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_pool '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
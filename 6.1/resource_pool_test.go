@@ -21,7 +21,7 @@ import (
 func TestResourcePool(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestPool")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckPoolDestroy,
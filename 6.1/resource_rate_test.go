@@ -21,7 +21,7 @@ import (
 func TestResourceRate(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRate")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckRateDestroy,
@@ -161,7 +161,7 @@ func resourceSecurityUpdate(d *schema.ResourceData, tm interface{}) error {
 
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_security: %s %s", applyErr.ErrorText, info)
 	}
 	d.SetId("security")
@@ -21,7 +21,7 @@ import (
 func TestResourceSslCa(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestSslCa")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSslCaDestroy,
@@ -121,7 +121,7 @@ func resourceSslServerKeyCreate(d *schema.ResourceData, tm interface{}) error {
 	resourceSslServerKeyObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_server_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -137,7 +137,7 @@ func resourceSslServerKeyUpdate(d *schema.ResourceData, tm interface{}) error {
 	resourceSslServerKeyObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_server_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceSslTicketKey(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestSslTicketKey")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSslTicketKeyDestroy,
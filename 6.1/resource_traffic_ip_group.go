@@ -304,7 +304,7 @@ func resourceTrafficIpGroupCreate(d *schema.ResourceData, tm interface{}) error
 	resourceTrafficIpGroupObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_traffic_ip_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -320,7 +320,7 @@ func resourceTrafficIpGroupUpdate(d *schema.ResourceData, tm interface{}) error
 	resourceTrafficIpGroupObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_traffic_ip_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -1166,7 +1166,7 @@ func resourceTrafficManagerUpdate(d *schema.ResourceData, tm interface{}) error
 	resourceTrafficManagerObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_traffic_manager '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -18,7 +18,7 @@ import (
 )
 
 func TestResourceTrafficManagerEnhanced(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: dummyCheckTrafficManagerEnhancedDeleted,
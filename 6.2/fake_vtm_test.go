@@ -0,0 +1,149 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * The fake vTM of the internal fakevtm package, serving the go-vtm 6.2 types and the defaults of
+ * this provider's resource schemas.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/6.2"
+	"github.com/pulse-vadc/terraform-provider-vtm/internal/fakevtm"
+)
+
+const (
+	fakeVtmUsername = fakevtm.Username
+	fakeVtmPassword = fakevtm.Password
+	fakeVtmHostname = fakevtm.Hostname
+)
+
+type fakeVtm = fakevtm.Server
+
+var fakeVtmApi = fakevtm.Api{
+	ApiVersions: []string{"6.2"},
+	Information: reflect.TypeOf(vtm.SystemInformation{}),
+	State:       reflect.TypeOf(vtm.SystemState{}),
+	Collections: map[string]fakevtm.Collection{
+		"config/active/actions":                {Properties: reflect.TypeOf(vtm.ActionProperties{}), Resource: "vtm_action"},
+		"config/active/action_programs":        {},
+		"config/active/appliance/nat":          {Properties: reflect.TypeOf(vtm.ApplianceNatProperties{}), Resource: "vtm_appliance_nat"},
+		"config/active/aptimizer/profiles":     {Properties: reflect.TypeOf(vtm.AptimizerProfileProperties{}), Resource: "vtm_aptimizer_profile"},
+		"config/active/aptimizer/scopes":       {Properties: reflect.TypeOf(vtm.AptimizerScopeProperties{}), Resource: "vtm_aptimizer_scope"},
+		"config/active/bandwidth":              {Properties: reflect.TypeOf(vtm.BandwidthProperties{}), Resource: "vtm_bandwidth"},
+		"config/active/bgpneighbors":           {Properties: reflect.TypeOf(vtm.BgpneighborProperties{}), Resource: "vtm_bgpneighbor"},
+		"config/active/cloud_api_credentials":  {Properties: reflect.TypeOf(vtm.CloudApiCredentialProperties{}), Resource: "vtm_cloud_api_credential"},
+		"config/active/custom":                 {Properties: reflect.TypeOf(vtm.CustomProperties{}), Resource: "vtm_custom"},
+		"config/active/dns_server/zones":       {Properties: reflect.TypeOf(vtm.DnsServerZoneProperties{}), Resource: "vtm_dns_server_zone"},
+		"config/active/dns_server/zone_files":  {},
+		"config/active/event_types":            {Properties: reflect.TypeOf(vtm.EventTypeProperties{}), Resource: "vtm_event_type"},
+		"config/active/extra_files":            {},
+		"config/active/glb_services":           {Properties: reflect.TypeOf(vtm.GlbServiceProperties{}), Resource: "vtm_glb_service"},
+		"config/active/global_settings":        {Properties: reflect.TypeOf(vtm.GlobalSettingsProperties{}), Resource: "vtm_global_settings"},
+		"config/active/kerberos/keytabs":       {},
+		"config/active/kerberos/krb5confs":     {},
+		"config/active/kerberos/principals":    {Properties: reflect.TypeOf(vtm.KerberosPrincipalProperties{}), Resource: "vtm_kerberos_principal"},
+		"config/active/license_keys":           {},
+		"config/active/locations":              {Properties: reflect.TypeOf(vtm.LocationProperties{}), Resource: "vtm_location"},
+		"config/active/log_export":             {Properties: reflect.TypeOf(vtm.LogExportProperties{}), Resource: "vtm_log_export"},
+		"config/active/monitors":               {Properties: reflect.TypeOf(vtm.MonitorProperties{}), Resource: "vtm_monitor"},
+		"config/active/monitor_scripts":        {},
+		"config/active/persistence":            {Properties: reflect.TypeOf(vtm.PersistenceProperties{}), Resource: "vtm_persistence"},
+		"config/active/pools":                  {Properties: reflect.TypeOf(vtm.PoolProperties{}), Resource: "vtm_pool"},
+		"config/active/protection":             {Properties: reflect.TypeOf(vtm.ProtectionProperties{}), Resource: "vtm_protection"},
+		"config/active/rate":                   {Properties: reflect.TypeOf(vtm.RateProperties{}), Resource: "vtm_rate"},
+		"config/active/rules":                  {},
+		"config/active/rule_authenticators":    {Properties: reflect.TypeOf(vtm.RuleAuthenticatorProperties{}), Resource: "vtm_rule_authenticator"},
+		"config/active/saml/trustedidps":       {Properties: reflect.TypeOf(vtm.SamlTrustedidpProperties{}), Resource: "vtm_saml_trustedidp"},
+		"config/active/security":               {Properties: reflect.TypeOf(vtm.SecurityProperties{}), Resource: "vtm_security"},
+		"config/active/service_level_monitors": {Properties: reflect.TypeOf(vtm.ServiceLevelMonitorProperties{}), Resource: "vtm_service_level_monitor"},
+		"config/active/servicediscovery":       {},
+		"config/active/ssl/cas":                {},
+		"config/active/ssl/client_keys":        {Properties: reflect.TypeOf(vtm.SslClientKeyProperties{}), Resource: "vtm_ssl_client_key"},
+		"config/active/ssl/server_keys":        {Properties: reflect.TypeOf(vtm.SslServerKeyProperties{}), Resource: "vtm_ssl_server_key"},
+		"config/active/ssl/ticket_keys":        {Properties: reflect.TypeOf(vtm.SslTicketKeyProperties{}), Resource: "vtm_ssl_ticket_key"},
+		"config/active/traffic_ip_groups":      {Properties: reflect.TypeOf(vtm.TrafficIpGroupProperties{}), Resource: "vtm_traffic_ip_group"},
+		"config/active/traffic_managers":       {Properties: reflect.TypeOf(vtm.TrafficManagerProperties{}), Resource: "vtm_traffic_manager"},
+		"config/active/user_authenticators":    {Properties: reflect.TypeOf(vtm.UserAuthenticatorProperties{}), Resource: "vtm_user_authenticator"},
+		"config/active/user_groups":            {Properties: reflect.TypeOf(vtm.UserGroupProperties{}), Resource: "vtm_user_group"},
+		"config/active/virtual_servers":        {Properties: reflect.TypeOf(vtm.VirtualServerProperties{}), Resource: "vtm_virtual_server"},
+		"status/local_tm/backups/full":         {Properties: reflect.TypeOf(vtm.SystemBackupsFullProperties{}), Resource: "vtm_backups_full"},
+	},
+	Statistics: map[string]reflect.Type{
+		"actions/":                                    reflect.TypeOf(vtm.ActionStatistics{}),
+		"bandwidth/":                                  reflect.TypeOf(vtm.BandwidthStatistics{}),
+		"cache/asp_session_cache":                     reflect.TypeOf(vtm.CacheAspSessionCacheStatistics{}),
+		"cache/ip_session_cache":                      reflect.TypeOf(vtm.CacheIpSessionCacheStatistics{}),
+		"cache/j2ee_session_cache":                    reflect.TypeOf(vtm.CacheJ2EeSessionCacheStatistics{}),
+		"cache/ssl_cache":                             reflect.TypeOf(vtm.CacheSslCacheStatistics{}),
+		"cache/ssl_session_cache":                     reflect.TypeOf(vtm.CacheSslSessionCacheStatistics{}),
+		"cache/uni_session_cache":                     reflect.TypeOf(vtm.CacheUniSessionCacheStatistics{}),
+		"cache/web_cache":                             reflect.TypeOf(vtm.CacheWebCacheStatistics{}),
+		"cloud_api_credentials/":                      reflect.TypeOf(vtm.CloudApiCredentialStatistics{}),
+		"connection_rate_limit/":                      reflect.TypeOf(vtm.ConnectionRateLimitStatistics{}),
+		"events/":                                     reflect.TypeOf(vtm.EventStatistics{}),
+		"extras/user_counters_32":                     reflect.TypeOf(vtm.ExtrasUserCounters32Statistics{}),
+		"extras/user_counters_64":                     reflect.TypeOf(vtm.ExtrasUserCounters64Statistics{}),
+		"glb_services/":                               reflect.TypeOf(vtm.GlbServiceStatistics{}),
+		"globals":                                     reflect.TypeOf(vtm.GlobalsStatistics{}),
+		"listen_ips/":                                 reflect.TypeOf(vtm.ListenIpStatistics{}),
+		"locations/":                                  reflect.TypeOf(vtm.LocationStatistics{}),
+		"network_interface/":                          reflect.TypeOf(vtm.NetworkInterfaceStatistics{}),
+		"nodes/node/":                                 reflect.TypeOf(vtm.NodesNodeStatistics{}),
+		"nodes/node_inet46/":                          reflect.TypeOf(vtm.NodesNodeInet46Statistics{}),
+		"nodes/per_pool_node/":                        reflect.TypeOf(vtm.NodesPerPoolNodeStatistics{}),
+		"per_location_service/":                       reflect.TypeOf(vtm.PerLocationServiceStatistics{}),
+		"per_node_slm/per_node_service_level/":        reflect.TypeOf(vtm.PerNodeSlmPerNodeServiceLevelStatistics{}),
+		"per_node_slm/per_node_service_level_inet46/": reflect.TypeOf(vtm.PerNodeSlmPerNodeServiceLevelInet46Statistics{}),
+		"pools/":                                      reflect.TypeOf(vtm.PoolStatistics{}),
+		"rule_authenticators/":                        reflect.TypeOf(vtm.RuleAuthenticatorStatistics{}),
+		"rules/":                                      reflect.TypeOf(vtm.RuleStatistics{}),
+		"service_level_monitors/":                     reflect.TypeOf(vtm.ServiceLevelMonitorStatistics{}),
+		"service_protection/":                         reflect.TypeOf(vtm.ServiceProtectionStatistics{}),
+		"ssl_ocsp_stapling":                           reflect.TypeOf(vtm.SslOcspStaplingStatistics{}),
+		"traffic_ips/ip_gateway":                      reflect.TypeOf(vtm.TrafficIpsIpGatewayStatistics{}),
+		"traffic_ips/traffic_ip/":                     reflect.TypeOf(vtm.TrafficIpsTrafficIpStatistics{}),
+		"traffic_ips/traffic_ip_inet46/":              reflect.TypeOf(vtm.TrafficIpsTrafficIpInet46Statistics{}),
+		"virtual_servers/":                            reflect.TypeOf(vtm.VirtualServerStatistics{}),
+	},
+}
+
+// newFakeVtm starts a fake vTM, whose objects take their defaults from this provider's resources.
+func newFakeVtm() *fakeVtm {
+	api := fakeVtmApi
+	api.Resources = Provider().(*schema.Provider).ResourcesMap
+	return fakevtm.New(api)
+}
+
+func TestFakeVtmValidation(t *testing.T) {
+	properties := fakevtm.Properties{
+		"basic": {
+			"note":           json.RawMessage(`"A note"`),
+			"max_idle_conns": json.RawMessage(`1`),
+			"transparent":    json.RawMessage(`"yes"`),
+		},
+		"no_such_section": {
+			"note": json.RawMessage(`"A note"`),
+		},
+	}
+	// Round-trip the error_info through JSON, as the provider receives it
+	var errorInfo interface{}
+	encoded, _ := json.Marshal(fakevtm.ValidateProperties(properties, reflect.TypeOf(vtm.PoolProperties{})))
+	json.Unmarshal(encoded, &errorInfo)
+	result := formatErrorInfo(errorInfo)
+	for _, expected := range []string{"basic_max_idle_conns Unknown property", "basic_transparent Invalid value", "no_such_section_note Unknown section"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Validation error '%s' not reported: %s", expected, result)
+		}
+	}
+	if strings.Contains(result, "basic_note") {
+		t.Errorf("Valid property reported as invalid: %s", result)
+	}
+}
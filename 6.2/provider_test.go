@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/pulse-vadc/go-vtm/6.2"
@@ -28,12 +29,16 @@ var testList = []string{
 
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
+var testFakeVtm *fakeVtm
 
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"vtm": testAccProvider,
 	}
+	if _, _, _, err := getTestEnvVars(); err != nil {
+		startTestFakeVtm()
+	}
 	_, err := getTestVtm()
 	if err != nil {
 		panic(err)
@@ -54,6 +59,32 @@ func getTestEnvVars() (baseUrl, username, password string, envError error) {
 	return
 }
 
+/*
+startTestFakeVtm points the tests at an in-process fake vTM, which is used whenever VTM_BASE_URL,
+VTM_USERNAME and VTM_PASSWORD have not been set to the details of a real vTM.
+*/
+func startTestFakeVtm() {
+	testFakeVtm = newFakeVtm()
+	os.Setenv("VTM_BASE_URL", testFakeVtm.BaseUrl())
+	os.Setenv("VTM_USERNAME", fakeVtmUsername)
+	os.Setenv("VTM_PASSWORD", fakeVtmPassword)
+}
+
+/*
+testAccTest runs a Terraform test case. Against the fake vTM the test case makes no changes outside
+of the test process, so it runs as a unit test without TF_ACC having to be set.
+*/
+func testAccTest(t *testing.T, testCase resource.TestCase) {
+	testCase.IsUnitTest = testFakeVtm != nil
+	resource.Test(t, testCase)
+}
+
+func testAccRequireRealVtm(t *testing.T) {
+	if testFakeVtm != nil {
+		t.Skip("This test requires a real vTM; set VTM_BASE_URL, VTM_USERNAME and VTM_PASSWORD to run it")
+	}
+}
+
 func getTestVtm() (*vtm.VirtualTrafficManager, error) {
 	baseUrl, username, password, err := getTestEnvVars()
 	if err != nil {
@@ -110,6 +141,24 @@ func TestGetVtmIncorrectSettings(t *testing.T) {
 	}
 }
 
+func TestFormatErrorInfo(t *testing.T) {
+	tables := []struct {
+		info   interface{}
+		result string
+	}{
+		{nil, ""},
+		{"not a map", ""},
+		{map[string]interface{}{"basic": "not a map"}, ""},
+		{map[string]interface{}{"basic": map[string]interface{}{"note": map[string]interface{}{"error_id": "type.string", "error_text": "Must be a string"}}}, "; basic_note Must be a string"},
+	}
+
+	for _, table := range tables {
+		if errorMsg := formatErrorInfo(table.info); errorMsg != table.result {
+			t.Errorf("formatErrorInfo failed: %#v -> %s", table.info, errorMsg)
+		}
+	}
+}
+
 func TestGetStringAddr(t *testing.T) {
 	inputString := "Hello"
 	outputStringPtr := getStringAddr(inputString)
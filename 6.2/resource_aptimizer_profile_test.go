@@ -21,7 +21,7 @@ import (
 func TestResourceAptimizerProfile(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestAptimizerProfile")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAptimizerProfileDestroy,
@@ -21,7 +21,7 @@ import (
 func TestResourceCloudApiCredential(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestCloudApiCredential")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCloudApiCredentialDestroy,
@@ -21,7 +21,7 @@ import (
 func TestResourceExtraFile(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestExtraFile")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckExtraFileDestroy,
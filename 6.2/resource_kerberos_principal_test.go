@@ -21,7 +21,7 @@ import (
 func TestResourceKerberosPrincipal(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestKerberosPrincipal")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckKerberosPrincipalDestroy,
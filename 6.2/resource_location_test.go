@@ -21,7 +21,7 @@ import (
 func TestResourceLocation(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestLocation")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckLocationDestroy,
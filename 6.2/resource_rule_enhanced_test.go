@@ -20,7 +20,7 @@ import (
 
 func TestResourceRuleEnhanced(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRule")
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckRuleEnhancedDestroy,
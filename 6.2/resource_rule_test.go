@@ -21,7 +21,7 @@ import (
 func TestResourceRule(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRule")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckRuleDestroy,
@@ -21,7 +21,7 @@ import (
 func TestResourceServicediscovery(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestServicediscovery")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckServicediscoveryDestroy,
@@ -21,7 +21,7 @@ import (
 func TestResourceSslServerKey(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestSslServerKey")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSslServerKeyDestroy,
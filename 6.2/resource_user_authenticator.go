@@ -418,7 +418,7 @@ func resourceUserAuthenticatorCreate(d *schema.ResourceData, tm interface{}) err
 	resourceUserAuthenticatorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_user_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -434,7 +434,7 @@ func resourceUserAuthenticatorUpdate(d *schema.ResourceData, tm interface{}) err
 	resourceUserAuthenticatorObjectFieldAssignments(d, object)
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_user_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceUserAuthenticator(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestUserAuthenticator")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckUserAuthenticatorDestroy,
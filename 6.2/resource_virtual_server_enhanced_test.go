@@ -35,7 +35,7 @@ func TestResourceVirtualServerEnhanced(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestVirtualServer")
 	configInvalidRegex := regexp.MustCompile(`invalid`)
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckVirtualServerEnhancedDestroy,
@@ -141,7 +141,7 @@ func TestResourceVirtualServerEnhanced(t *testing.T) {
 	})
 
 	// Test re-ordering of entries in list and set fields
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckVirtualServerEnhancedDestroy,
@@ -168,7 +168,7 @@ func TestResourceVirtualServerEnhanced(t *testing.T) {
 	})
 
 	// Test re-ordering of entries in list and set fields within tables
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckVirtualServerEnhancedDestroyWithCerts,
@@ -24,11 +24,21 @@ func setAllNotRequired(fields map[string]*schema.Schema) map[string]*schema.Sche
 	return fields
 }
 
-func formatErrorInfo(errorInfo map[string]interface{}) string {
+func formatErrorInfo(errorInfo interface{}) string {
 	errorMsg := ""
-	for section, info := range errorInfo {
-		for field, errors := range info.(map[string]interface{}) {
-			errorMsg += fmt.Sprintf("; %s_%s %s", section, field, errors.(map[string]interface{})["error_text"])
+	sections, ok := errorInfo.(map[string]interface{})
+	if !ok {
+		return errorMsg
+	}
+	for section, info := range sections {
+		fields, ok := info.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field, errors := range fields {
+			if fieldErrors, ok := errors.(map[string]interface{}); ok {
+				errorMsg += fmt.Sprintf("; %s_%s %s", section, field, fieldErrors["error_text"])
+			}
 		}
 	}
 	return errorMsg
@@ -0,0 +1,438 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * A minimal ACME (RFC 8555) client for vtm_acme_certificate. It registers an
+ * account, places an order, answers its challenges through a callback,
+ * finalizes the order with a certificate signing request and downloads the
+ * certificate chain. Requests are signed as JWS with an ECDSA P-256 (ES256)
+ * or RSA (RS256) account key.
+ */
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// How long to wait between polls of a pending authorization or order, unless the server says otherwise.
+var acmePollInterval = time.Second
+
+type acmeDirectory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type acmeProblem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func (problem *acmeProblem) Error() string {
+	return fmt.Sprintf("%s: %s", problem.Type, problem.Detail)
+}
+
+type acmeIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type acmeOrder struct {
+	Status         string           `json:"status"`
+	Identifiers    []acmeIdentifier `json:"identifiers"`
+	Authorizations []string         `json:"authorizations"`
+	Finalize       string           `json:"finalize"`
+	Certificate    string           `json:"certificate"`
+	Error          *acmeProblem     `json:"error"`
+}
+
+type acmeChallenge struct {
+	Type   string       `json:"type"`
+	URL    string       `json:"url"`
+	Status string       `json:"status"`
+	Token  string       `json:"token"`
+	Error  *acmeProblem `json:"error"`
+}
+
+type acmeAuthorization struct {
+	Status     string          `json:"status"`
+	Identifier acmeIdentifier  `json:"identifier"`
+	Challenges []acmeChallenge `json:"challenges"`
+}
+
+type acmeClient struct {
+	httpClient *http.Client
+	directory  acmeDirectory
+	key        crypto.Signer
+	accountURL string
+	nonces     []string
+}
+
+/*
+newAcmeClient fetches the directory of an ACME server. caCertPem, if not empty, holds the CA
+certificates to trust for the server's TLS certificate instead of the system's roots.
+*/
+func newAcmeClient(directoryURL, caCertPem string, key crypto.Signer) (*acmeClient, error) {
+	if err := checkAcmeAccountKey(key); err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{}
+	if caCertPem != "" {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caCertPem)) == false {
+			return nil, fmt.Errorf("No PEM encoded certificates found in directory_ca_cert_pem")
+		}
+	}
+	client := &acmeClient{
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+		},
+		key: key,
+	}
+	response, err := client.httpClient.Get(directoryURL)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch ACME directory: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to fetch ACME directory: %s", response.Status)
+	}
+	if err := json.NewDecoder(response.Body).Decode(&client.directory); err != nil {
+		return nil, fmt.Errorf("Failed to decode ACME directory: %v", err)
+	}
+	if client.directory.NewNonce == "" || client.directory.NewAccount == "" || client.directory.NewOrder == "" {
+		return nil, fmt.Errorf("ACME directory at %s is missing newNonce, newAccount or newOrder", directoryURL)
+	}
+	return client, nil
+}
+
+// parseAcmeAccountKey parses a PEM encoded PKCS#1 RSA, SEC 1 EC or PKCS#8 private key.
+func parseAcmeAccountKey(text string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil {
+		return nil, fmt.Errorf("No PEM encoded private key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse private key: %v", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if ok != true {
+		return nil, fmt.Errorf("Unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// checkAcmeAccountKey checks that an account key can sign requests, with ES256 or RS256.
+func checkAcmeAccountKey(key crypto.Signer) error {
+	switch typedKey := key.(type) {
+	case *ecdsa.PrivateKey:
+		if typedKey.Curve != elliptic.P256() {
+			return fmt.Errorf("ECDSA account keys must use the P-256 curve")
+		}
+	case *rsa.PrivateKey:
+	default:
+		return fmt.Errorf("Unsupported account key type %T", key)
+	}
+	return nil
+}
+
+func acmeBase64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// getAcmeJwk returns the JSON Web Key for the public part of key, with its members in lexicographic order.
+func getAcmeJwk(key crypto.PublicKey) (map[string]string, error) {
+	switch typedKey := key.(type) {
+	case *ecdsa.PublicKey:
+		size := (typedKey.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"crv": typedKey.Curve.Params().Name,
+			"kty": "EC",
+			"x":   acmeBase64(typedKey.X.FillBytes(make([]byte, size))),
+			"y":   acmeBase64(typedKey.Y.FillBytes(make([]byte, size))),
+		}, nil
+	case *rsa.PublicKey:
+		return map[string]string{
+			"e":   acmeBase64(big.NewInt(int64(typedKey.E)).Bytes()),
+			"kty": "RSA",
+			"n":   acmeBase64(typedKey.N.Bytes()),
+		}, nil
+	}
+	return nil, fmt.Errorf("Unsupported account key type %T", key)
+}
+
+// getAcmeJwkThumbprint returns the RFC 7638 thumbprint of key.
+func getAcmeJwkThumbprint(key crypto.PublicKey) (string, error) {
+	jwk, err := getAcmeJwk(key)
+	if err != nil {
+		return "", err
+	}
+	// encoding/json writes map keys in order, without whitespace, as RFC 7638 requires
+	encoded, _ := json.Marshal(jwk)
+	digest := sha256.Sum256(encoded)
+	return acmeBase64(digest[:]), nil
+}
+
+// keyAuthorization returns the response to a challenge with token.
+func (client *acmeClient) keyAuthorization(token string) (string, error) {
+	thumbprint, err := getAcmeJwkThumbprint(client.key.Public())
+	if err != nil {
+		return "", err
+	}
+	return token + "." + thumbprint, nil
+}
+
+func (client *acmeClient) getNonce() (string, error) {
+	if len(client.nonces) > 0 {
+		nonce := client.nonces[len(client.nonces)-1]
+		client.nonces = client.nonces[:len(client.nonces)-1]
+		return nonce, nil
+	}
+	response, err := client.httpClient.Head(client.directory.NewNonce)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get ACME nonce: %v", err)
+	}
+	response.Body.Close()
+	nonce := response.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", fmt.Errorf("Failed to get ACME nonce: no Replay-Nonce in response from %s", client.directory.NewNonce)
+	}
+	return nonce, nil
+}
+
+// signRequest encodes payload as a flattened JWS for url. A nil payload gives a POST-as-GET request.
+func (client *acmeClient) signRequest(url string, payload []byte) ([]byte, error) {
+	nonce, err := client.getNonce()
+	if err != nil {
+		return nil, err
+	}
+	protected := map[string]interface{}{"nonce": nonce, "url": url}
+	if client.accountURL == "" {
+		jwk, err := getAcmeJwk(client.key.Public())
+		if err != nil {
+			return nil, err
+		}
+		protected["jwk"] = jwk
+	} else {
+		protected["kid"] = client.accountURL
+	}
+	if _, isRsa := client.key.(*rsa.PrivateKey); isRsa {
+		protected["alg"] = "RS256"
+	} else {
+		protected["alg"] = "ES256"
+	}
+	encodedProtected, _ := json.Marshal(protected)
+	signingInput := acmeBase64(encodedProtected) + "." + acmeBase64(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := client.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to sign ACME request: %v", err)
+	}
+	// JWS takes ECDSA signatures as the concatenated R and S values, not DER.
+	if _, isEcdsa := client.key.(*ecdsa.PrivateKey); isEcdsa {
+		var values struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(signature, &values); err != nil {
+			return nil, fmt.Errorf("Failed to sign ACME request: %v", err)
+		}
+		signature = append(values.R.FillBytes(make([]byte, 32)), values.S.FillBytes(make([]byte, 32))...)
+	}
+	return json.Marshal(map[string]string{
+		"protected": acmeBase64(encodedProtected),
+		"payload":   acmeBase64(payload),
+		"signature": acmeBase64(signature),
+	})
+}
+
+/*
+post sends a signed request to url, retrying once if the server rejects the nonce, and decodes the
+JSON response into result unless it is nil. It returns the response, whose body has been read into
+body.
+*/
+func (client *acmeClient) post(url string, payload interface{}, result interface{}) (response *http.Response, body []byte, err error) {
+	var encodedPayload []byte
+	if payload != nil {
+		encodedPayload, _ = json.Marshal(payload)
+	}
+	for attempt := 0; ; attempt++ {
+		request, err := client.signRequest(url, encodedPayload)
+		if err != nil {
+			return nil, nil, err
+		}
+		response, err = client.httpClient.Post(url, "application/jose+json", bytes.NewReader(request))
+		if err != nil {
+			return nil, nil, fmt.Errorf("ACME request to %s failed: %v", url, err)
+		}
+		body, err = ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("ACME request to %s failed: %v", url, err)
+		}
+		if nonce := response.Header.Get("Replay-Nonce"); nonce != "" {
+			client.nonces = append(client.nonces, nonce)
+		}
+		if response.StatusCode < 400 {
+			break
+		}
+		problem := &acmeProblem{Status: response.StatusCode}
+		if json.Unmarshal(body, problem) != nil || problem.Type == "" {
+			return nil, nil, fmt.Errorf("ACME request to %s failed: %s", url, response.Status)
+		}
+		if problem.Type != "urn:ietf:params:acme:error:badNonce" || attempt > 0 {
+			return nil, nil, problem
+		}
+	}
+	if result != nil {
+		if err := json.Unmarshal(body, result); err != nil {
+			return nil, nil, fmt.Errorf("Failed to decode ACME response from %s: %v", url, err)
+		}
+	}
+	return response, body, nil
+}
+
+// register creates the account for the client's key, or finds the existing one.
+func (client *acmeClient) register(email string) error {
+	account := map[string]interface{}{"termsOfServiceAgreed": true}
+	if email != "" {
+		account["contact"] = []string{"mailto:" + email}
+	}
+	response, _, err := client.post(client.directory.NewAccount, account, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to register ACME account: %v", err)
+	}
+	client.accountURL = response.Header.Get("Location")
+	if client.accountURL == "" {
+		return fmt.Errorf("Failed to register ACME account: no Location in response")
+	}
+	return nil
+}
+
+// newOrder places an order for names, returning it and its URL.
+func (client *acmeClient) newOrder(names []string) (*acmeOrder, string, error) {
+	identifiers := []acmeIdentifier{}
+	for _, name := range names {
+		identifiers = append(identifiers, acmeIdentifier{Type: "dns", Value: name})
+	}
+	order := &acmeOrder{}
+	response, _, err := client.post(client.directory.NewOrder, map[string]interface{}{"identifiers": identifiers}, order)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to place ACME order: %v", err)
+	}
+	return order, response.Header.Get("Location"), nil
+}
+
+func (client *acmeClient) getAuthorization(url string) (*acmeAuthorization, error) {
+	authorization := &acmeAuthorization{}
+	if _, _, err := client.post(url, nil, authorization); err != nil {
+		return nil, fmt.Errorf("Failed to read ACME authorization: %v", err)
+	}
+	return authorization, nil
+}
+
+// acceptChallenge tells the server that the response to a challenge is in place.
+func (client *acmeClient) acceptChallenge(challenge acmeChallenge) error {
+	if _, _, err := client.post(challenge.URL, map[string]interface{}{}, nil); err != nil {
+		return fmt.Errorf("Failed to accept ACME challenge for token %s: %v", challenge.Token, err)
+	}
+	return nil
+}
+
+func getAcmeRetryAfter(response *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return acmePollInterval
+}
+
+// waitForAuthorization polls an authorization until it is valid, failing if it becomes invalid or the deadline passes.
+func (client *acmeClient) waitForAuthorization(url string, deadline time.Time) error {
+	for {
+		authorization := &acmeAuthorization{}
+		response, _, err := client.post(url, nil, authorization)
+		if err != nil {
+			return fmt.Errorf("Failed to read ACME authorization: %v", err)
+		}
+		switch authorization.Status {
+		case "valid":
+			return nil
+		case "pending", "processing":
+		default:
+			for _, challenge := range authorization.Challenges {
+				if challenge.Error != nil {
+					return fmt.Errorf("ACME authorization for %s is %s: %v", authorization.Identifier.Value, authorization.Status, challenge.Error)
+				}
+			}
+			return fmt.Errorf("ACME authorization for %s is %s", authorization.Identifier.Value, authorization.Status)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out waiting for ACME authorization for %s", authorization.Identifier.Value)
+		}
+		time.Sleep(getAcmeRetryAfter(response))
+	}
+}
+
+/*
+finalize submits the DER encoded certificate signing request for an order, then polls the order
+until its certificate has been issued.
+*/
+func (client *acmeClient) finalize(order *acmeOrder, orderURL string, csr []byte, deadline time.Time) (*acmeOrder, error) {
+	response, _, err := client.post(order.Finalize, map[string]string{"csr": acmeBase64(csr)}, order)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to finalize ACME order: %v", err)
+	}
+	for {
+		switch order.Status {
+		case "valid":
+			return order, nil
+		case "pending", "ready", "processing":
+		default:
+			if order.Error != nil {
+				return nil, fmt.Errorf("ACME order is %s: %v", order.Status, order.Error)
+			}
+			return nil, fmt.Errorf("ACME order is %s", order.Status)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("Timed out waiting for the ACME order to be issued")
+		}
+		time.Sleep(getAcmeRetryAfter(response))
+		if response, _, err = client.post(orderURL, nil, order); err != nil {
+			return nil, fmt.Errorf("Failed to read ACME order: %v", err)
+		}
+	}
+}
+
+// downloadCertificate returns the PEM encoded certificate chain issued for an order.
+func (client *acmeClient) downloadCertificate(url string) (string, error) {
+	_, body, err := client.post(url, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download ACME certificate: %v", err)
+	}
+	return string(body), nil
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+// Properties of the go-vtm types which do not exist in older REST API
+// versions, keyed by API version, go-vtm type and field path, with the
+// Terraform attribute each one is exposed as. Derived from the vendored
+// go-vtm packages for each version.
+var apiVersionUnsupportedFields = map[string]map[string]map[string]string{
+	"5.2": {
+		"CacheIpSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheJ2EeSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheUniSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"GlbService": {
+			"Basic.OptimisticLocationHealth": "",
+		},
+		"GlobalSettings": {
+			"Admin.SupportTls13":                         "admin_support_tls1_3",
+			"FaultTolerance.MulticastVersion":            "",
+			"Gce.ActionTimeout":                          "",
+			"RemoteLicensing.CommChannelEnabled":         "remote_licensing_comm_channel_enabled",
+			"RemoteLicensing.CommChannelPort":            "remote_licensing_comm_channel_port",
+			"RemoteLicensing.ServerCertificateSecondary": "",
+			"RestApi.Maxfds":                             "rest_api_maxfds",
+			"Session.IpCacheExpiry":                      "session_ip_cache_expiry",
+			"Session.J2EeCacheExpiry":                    "session_j2ee_cache_expiry",
+			"Session.UniversalCacheExpiry":               "session_universal_cache_expiry",
+			"Ssl.LogKeys":                                "ssl_log_keys",
+			"Ssl.MiddleboxCompatibility":                 "ssl_middlebox_compatibility",
+			"Ssl.SupportTls13":                           "ssl_support_tls1_3",
+			"Telemetry.AutotestSchedule":                 "telemetry_autotest_schedule",
+			"Watchdog.Timeout":                           "watchdog_timeout",
+		},
+		"GlobalsStatistics": {
+			"Statistics.SslHandshakeTlsv13": "ssl_handshake_tlsv13",
+		},
+		"Persistence": {
+			"Basic.TransparentAlwaysSetCookie": "transparent_always_set_cookie",
+			"Basic.TransparentDirectives":      "transparent_directives",
+		},
+		"Pool": {
+			"Connection.MaxTransactionsPerNode": "connection_max_transactions_per_node",
+			"Ssl.MiddleboxCompatibility":        "ssl_middlebox_compatibility",
+			"Ssl.SslFixedClientCertificate":     "ssl_ssl_fixed_client_certificate",
+			"Ssl.SupportTls13":                  "ssl_support_tls1_3",
+		},
+		"PoolStatistics": {
+			"Statistics.Http1XxResponses": "http1xx_responses",
+			"Statistics.Http2XxResponses": "http2xx_responses",
+			"Statistics.Http3XxResponses": "http3xx_responses",
+			"Statistics.Http4XxResponses": "http4xx_responses",
+			"Statistics.Http503Retries":   "http503_retries",
+			"Statistics.Http5XxResponses": "http5xx_responses",
+		},
+		"SystemInformation": {
+			"Information.Platform": "information_platform",
+		},
+		"TrafficManager": {
+			"Appliance.Dnscache":                     "appliance_dnscache",
+			"Appliance.Dnssec":                       "appliance_dnssec",
+			"Appliance.Managegceroutes":              "appliance_managegceroutes",
+			"Appliance.Manageresolved":               "",
+			"Basic.CommunityEditionAccepted":         "",
+			"RemoteLicensing.IsTemplate":             "",
+			"RemoteLicensing.RegistrationIdentifier": "",
+			"Soap.MaxRequests":                       "",
+		},
+		"VirtualServer": {
+			"Basic.ProxyProtocolOptional": "",
+			"Sip.UdpAssociateBySource":    "sip_udp_associate_by_source",
+			"Ssl.SupportTls13":            "ssl_support_tls1_3",
+		},
+		"VirtualServerStatistics": {
+			"Statistics.Http1XxResponses":          "http1xx_responses",
+			"Statistics.Http2XxResponses":          "http2xx_responses",
+			"Statistics.Http3XxResponses":          "http3xx_responses",
+			"Statistics.Http4XxResponses":          "http4xx_responses",
+			"Statistics.Http5XxResponses":          "http5xx_responses",
+			"Statistics.HttpCache2XxResponses":     "http_cache2xx_responses",
+			"Statistics.HttpCache3XxResponses":     "http_cache3xx_responses",
+			"Statistics.HttpCache4XxResponses":     "http_cache4xx_responses",
+			"Statistics.HttpCache5XxResponses":     "http_cache5xx_responses",
+			"Statistics.HttpGenerated2XxResponses": "http_generated2xx_responses",
+			"Statistics.HttpGenerated3XxResponses": "http_generated3xx_responses",
+			"Statistics.HttpGenerated4XxResponses": "http_generated4xx_responses",
+			"Statistics.HttpGenerated5XxResponses": "http_generated5xx_responses",
+			"Statistics.HttpServer1XxResponses":    "http_server1xx_responses",
+			"Statistics.HttpServer2XxResponses":    "http_server2xx_responses",
+			"Statistics.HttpServer3XxResponses":    "http_server3xx_responses",
+			"Statistics.HttpServer4XxResponses":    "http_server4xx_responses",
+			"Statistics.HttpServer5XxResponses":    "http_server5xx_responses",
+			"Statistics.SslHelloRetryRequested":    "ssl_hello_retry_requested",
+		},
+	},
+	"6.0": {
+		"CacheIpSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheJ2EeSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheUniSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"GlobalSettings": {
+			"Admin.SupportTls13":                         "admin_support_tls1_3",
+			"FaultTolerance.MulticastVersion":            "",
+			"Gce.ActionTimeout":                          "",
+			"RemoteLicensing.CommChannelEnabled":         "remote_licensing_comm_channel_enabled",
+			"RemoteLicensing.CommChannelPort":            "remote_licensing_comm_channel_port",
+			"RemoteLicensing.ServerCertificateSecondary": "",
+			"RestApi.Maxfds":                             "rest_api_maxfds",
+			"Session.IpCacheExpiry":                      "session_ip_cache_expiry",
+			"Session.J2EeCacheExpiry":                    "session_j2ee_cache_expiry",
+			"Session.UniversalCacheExpiry":               "session_universal_cache_expiry",
+			"Ssl.LogKeys":                                "ssl_log_keys",
+			"Ssl.MiddleboxCompatibility":                 "ssl_middlebox_compatibility",
+			"Ssl.SupportTls13":                           "ssl_support_tls1_3",
+			"Telemetry.AutotestSchedule":                 "telemetry_autotest_schedule",
+			"Watchdog.Timeout":                           "watchdog_timeout",
+		},
+		"GlobalsStatistics": {
+			"Statistics.SslHandshakeTlsv13": "ssl_handshake_tlsv13",
+		},
+		"Persistence": {
+			"Basic.TransparentAlwaysSetCookie": "transparent_always_set_cookie",
+			"Basic.TransparentDirectives":      "transparent_directives",
+		},
+		"Pool": {
+			"Connection.MaxTransactionsPerNode": "connection_max_transactions_per_node",
+			"Ssl.MiddleboxCompatibility":        "ssl_middlebox_compatibility",
+			"Ssl.SslFixedClientCertificate":     "ssl_ssl_fixed_client_certificate",
+			"Ssl.SupportTls13":                  "ssl_support_tls1_3",
+		},
+		"SystemInformation": {
+			"Information.Platform": "information_platform",
+		},
+		"TrafficManager": {
+			"Appliance.Managegceroutes":              "appliance_managegceroutes",
+			"Appliance.Manageresolved":               "",
+			"Basic.CommunityEditionAccepted":         "",
+			"RemoteLicensing.IsTemplate":             "",
+			"RemoteLicensing.RegistrationIdentifier": "",
+			"Soap.MaxRequests":                       "",
+		},
+		"VirtualServer": {
+			"Sip.UdpAssociateBySource": "sip_udp_associate_by_source",
+			"Ssl.SupportTls13":         "ssl_support_tls1_3",
+		},
+		"VirtualServerStatistics": {
+			"Statistics.Http1XxResponses":          "http1xx_responses",
+			"Statistics.Http2XxResponses":          "http2xx_responses",
+			"Statistics.Http3XxResponses":          "http3xx_responses",
+			"Statistics.Http4XxResponses":          "http4xx_responses",
+			"Statistics.Http5XxResponses":          "http5xx_responses",
+			"Statistics.HttpCache2XxResponses":     "http_cache2xx_responses",
+			"Statistics.HttpCache3XxResponses":     "http_cache3xx_responses",
+			"Statistics.HttpCache4XxResponses":     "http_cache4xx_responses",
+			"Statistics.HttpCache5XxResponses":     "http_cache5xx_responses",
+			"Statistics.HttpGenerated2XxResponses": "http_generated2xx_responses",
+			"Statistics.HttpGenerated3XxResponses": "http_generated3xx_responses",
+			"Statistics.HttpGenerated4XxResponses": "http_generated4xx_responses",
+			"Statistics.HttpGenerated5XxResponses": "http_generated5xx_responses",
+			"Statistics.HttpServer1XxResponses":    "http_server1xx_responses",
+			"Statistics.HttpServer2XxResponses":    "http_server2xx_responses",
+			"Statistics.HttpServer3XxResponses":    "http_server3xx_responses",
+			"Statistics.HttpServer4XxResponses":    "http_server4xx_responses",
+			"Statistics.HttpServer5XxResponses":    "http_server5xx_responses",
+			"Statistics.SslHelloRetryRequested":    "ssl_hello_retry_requested",
+		},
+	},
+	"6.1": {
+		"CacheIpSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheJ2EeSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheUniSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"GlobalSettings": {
+			"Gce.ActionTimeout":                          "",
+			"RemoteLicensing.CommChannelEnabled":         "remote_licensing_comm_channel_enabled",
+			"RemoteLicensing.CommChannelPort":            "remote_licensing_comm_channel_port",
+			"RemoteLicensing.ServerCertificateSecondary": "",
+			"RestApi.Maxfds":                             "rest_api_maxfds",
+			"Session.IpCacheExpiry":                      "session_ip_cache_expiry",
+			"Session.J2EeCacheExpiry":                    "session_j2ee_cache_expiry",
+			"Session.UniversalCacheExpiry":               "session_universal_cache_expiry",
+			"Ssl.MiddleboxCompatibility":                 "ssl_middlebox_compatibility",
+			"Watchdog.Timeout":                           "watchdog_timeout",
+		},
+		"Pool": {
+			"Connection.MaxTransactionsPerNode": "connection_max_transactions_per_node",
+			"Ssl.MiddleboxCompatibility":        "ssl_middlebox_compatibility",
+			"Ssl.SslFixedClientCertificate":     "ssl_ssl_fixed_client_certificate",
+			"Ssl.SupportTls13":                  "ssl_support_tls1_3",
+		},
+		"SystemInformation": {
+			"Information.Platform": "information_platform",
+		},
+		"TrafficManager": {
+			"Appliance.Managegceroutes":              "appliance_managegceroutes",
+			"Appliance.Manageresolved":               "",
+			"RemoteLicensing.IsTemplate":             "",
+			"RemoteLicensing.RegistrationIdentifier": "",
+			"Soap.MaxRequests":                       "",
+		},
+		"VirtualServer": {
+			"Sip.UdpAssociateBySource": "sip_udp_associate_by_source",
+		},
+	},
+	"6.2": {
+		"CacheIpSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheJ2EeSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"CacheUniSessionCacheStatistics": {
+			"Statistics.Expiries": "expiries",
+		},
+		"GlobalSettings": {
+			"Session.IpCacheExpiry":        "session_ip_cache_expiry",
+			"Session.J2EeCacheExpiry":      "session_j2ee_cache_expiry",
+			"Session.UniversalCacheExpiry": "session_universal_cache_expiry",
+		},
+		"VirtualServer": {
+			"Sip.UdpAssociateBySource": "sip_udp_associate_by_source",
+		},
+	},
+}
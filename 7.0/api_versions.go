@@ -0,0 +1,190 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// Objects are always built from the newest go-vtm types, whatever REST API
+// version was negotiated with the vTM. Before an object is written to an older
+// vTM, properties which that version does not know about are dropped (or
+// rejected, if they have been configured), and after one is read back they are
+// filled in from the Terraform state so that they do not look like missing
+// fields or produce spurious diffs.
+
+func getUnsupportedFields(tm interface{}, object interface{}) map[string]string {
+	objectType := reflect.TypeOf(object)
+	for objectType.Kind() == reflect.Ptr {
+		objectType = objectType.Elem()
+	}
+	version := tm.(*vtm.VirtualTrafficManager).ApiVersion()
+	return apiVersionUnsupportedFields[version][objectType.Name()]
+}
+
+func getRequiredApiVersion(typeName, path string) string {
+	for _, version := range vtm.SupportedApiVersions {
+		if _, unsupported := apiVersionUnsupportedFields[version][typeName][path]; !unsupported {
+			return version
+		}
+	}
+	return vtm.DefaultApiVersion
+}
+
+func getFieldByPath(object reflect.Value, path string) reflect.Value {
+	for _, part := range strings.Split(path, ".") {
+		for object.Kind() == reflect.Ptr {
+			if object.IsNil() {
+				return reflect.Value{}
+			}
+			object = object.Elem()
+		}
+		if object.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		object = object.FieldByName(part)
+		if !object.IsValid() {
+			return object
+		}
+	}
+	return object
+}
+
+func setFieldFromTerraform(field reflect.Value, value interface{}) {
+	switch field.Kind() {
+	case reflect.String, reflect.Int, reflect.Bool, reflect.Float64:
+		terraformValue := reflect.ValueOf(value)
+		if terraformValue.IsValid() && terraformValue.Type().ConvertibleTo(field.Type()) {
+			field.Set(terraformValue.Convert(field.Type()))
+		}
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		switch values := value.(type) {
+		case []interface{}:
+			field.Set(reflect.ValueOf(expandStringList(values)))
+		case *schema.Set:
+			field.Set(reflect.ValueOf(expandStringSet(values)))
+		}
+	}
+}
+
+func isDefaultFieldValue(field reflect.Value, fieldSchema *schema.Schema) bool {
+	if field.IsNil() {
+		return true
+	}
+	value := field.Elem()
+	if value.IsZero() || (value.Kind() == reflect.Slice && value.Len() == 0) {
+		return true
+	}
+	if fieldSchema != nil && fieldSchema.Default != nil {
+		defaultValue := reflect.ValueOf(fieldSchema.Default)
+		if defaultValue.Type().ConvertibleTo(value.Type()) {
+			return reflect.DeepEqual(defaultValue.Convert(value.Type()).Interface(), value.Interface())
+		}
+	}
+	return false
+}
+
+func getAttributeSchema(resourceSchema map[string]*schema.Schema, attribute string) *schema.Schema {
+	parts := strings.SplitN(attribute, ".", 2)
+	attributeSchema, ok := resourceSchema[parts[0]]
+	if !ok || len(parts) == 1 {
+		return attributeSchema
+	}
+	if table, ok := attributeSchema.Elem.(*schema.Resource); ok {
+		return table.Schema[parts[1]]
+	}
+	return nil
+}
+
+func stripFields(object reflect.Value, typeName string, fields map[string]string, resourceSchema map[string]*schema.Schema, unsupported map[string]string) {
+	for path, attribute := range fields {
+		field := getFieldByPath(object, path)
+		if !field.IsValid() || field.Kind() != reflect.Ptr {
+			continue
+		}
+		if isDefaultFieldValue(field, getAttributeSchema(resourceSchema, attribute)) {
+			field.Set(reflect.Zero(field.Type()))
+			continue
+		}
+		if attribute == "" {
+			attribute = path
+		}
+		unsupported[attribute] = getRequiredApiVersion(typeName, path)
+	}
+}
+
+func stripTableRows(object reflect.Value, resourceSchema map[string]*schema.Schema, version string, unsupported map[string]string) {
+	for object.Kind() == reflect.Ptr {
+		if object.IsNil() {
+			return
+		}
+		object = object.Elem()
+	}
+	switch object.Kind() {
+	case reflect.Struct:
+		for i := 0; i < object.NumField(); i++ {
+			if object.Type().Field(i).PkgPath == "" {
+				stripTableRows(object.Field(i), resourceSchema, version, unsupported)
+			}
+		}
+	case reflect.Slice:
+		rowType := object.Type().Elem()
+		if rowType.Kind() != reflect.Struct {
+			return
+		}
+		rowFields := apiVersionUnsupportedFields[version][rowType.Name()]
+		for i := 0; i < object.Len(); i++ {
+			stripFields(object.Index(i), rowType.Name(), rowFields, resourceSchema, unsupported)
+		}
+	}
+}
+
+/*
+stripUnsupportedFields prepares an object to be written to a vTM using an older REST API version.
+Unsupported properties left at their default values are removed from the object; an error listing
+every unsupported property that has been given a non-default value is returned otherwise.
+*/
+func stripUnsupportedFields(tm interface{}, object interface{}, resourceSchema map[string]*schema.Schema) error {
+	version := tm.(*vtm.VirtualTrafficManager).ApiVersion()
+	typeName := reflect.TypeOf(object).Elem().Name()
+	unsupported := make(map[string]string)
+	stripFields(reflect.ValueOf(object), typeName, getUnsupportedFields(tm, object), resourceSchema, unsupported)
+	stripTableRows(reflect.ValueOf(object), resourceSchema, version, unsupported)
+	if len(unsupported) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(unsupported))
+	for attribute, requiredVersion := range unsupported {
+		messages = append(messages, fmt.Sprintf("'%s' requires REST API version %s or later", attribute, requiredVersion))
+	}
+	sort.Strings(messages)
+	return fmt.Errorf("Unsupported on REST API version %s: %s", version, strings.Join(messages, "; "))
+}
+
+/*
+fillUnsupportedFields populates properties of an object read from a vTM using an older REST API
+version which that version does not return, taking their values from the Terraform state.
+*/
+func fillUnsupportedFields(d *schema.ResourceData, tm interface{}, object interface{}) {
+	for path, attribute := range getUnsupportedFields(tm, object) {
+		field := getFieldByPath(reflect.ValueOf(object), path)
+		if !field.IsValid() || field.Kind() != reflect.Ptr || !field.IsNil() {
+			continue
+		}
+		value := reflect.New(field.Type().Elem())
+		if attribute != "" && !strings.Contains(attribute, ".") {
+			setFieldFromTerraform(value.Elem(), d.Get(attribute))
+		}
+		field.Set(value)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func getVersionedTestVtm(t *testing.T, offered, requested string) *vtm.VirtualTrafficManager {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		children := []string{}
+		for _, version := range strings.Split(offered, ",") {
+			children = append(children, `{"name":"`+version+`","href":"/api/tm/`+version+`/"}`)
+		}
+		w.Write([]byte(`{"children":[` + strings.Join(children, ",") + `]}`))
+	}))
+	t.Cleanup(server.Close)
+	tm, contactable, contactErr := vtm.NewVirtualTrafficManager(server.URL+"/api", "admin", "password", false, false)
+	if contactable != true {
+		t.Fatalf("Failed to contact test server: %v", contactErr)
+	}
+	if _, err := tm.NegotiateApiVersion(requested); err != nil {
+		t.Fatalf("Failed to negotiate API version: %v", err)
+	}
+	return tm
+}
+
+func TestNegotiateApiVersion(t *testing.T) {
+	tables := []struct {
+		offered   string
+		requested string
+		result    string
+	}{
+		{"3.8,4.0,5.2,6.0,6.1,6.2,7.0", "", "7.0"},
+		{"3.8,4.0,5.2,6.0,6.1,6.2", "", "6.2"},
+		{"6.2,7.0,8.0", "", "7.0"},
+		{"5.2,6.0,6.1,6.2,7.0", "6.0", "6.0"},
+	}
+
+	for _, table := range tables {
+		tm := getVersionedTestVtm(t, table.offered, table.requested)
+		if tm.ApiVersion() != table.result {
+			t.Errorf("Negotiation failed: %s/%s -> %s", table.offered, table.requested, tm.ApiVersion())
+		}
+	}
+}
+
+func TestStripUnsupportedFields(t *testing.T) {
+	tm := getVersionedTestVtm(t, "6.1,6.2", "6.1")
+
+	pool := tm.NewPool("pool")
+	pool.Ssl.SupportTls13 = getStringAddr("use_default")
+	pool.Ssl.CipherSuites = getStringAddr("SSL_RSA_WITH_AES_128_CBC_SHA")
+	if err := stripUnsupportedFields(tm, pool, getResourcePoolSchema()); err != nil {
+		t.Fatalf("Default value rejected: %v", err)
+	}
+	if pool.Ssl.SupportTls13 != nil {
+		t.Errorf("Unsupported field with default value was not removed")
+	}
+	if pool.Ssl.CipherSuites == nil {
+		t.Errorf("Supported field was removed")
+	}
+
+	pool.Ssl.SupportTls13 = getStringAddr("enabled")
+	err := stripUnsupportedFields(tm, pool, getResourcePoolSchema())
+	if err == nil || !strings.Contains(err.Error(), "'ssl_support_tls1_3' requires REST API version 6.2") {
+		t.Errorf("Configured unsupported field not reported: %v", err)
+	}
+}
+
+func TestFillUnsupportedFields(t *testing.T) {
+	tm := getVersionedTestVtm(t, "6.1", "")
+
+	d := schema.TestResourceDataRaw(t, getResourcePoolSchema(), map[string]interface{}{"name": "pool"})
+	pool := tm.NewPool("pool")
+	fillUnsupportedFields(d, tm, pool)
+	if pool.Ssl.SupportTls13 == nil || *pool.Ssl.SupportTls13 != "use_default" {
+		t.Errorf("Unsupported field not filled from schema default: %v", pool.Ssl.SupportTls13)
+	}
+	if pool.Ssl.CipherSuites != nil {
+		t.Errorf("Supported field was filled")
+	}
+}
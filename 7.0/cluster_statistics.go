@@ -0,0 +1,254 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * Statistics are kept separately by each traffic manager in a cluster, and the
+ * REST API returns those of the traffic manager that the provider's base_url
+ * points at unless another member is named in the request. Every statistics
+ * data source is wrapped by clusterStatistics, which adds:
+ *
+ *   - traffic_manager, to read the statistics of a specific cluster member
+ *   - aggregate, to read the statistics of every cluster member and combine
+ *     them, with the values from each member listed in per_traffic_manager
+ *
+ * When aggregating, counters are summed, except for maximums, minimums and
+ * means, which are combined as such, percentages and rates, which are
+ * averaged, and the identifiers, times and gauges listed in
+ * clusterStatisticsCombinations. String values are reported as-is when every
+ * member agrees, and otherwise as a comma-separated list of the distinct
+ * values. Numeric identifiers, such as ports, are reported as-is when every
+ * member agrees, and otherwise are left unset, with the comma-separated list
+ * of their distinct values in distinct_values.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+var clusterStatisticsAttributes = map[string]bool{
+	"name":                true,
+	"traffic_manager":     true,
+	"aggregate":           true,
+	"per_traffic_manager": true,
+	"distinct_values":     true,
+}
+
+/*
+The numeric statistics which must not be summed when aggregating, and how they are combined
+instead: "same" for identifiers, which every member should report alike, "max" for times and ages,
+"mean" for gauges and "min" for flags, whose lowest value is the worst (1 is not OK, 2 is OK).
+*/
+var clusterStatisticsCombinations = map[string]string{
+	"port":                    "same",
+	"node_port":               "same",
+	"time":                    "max",
+	"up_time":                 "max",
+	"time_last_config_update": "max",
+	"last_refusal_time":       "max",
+	"oldest":                  "max",
+	"load":                    "mean",
+	"conforming":              "mean",
+	"is_o_k":                  "min",
+}
+
+// Statistics whose names end in these are averaged when aggregating, as percentages and rates.
+var clusterStatisticsMeanSuffixes = []string{"_percent", "_rate"}
+
+func clusterStatistics(dataSource *schema.Resource) *schema.Resource {
+	// The name of the cluster member to read statistics from, as listed
+	//  by vtm_traffic_manager_list. Defaults to the traffic manager that
+	//  base_url points at.
+	dataSource.Schema["traffic_manager"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"aggregate"},
+	}
+
+	// Combine the statistics of every traffic manager in the cluster.
+	dataSource.Schema["aggregate"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+
+	// The statistics of each traffic manager that was aggregated.
+	dataSource.Schema["per_traffic_manager"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"traffic_manager": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"statistics": &schema.Schema{
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+
+	// The distinct values, comma-separated, of each numeric identifier
+	//  that differs between the traffic managers that were aggregated.
+	dataSource.Schema["distinct_values"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	dataSource.Read = clusterStatisticsRead(dataSource, dataSource.Read)
+	return dataSource
+}
+
+func clusterStatisticsRead(dataSource *schema.Resource, read schema.ReadFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, tm interface{}) error {
+		localTm := tm.(*vtm.VirtualTrafficManager)
+		if d.Get("aggregate") != true {
+			d.Set("per_traffic_manager", nil)
+			d.Set("distinct_values", nil)
+			return read(d, localTm.ForTrafficManager(d.Get("traffic_manager").(string)))
+		}
+
+		members, err := localTm.ListTrafficManagers()
+		if err != nil {
+			return fmt.Errorf("Failed to list traffic managers: %v", err.ErrorText)
+		}
+		sort.Strings(*members)
+		values := make(map[string][]interface{})
+		breakdown := make([]map[string]interface{}, 0, len(*members))
+		objectId := ""
+		for _, member := range *members {
+			memberData := dataSource.Data(nil)
+			if name, ok := d.GetOk("name"); ok {
+				memberData.Set("name", name)
+			}
+			if readErr := read(memberData, localTm.ForTrafficManager(member)); readErr != nil {
+				return fmt.Errorf("Failed to read statistics from traffic manager '%s': %v", member, readErr)
+			}
+			// The object has no statistics on this traffic manager.
+			if memberData.Id() == "" {
+				continue
+			}
+			objectId = memberData.Id()
+			memberStatistics := make(map[string]interface{})
+			for attribute := range dataSource.Schema {
+				if clusterStatisticsAttributes[attribute] {
+					continue
+				}
+				value := memberData.Get(attribute)
+				values[attribute] = append(values[attribute], value)
+				memberStatistics[attribute] = fmt.Sprintf("%v", value)
+			}
+			breakdown = append(breakdown, map[string]interface{}{
+				"traffic_manager": member,
+				"statistics":      memberStatistics,
+			})
+		}
+		if objectId == "" {
+			d.SetId("")
+			return nil
+		}
+		distinctValues := make(map[string]interface{})
+		for attribute, attributeValues := range values {
+			if _, isString := attributeValues[0].(string); !isString && clusterStatisticsCombinations[attribute] == "same" {
+				if distinct := getDistinctStatistics(attributeValues); len(distinct) > 1 {
+					distinctValues[attribute] = strings.Join(distinct, ",")
+					d.Set(attribute, nil)
+					continue
+				}
+			}
+			d.Set(attribute, aggregateStatistic(attribute, attributeValues))
+		}
+		d.Set("distinct_values", distinctValues)
+		d.Set("per_traffic_manager", breakdown)
+		d.SetId(objectId)
+		return nil
+	}
+}
+
+/*
+aggregateStatistic combines the values of a statistic from several traffic managers. Statistics in
+clusterStatisticsCombinations are combined as listed there, with identifiers taking the first
+member's value. Otherwise, the way that numbers are combined depends on the words in the statistic's
+name: "max" and "peak" values take the maximum, "min" values the minimum, and "mean" values and
+those named with one of clusterStatisticsMeanSuffixes the mean; all other numbers are summed.
+*/
+func aggregateStatistic(attribute string, values []interface{}) interface{} {
+	if _, isString := values[0].(string); isString {
+		return strings.Join(getDistinctStatistics(values), ",")
+	}
+
+	combine, listed := clusterStatisticsCombinations[attribute]
+	if combine == "same" {
+		return values[0]
+	}
+	if !listed {
+		combine = "sum"
+		for _, word := range strings.Split(attribute, "_") {
+			switch word {
+			case "max", "maximum", "peak":
+				combine = "max"
+			case "min", "minimum":
+				if combine == "sum" {
+					combine = "min"
+				}
+			case "mean", "average", "avg":
+				if combine == "sum" {
+					combine = "mean"
+				}
+			}
+			if combine == "max" {
+				break
+			}
+		}
+		for _, suffix := range clusterStatisticsMeanSuffixes {
+			if combine == "sum" && strings.HasSuffix(attribute, suffix) {
+				combine = "mean"
+			}
+		}
+	}
+
+	result := values[0].(int)
+	for _, value := range values[1:] {
+		switch combine {
+		case "max":
+			if value.(int) > result {
+				result = value.(int)
+			}
+		case "min":
+			if value.(int) < result {
+				result = value.(int)
+			}
+		default:
+			result += value.(int)
+		}
+	}
+	if combine == "mean" {
+		result /= len(values)
+	}
+	return result
+}
+
+// getDistinctStatistics returns the distinct values of a statistic from several traffic managers, sorted.
+func getDistinctStatistics(values []interface{}) []string {
+	distinct := make([]string, 0, len(values))
+	seen := make(map[string]bool)
+	for _, value := range values {
+		text := fmt.Sprintf("%v", value)
+		if !seen[text] {
+			seen[text] = true
+			distinct = append(distinct, text)
+		}
+	}
+	sort.Strings(distinct)
+	return distinct
+}
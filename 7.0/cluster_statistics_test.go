@@ -0,0 +1,212 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Reading the statistics of a named cluster member (fake vTM only)
+ *   - Aggregating statistics across every traffic manager in the cluster (fake vTM only)
+ *   - Combining counters, maximums and strings from several traffic managers
+ *   - Reporting ports rather than summing them, listing differing ports in distinct_values,
+ *     and taking the latest of times (fake vTM only)
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+const testClusterMember = "fake-vtm-2.example.com"
+
+func TestDataSourceClusterStatistics(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test requires the fake vTM, to simulate a second traffic manager")
+	}
+	poolName := acctest.RandomWithPrefix("TestClusterStatistics")
+	tm, err := getTestVtm()
+	if err != nil {
+		t.Fatalf("Fatal error: %+v", err)
+	}
+	if _, applyErr := tm.NewTrafficManager(testClusterMember).Apply(); applyErr != nil {
+		t.Fatalf("Failed to add traffic manager %s: %#v", testClusterMember, applyErr)
+	}
+	defer tm.DeleteTrafficManager(testClusterMember)
+
+	localStatistics := new(vtm.PoolStatistics)
+	localStatistics.Statistics.BytesIn = getIntAddr(5)
+	localStatistics.Statistics.MaxQueueTime = getIntAddr(30)
+	localStatistics.Statistics.State = getStringAddr("active")
+	testFakeVtm.SetStatistics("pools/"+poolName, localStatistics)
+	defer testFakeVtm.SetStatistics("pools/"+poolName, nil)
+	memberStatistics := new(vtm.PoolStatistics)
+	memberStatistics.Statistics.BytesIn = getIntAddr(7)
+	memberStatistics.Statistics.MaxQueueTime = getIntAddr(20)
+	memberStatistics.Statistics.State = getStringAddr("nodefail")
+	testFakeVtm.SetTrafficManagerStatistics(testClusterMember, "pools/"+poolName, memberStatistics)
+	defer testFakeVtm.SetTrafficManagerStatistics(testClusterMember, "pools/"+poolName, nil)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: getDataSourceClusterStatisticsConfig(poolName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.local", "bytes_in", "5"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.local", "per_traffic_manager.#", "0"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.member", "bytes_in", "7"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.member", "state", "nodefail"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.cluster", "bytes_in", "12"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.cluster", "max_queue_time", "30"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.cluster", "state", "active,nodefail"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.cluster", "per_traffic_manager.#", "2"),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.cluster", "per_traffic_manager.0.traffic_manager", testClusterMember),
+					resource.TestCheckResourceAttr("data.vtm_pool_stats.cluster", "per_traffic_manager.0.statistics.bytes_in", "7"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceClusterStatisticsPortsAndTimes(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test requires the fake vTM, to simulate a second traffic manager")
+	}
+	objName := acctest.RandomWithPrefix("TestClusterStatisticsPortsAndTimes")
+	tm, err := getTestVtm()
+	if err != nil {
+		t.Fatalf("Fatal error: %+v", err)
+	}
+	if _, applyErr := tm.NewTrafficManager(testClusterMember).Apply(); applyErr != nil {
+		t.Fatalf("Failed to add traffic manager %s: %#v", testClusterMember, applyErr)
+	}
+	defer tm.DeleteTrafficManager(testClusterMember)
+
+	for member, port := range map[string]int{"": 8080, testClusterMember: 8081} {
+		virtualServerStatistics := new(vtm.VirtualServerStatistics)
+		virtualServerStatistics.Statistics.Port = getIntAddr(80)
+		virtualServerStatistics.Statistics.TotalRequests = getIntAddr(port - 8000)
+		nodeStatistics := new(vtm.NodesNodeStatistics)
+		nodeStatistics.Statistics.Port = getIntAddr(port)
+		globalsStatistics := new(vtm.GlobalsStatistics)
+		globalsStatistics.Statistics.UpTime = getIntAddr(port * 10)
+		globalsStatistics.Statistics.TimeLastConfigUpdate = getIntAddr(port)
+		if member == "" {
+			testFakeVtm.SetStatistics("virtual_servers/"+objName, virtualServerStatistics)
+			defer testFakeVtm.SetStatistics("virtual_servers/"+objName, nil)
+			testFakeVtm.SetStatistics("nodes/node/"+objName, nodeStatistics)
+			defer testFakeVtm.SetStatistics("nodes/node/"+objName, nil)
+			testFakeVtm.SetStatistics("globals", globalsStatistics)
+			defer testFakeVtm.SetStatistics("globals", nil)
+		} else {
+			testFakeVtm.SetTrafficManagerStatistics(member, "virtual_servers/"+objName, virtualServerStatistics)
+			defer testFakeVtm.SetTrafficManagerStatistics(member, "virtual_servers/"+objName, nil)
+			testFakeVtm.SetTrafficManagerStatistics(member, "nodes/node/"+objName, nodeStatistics)
+			defer testFakeVtm.SetTrafficManagerStatistics(member, "nodes/node/"+objName, nil)
+			testFakeVtm.SetTrafficManagerStatistics(member, "globals", globalsStatistics)
+			defer testFakeVtm.SetTrafficManagerStatistics(member, "globals", nil)
+		}
+	}
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: getDataSourceClusterStatisticsPortsAndTimesConfig(objName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vtm_virtual_server_stats.cluster", "port", "80"),
+					resource.TestCheckResourceAttr("data.vtm_virtual_server_stats.cluster", "total_requests", "161"),
+					resource.TestCheckResourceAttr("data.vtm_virtual_server_stats.cluster", "distinct_values.%", "0"),
+					resource.TestCheckResourceAttr("data.vtm_nodes_node_stats.cluster", "port", "0"),
+					resource.TestCheckResourceAttr("data.vtm_nodes_node_stats.cluster", "distinct_values.%", "1"),
+					resource.TestCheckResourceAttr("data.vtm_nodes_node_stats.cluster", "distinct_values.port", "8080,8081"),
+					resource.TestCheckResourceAttr("data.vtm_globals_stats.cluster", "up_time", "80810"),
+					resource.TestCheckResourceAttr("data.vtm_globals_stats.cluster", "time_last_config_update", "8081"),
+				),
+			},
+		},
+	})
+}
+
+func TestAggregateStatistic(t *testing.T) {
+	for _, test := range []struct {
+		attribute string
+		values    []interface{}
+		expected  interface{}
+	}{
+		{"total_conn", []interface{}{1, 2, 3}, 6},
+		{"max_conn", []interface{}{1, 3, 2}, 3},
+		{"hourly_peak_requests_per_second", []interface{}{4, 9}, 9},
+		{"min_queue_time", []interface{}{4, 2, 3}, 2},
+		{"max_rate_per_min", []interface{}{4, 9}, 9},
+		{"mean_queue_time", []interface{}{2, 4}, 3},
+		{"port", []interface{}{80, 80, 80}, 80},
+		{"node_port", []interface{}{443, 443}, 443},
+		{"up_time", []interface{}{100, 300, 200}, 300},
+		{"time_last_config_update", []interface{}{50, 20}, 50},
+		{"last_refusal_time", []interface{}{5, 7}, 7},
+		{"sys_cpu_busy_percent", []interface{}{50, 50, 50}, 50},
+		{"sys_cpu_idle_percent", []interface{}{40, 60}, 50},
+		{"sys_cpu_system_busy_percent", []interface{}{10, 20, 30}, 20},
+		{"sys_cpu_user_busy_percent", []interface{}{30, 50}, 40},
+		{"hit_rate", []interface{}{80, 90}, 85},
+		{"http_cache_hit_rate", []interface{}{20, 40, 60}, 40},
+		{"current_rate", []interface{}{100, 300}, 200},
+		{"max_rate_per_sec", []interface{}{100, 300}, 300},
+		{"oldest", []interface{}{30, 90, 60}, 90},
+		{"load", []interface{}{2, 4}, 3},
+		{"conforming", []interface{}{100, 90}, 95},
+		{"is_o_k", []interface{}{2, 1, 2}, 1},
+		{"is_o_k", []interface{}{2, 2}, 2},
+		{"state", []interface{}{"ok", "ok"}, "ok"},
+		{"state", []interface{}{"ok", "error", "ok"}, "error,ok"},
+	} {
+		if result := aggregateStatistic(test.attribute, test.values); result != test.expected {
+			t.Errorf("aggregateStatistic(%s, %v) = %v, expected %v", test.attribute, test.values, result, test.expected)
+		}
+	}
+}
+
+func getDataSourceClusterStatisticsConfig(poolName string) string {
+	return fmt.Sprintf(`
+		data "vtm_pool_stats" "local" {
+			name = "%s"
+		}
+
+		data "vtm_pool_stats" "member" {
+			name = "%s"
+			traffic_manager = "%s"
+		}
+
+		data "vtm_pool_stats" "cluster" {
+			name = "%s"
+			aggregate = true
+		}`,
+		poolName, poolName, testClusterMember, poolName,
+	)
+}
+
+func getDataSourceClusterStatisticsPortsAndTimesConfig(objName string) string {
+	return fmt.Sprintf(`
+		data "vtm_virtual_server_stats" "cluster" {
+			name = "%s"
+			aggregate = true
+		}
+
+		data "vtm_nodes_node_stats" "cluster" {
+			name = "%s"
+			aggregate = true
+		}
+
+		data "vtm_globals_stats" "cluster" {
+			aggregate = true
+		}`,
+		objName, objName,
+	)
+}
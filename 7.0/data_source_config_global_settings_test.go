@@ -25,7 +25,7 @@ var testGlobalSettingsAdminSsl3AllowRehandshakeValue *string
 var testGlobalSettingsGlbVerboseValue *bool
 
 func TestDataSourceConfigGlobalSettings(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: rollbackDataSourceConfigGlobalSettingsConfig,
@@ -0,0 +1,63 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_config_object reads any configuration object by its REST path relative
+ * to config/active, as a JSON properties document for a standard object or
+ * as its content for a text object.
+ */
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func dataSourceConfigObject() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConfigObjectRead,
+		Schema: map[string]*schema.Schema{
+
+			// The path of the object relative to config/active, for example
+			//  "ssl/admin_cas/my-ca".
+			"path": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateConfigObjectPath,
+			},
+
+			// JSON document holding every property of a standard object,
+			//  keyed by section and then by property name.
+			"properties": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// The content of a text object.
+			"content": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceConfigObjectRead(d *schema.ResourceData, tm interface{}) error {
+	path := d.Get("path").(string)
+	object, err := tm.(*vtm.VirtualTrafficManager).GetConfigObject(path)
+	if err != nil {
+		return fmt.Errorf("Failed to read vtm_config_object '%v': %v", path, err.ErrorText)
+	}
+	if object.Properties == nil {
+		d.Set("properties", "")
+		d.Set("content", object.Content)
+	} else {
+		d.Set("properties", formatConfigObjectProperties(object.Properties))
+		d.Set("content", "")
+	}
+	d.SetId(path)
+	return nil
+}
@@ -0,0 +1,333 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_health_gate waits until the traffic manager's state and the statistics
+ * of its pools and nodes report that a set of conditions on its pools and
+ * virtual servers hold, failing with a report of
+ * the conditions which do not once its timeout expires. Other resources can
+ * depend on it so that, for example, traffic is only moved to a new pool once
+ * enough of its nodes are active.
+ */
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+var healthGateErrorLevels = []string{"ok", "warn", "error", "fatal"}
+
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be a duration such as \"30s\" or \"5m\", got %q", k, v))
+	}
+	return
+}
+
+func dataSourceHealthGate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceHealthGateRead,
+		Schema: map[string]*schema.Schema{
+
+			// The most severe error level the traffic manager may report.
+			"max_error_level": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(healthGateErrorLevels, false),
+			},
+
+			// Pools which must have a minimum number of active nodes.
+			"pool": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						// The name of the pool.
+						"name": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+
+						// The number of nodes in the pool that must be active
+						//  and not failed.
+						"min_active_nodes": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Default:      1,
+						},
+					},
+				},
+			},
+
+			// A regular expression; no failed node may be in a pool whose
+			//  name matches it.
+			"no_failed_nodes_in_pools": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+
+			// Virtual servers which must be enabled and running, with a
+			//  minimum number of active nodes in their pool.
+			"virtual_server": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						// The name of the virtual server.
+						"name": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+
+						// The number of nodes in the virtual server's pool
+						//  that must be active and not failed.
+						"min_active_nodes": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Default:      1,
+						},
+					},
+				},
+			},
+
+			// How long to wait for the conditions to hold.
+			"timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Default:      "5m",
+			},
+
+			// How often to check the conditions, in seconds.
+			"poll_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Default:      10,
+			},
+
+			// The result of each condition at the last check.
+			"report": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getErrorLevelSeverity(errorLevel string) int {
+	for i, level := range healthGateErrorLevels {
+		if level == errorLevel {
+			return i
+		}
+	}
+	return len(healthGateErrorLevels)
+}
+
+/*
+checkHealthGate evaluates the conditions of a vtm_health_gate against the current state of the
+traffic manager, returning a line describing each condition and whether all of them held. A pool
+must also be active in its statistics, and only its active nodes which the node statistics report
+as alive are counted.
+*/
+func checkHealthGate(d *schema.ResourceData, tm *vtm.VirtualTrafficManager) ([]string, bool, error) {
+	systemState, err := tm.GetSystemState()
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to read the traffic manager state: %v", err.ErrorText)
+	}
+	state := systemState.State
+	report := []string{}
+	healthy := true
+	check := func(ok bool, format string, args ...interface{}) {
+		result := "ok"
+		if !ok {
+			result = "FAILED"
+			healthy = false
+		}
+		report = append(report, fmt.Sprintf("%s: %s", result, fmt.Sprintf(format, args...)))
+	}
+
+	activeNodes := make(map[string][]string)
+	if state.Pools != nil {
+		for _, pool := range *state.Pools {
+			if pool.Name != nil && pool.ActiveNodes != nil {
+				activeNodes[*pool.Name] = *pool.ActiveNodes
+			}
+		}
+	}
+	// describePool checks a pool's statistics, and those of its active nodes, describing the
+	// pool's state and how many of its nodes are active and alive.
+	describePool := func(name string, minActive int) (bool, string, error) {
+		poolStatistics, err := tm.GetPoolStatistics(name)
+		if err != nil {
+			if err.ErrorId != "resource.not_found" {
+				return false, "", fmt.Errorf("Failed to read the statistics of pool '%s': %v", name, err.ErrorText)
+			}
+			return false, fmt.Sprintf("pool '%s' has no statistics", name), nil
+		}
+		poolState := ""
+		if poolStatistics.Statistics.State != nil {
+			poolState = *poolStatistics.Statistics.State
+		}
+		alive := 0
+		notAlive := []string{}
+		for _, node := range activeNodes[name] {
+			nodeState := "not found"
+			nodeStatistics, err := tm.GetNodesNodeStatistics(node)
+			if err != nil && err.ErrorId != "resource.not_found" {
+				return false, "", fmt.Errorf("Failed to read the statistics of node '%s': %v", node, err.ErrorText)
+			}
+			if err == nil && nodeStatistics.Statistics.State != nil {
+				nodeState = *nodeStatistics.Statistics.State
+			}
+			if nodeState == "alive" {
+				alive++
+			} else {
+				notAlive = append(notAlive, fmt.Sprintf("%s is %s", node, nodeState))
+			}
+		}
+		description := fmt.Sprintf("pool '%s' is %s and has %d active nodes, at least %d required", name, poolState, alive, minActive)
+		if len(notAlive) != 0 {
+			description += fmt.Sprintf(" (%s)", strings.Join(notAlive, ", "))
+		}
+		return poolState == "active" && alive >= minActive, description, nil
+	}
+
+	if maxErrorLevel := d.Get("max_error_level").(string); maxErrorLevel != "" {
+		errorLevel := ""
+		if state.ErrorLevel != nil {
+			errorLevel = *state.ErrorLevel
+		}
+		check(getErrorLevelSeverity(errorLevel) <= getErrorLevelSeverity(maxErrorLevel),
+			"error level is '%s', at most '%s' allowed", errorLevel, maxErrorLevel)
+	}
+
+	for _, item := range d.Get("pool").([]interface{}) {
+		pool := item.(map[string]interface{})
+		ok, description, describeErr := describePool(pool["name"].(string), pool["min_active_nodes"].(int))
+		if describeErr != nil {
+			return nil, false, describeErr
+		}
+		check(ok, "%s", description)
+	}
+
+	if poolPattern := d.Get("no_failed_nodes_in_pools").(string); poolPattern != "" {
+		poolRegexp := regexp.MustCompile(poolPattern)
+		failed := []string{}
+		if state.FailedNodes != nil {
+			for _, failedNode := range *state.FailedNodes {
+				if failedNode.Node == nil || failedNode.Pools == nil {
+					continue
+				}
+				for _, pool := range *failedNode.Pools {
+					if poolRegexp.MatchString(pool) {
+						failed = append(failed, fmt.Sprintf("%s in pool '%s'", *failedNode.Node, pool))
+					}
+				}
+			}
+		}
+		if len(failed) == 0 {
+			check(true, "no failed nodes in pools matching '%s'", poolPattern)
+		} else {
+			check(false, "failed nodes in pools matching '%s': %s", poolPattern, strings.Join(failed, ", "))
+		}
+	}
+
+	for _, item := range d.Get("virtual_server").([]interface{}) {
+		virtualServer := item.(map[string]interface{})
+		name, minActive := virtualServer["name"].(string), virtualServer["min_active_nodes"].(int)
+		object, err := tm.GetVirtualServer(name)
+		if err != nil {
+			if err.ErrorId != "resource.not_found" {
+				return nil, false, fmt.Errorf("Failed to read vtm_virtual_server '%s': %v", name, err.ErrorText)
+			}
+			check(false, "virtual server '%s' does not exist", name)
+			continue
+		}
+		if object.Basic.Enabled == nil || *object.Basic.Enabled != true {
+			check(false, "virtual server '%s' is disabled", name)
+			continue
+		}
+		running := false
+		poolName := ""
+		if state.VirtualServers != nil {
+			for _, stateVirtualServer := range *state.VirtualServers {
+				if stateVirtualServer.Name != nil && *stateVirtualServer.Name == name {
+					running = true
+					if stateVirtualServer.Pool != nil {
+						poolName = *stateVirtualServer.Pool
+					}
+				}
+			}
+		}
+		if !running {
+			check(false, "virtual server '%s' is enabled but not running", name)
+			continue
+		}
+		ok, description, describeErr := describePool(poolName, minActive)
+		if describeErr != nil {
+			return nil, false, describeErr
+		}
+		check(ok, "virtual server '%s' is running and its %s", name, description)
+	}
+
+	return report, healthy, nil
+}
+
+func dataSourceHealthGateRead(d *schema.ResourceData, tm interface{}) error {
+	timeout, _ := time.ParseDuration(d.Get("timeout").(string))
+	// The last report, which is written by the waiter's goroutine and read here on a timeout
+	var lastReport []string
+	var reportMutex sync.Mutex
+	waiter := &resource.StateChangeConf{
+		Pending:      []string{"unhealthy"},
+		Target:       []string{"healthy"},
+		Timeout:      timeout,
+		PollInterval: time.Duration(d.Get("poll_interval").(int)) * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			report, healthy, err := checkHealthGate(d, tm.(*vtm.VirtualTrafficManager))
+			if err != nil {
+				return nil, "", err
+			}
+			reportMutex.Lock()
+			lastReport = report
+			reportMutex.Unlock()
+			log.Printf("[DEBUG] vtm_health_gate:\n  %s", strings.Join(report, "\n  "))
+			if healthy {
+				return report, "healthy", nil
+			}
+			return report, "unhealthy", nil
+		},
+	}
+	result, err := waiter.WaitForState()
+	if err != nil {
+		reportMutex.Lock()
+		report := lastReport
+		reportMutex.Unlock()
+		if _, isTimeout := err.(*resource.TimeoutError); isTimeout && report != nil {
+			return fmt.Errorf("vtm_health_gate conditions did not hold within %s:\n  %s", timeout, strings.Join(report, "\n  "))
+		}
+		return fmt.Errorf("Error waiting for vtm_health_gate: %v", err)
+	}
+	d.Set("report", strings.Join(result.([]string), "\n"))
+	d.SetId("health_gate")
+	return nil
+}
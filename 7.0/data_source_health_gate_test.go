@@ -0,0 +1,143 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Passing a gate on the error level, pool nodes and an enabled virtual server
+ *   - Failing with a report when a node has failed, then passing once it recovers (fake vTM only)
+ *   - Failing when the pool's statistics report it as not active, though it has enough active
+ *     nodes, or a node as not alive (fake vTM only)
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func TestDataSourceHealthGate(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestHealthGate")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: getHealthGateConfig(objName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vtm_health_gate.gate", "report", regexp.MustCompile(fmt.Sprintf("ok: pool '%s' is active and has 2 active nodes", objName))),
+					resource.TestMatchResourceAttr("data.vtm_health_gate.gate", "report", regexp.MustCompile(fmt.Sprintf("ok: virtual server '%s' is running and its pool '%s' is active", objName, objName))),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceHealthGateTimeout(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test requires the fake vTM, to simulate a failed node")
+	}
+	objName := acctest.RandomWithPrefix("TestHealthGate")
+	testFakeVtm.SetNodeFailed("192.168.0.2:80", true)
+	defer testFakeVtm.SetNodeFailed("192.168.0.2:80", false)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      getHealthGateConfig(objName, 2),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("(?s)did not hold within 2s.*FAILED: pool '%s' is active and has 1 active nodes.*FAILED: failed nodes in pools matching '.*': 192.168.0.2:80 in pool '%s'", objName, objName)),
+			},
+			{
+				PreConfig: func() { testFakeVtm.SetNodeFailed("192.168.0.2:80", false) },
+				Config:    getHealthGateConfig(objName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vtm_health_gate.gate", "report", regexp.MustCompile("ok: no failed nodes")),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceHealthGatePoolState(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test requires the fake vTM, to simulate pool and node statistics")
+	}
+	objName := acctest.RandomWithPrefix("TestHealthGate")
+	poolStatistics := new(vtm.PoolStatistics)
+	poolStatistics.Statistics.State = getStringAddr("disabled")
+	nodeStatistics := new(vtm.NodesNodeStatistics)
+	nodeStatistics.Statistics.State = getStringAddr("dead")
+	defer testFakeVtm.SetStatistics("pools/"+objName, nil)
+	defer testFakeVtm.SetStatistics("nodes/node/192.168.0.2:80", nil)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { testFakeVtm.SetStatistics("pools/"+objName, poolStatistics) },
+				Config:      getHealthGateConfig(objName, 2),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("(?s)FAILED: pool '%s' is disabled and has 2 active nodes, at least 2 required.*FAILED: virtual server '%s' is running and its pool '%s' is disabled", objName, objName, objName)),
+			},
+			{
+				PreConfig: func() {
+					testFakeVtm.SetStatistics("pools/"+objName, nil)
+					testFakeVtm.SetStatistics("nodes/node/192.168.0.2:80", nodeStatistics)
+				},
+				Config:      getHealthGateConfig(objName, 2),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`FAILED: pool '%s' is active and has 1 active nodes, at least 2 required \(192.168.0.2:80 is dead\)`, objName)),
+			},
+			{
+				PreConfig: func() { testFakeVtm.SetStatistics("nodes/node/192.168.0.2:80", nil) },
+				Config:    getHealthGateConfig(objName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vtm_health_gate.gate", "report", regexp.MustCompile(fmt.Sprintf("ok: pool '%s' is active and has 2 active nodes", objName))),
+				),
+			},
+		},
+	})
+}
+
+func getHealthGateConfig(name string, minActiveNodes int) string {
+	return fmt.Sprintf(`
+		resource "vtm_pool" "test_vtm_pool" {
+			name = "%s"
+			nodes_table {
+				node = "192.168.0.1:80"
+			}
+			nodes_table {
+				node = "192.168.0.2:80"
+			}
+		}
+
+		resource "vtm_virtual_server" "test_vtm_virtual_server" {
+			name = "%s"
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			port = 10
+			enabled = true
+		}
+
+		data "vtm_health_gate" "gate" {
+			max_error_level = "warn"
+			pool {
+				name = "${vtm_pool.test_vtm_pool.name}"
+				min_active_nodes = %d
+			}
+			no_failed_nodes_in_pools = "^TestHealthGate"
+			virtual_server {
+				name = "${vtm_virtual_server.test_vtm_virtual_server.name}"
+			}
+			timeout = "2s"
+			poll_interval = 1
+		}`,
+		name, name, minActiveNodes,
+	)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_rule_lint checks a TrafficScript rule with the same checks that vtm_rule
+ * makes when planning, without failing, so that the problems found can be
+ * inspected or used in other resources.
+ */
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceRuleLint() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRuleLintRead,
+		Schema: map[string]*schema.Schema{
+
+			// The TrafficScript to check.
+			"content": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Whether no problems other than warnings were found.
+			"valid": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// The problems found, in the order in which they appear in the
+			//  rule.
+			"problems": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						// The line of the problem, counting from one.
+						"line": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						// The column of the problem, counting from one.
+						"column": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						// A description of the problem.
+						"message": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// "error", or "warning" for a problem which may not be
+						//  a mistake, such as a call to a function which is
+						//  not in the provider's function catalogue.
+						"severity": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRuleLintRead(d *schema.ResourceData, tm interface{}) error {
+	problems := lintTrafficScript(d.Get("content").(string))
+	problemList := make([]map[string]interface{}, 0, len(problems))
+	for _, problem := range problems {
+		severity := "error"
+		if problem.Warning {
+			severity = "warning"
+		}
+		problemList = append(problemList, map[string]interface{}{
+			"line":     problem.Line,
+			"column":   problem.Column,
+			"message":  problem.Message,
+			"severity": severity,
+		})
+	}
+	errors, _ := splitTrafficScriptProblems(problems)
+	d.Set("valid", len(errors) == 0)
+	d.Set("problems", problemList)
+	d.SetId("rule_lint")
+	return nil
+}
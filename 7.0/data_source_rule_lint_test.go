@@ -0,0 +1,136 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Reporting a valid rule, and the line and column of each problem in an invalid one
+ *   - Each kind of problem found by the TrafficScript checks
+ *   - Reporting calls to unknown functions as warnings, which leave a rule valid
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceRuleLint(t *testing.T) {
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: getDataSourceRuleLintConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.valid", "valid", "true"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.valid", "problems.#", "0"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "valid", "false"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.#", "2"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.0.line", "2"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.0.column", "1"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.0.message", "unknown keyword 'fi'"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.0.severity", "error"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.1.line", "3"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.1.column", "3"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.1.message", "unknown function 'http.getHedaer'"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.invalid", "problems.1.severity", "warning"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.unknown_function", "valid", "true"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.unknown_function", "problems.#", "1"),
+					resource.TestCheckResourceAttr("data.vtm_rule_lint.unknown_function", "problems.0.severity", "warning"),
+				),
+			},
+		},
+	})
+}
+
+func TestLintTrafficScript(t *testing.T) {
+	for _, test := range []struct {
+		source   string
+		expected []string
+	}{
+		{`
+			# Send requests for images to their own pool
+			import utils as u;
+			sub choosePool($path) {
+				if (string.startsWith($path, "/images/")) {
+					return "images";
+				} else if (string.regexMatch($path, '^/(static|assets)/.*\.css$')) {
+					return u.cssPool();
+				}
+				return "main";
+			}
+			$path = http.getPath();
+			foreach ($header in ["X-Forwarded-For", "X-Real-IP"]) {
+				log.info("\x41 " . $header . " " . http.getHeader($header));
+			}
+			pool.use(choosePool($path));`,
+			[]string{},
+		},
+		{`
+			switch (sip.getMethod()) {
+				case "INVITE":
+					sip.setRequestURI("sip:user@example.com");
+					break;
+				case "OPTIONS":
+					counter64.increment(1);
+					break;
+				default:
+					rtsp.getRequestURI();
+			}`,
+			[]string{},
+		},
+		{"TEST_TEXT", []string{"line 1, column 1: unknown keyword 'TEST_TEXT'"}},
+		{"$x = foo;", []string{"line 1, column 6: unexpected word 'foo'"}},
+		{"if ($x) {\n  log.info($x);\n", []string{"line 1, column 9: '{' is never closed"}},
+		{"log.info(($x);", []string{"line 1, column 9: '(' is never closed"}},
+		{"log.info($x));", []string{"line 1, column 13: unexpected ')' with no matching opening bracket"}},
+		{"if ($x] {}", []string{"line 1, column 7: ']' does not match '(' at line 1, column 4"}},
+		{"log.info(\"unterminated);\n", []string{"line 1, column 10: unterminated string"}},
+		{"/* unterminated\n", []string{"line 1, column 1: unterminated comment"}},
+		{`log.info("\x4");`, []string{"line 1, column 11: malformed escape sequence '\\x4', expected two hexadecimal digits"}},
+		{"$x = 1 @ 2;", []string{"line 1, column 8: unexpected character '@'"}},
+		{"fi ($x) { log.info($x); }", []string{"line 1, column 1: unknown keyword 'fi'"}},
+		{"$ = 1;", []string{"line 1, column 1: expected a variable name after '$'"}},
+		{"http.getHedaer('Host');", []string{"line 1, column 1: warning: unknown function 'http.getHedaer'"}},
+		{"chooseMe();", []string{"line 1, column 1: warning: unknown function 'chooseMe'"}},
+		{testRuleMissingFunctions, []string{
+			"line 1, column 10: warning: unknown function 'ssl.clientCertIssuerDN'",
+			"line 1, column 46: warning: unknown function 'ssl.clientCertNotAfter'",
+		}},
+		{"HTTP.GetHeader('Host');", []string{}},
+		{"string.regexMatch($x, 'a(b');", []string{"line 1, column 23: malformed regular expression \"a(b\": missing closing )"}},
+		{"string.regexMatch($x, '[a-');", []string{"line 1, column 23: malformed regular expression \"[a-\": missing closing ]"}},
+		{"string.regexMatch($x, '(?=a)b(\\d)\\1');", []string{}},
+		{"string.regexMatch($x, 'a(' . $y);", []string{}},
+	} {
+		problems := []string{}
+		for _, problem := range lintTrafficScript(test.source) {
+			problems = append(problems, problem.String())
+		}
+		if !reflect.DeepEqual(problems, test.expected) {
+			t.Errorf("lintTrafficScript(%q) = %q, expected %q", test.source, problems, test.expected)
+		}
+	}
+}
+
+func getDataSourceRuleLintConfig() string {
+	return `
+		data "vtm_rule_lint" "valid" {
+			content = "log.info('valid');"
+		}
+
+		data "vtm_rule_lint" "unknown_function" {
+			content = "log.inf('valid');"
+		}
+
+		data "vtm_rule_lint" "invalid" {
+			content = <<EOF
+$host = http.getHostHeader();
+fi ($host == "example.com")
+{ http.getHedaer("Host"); }
+EOF
+		}`
+}
@@ -0,0 +1,485 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_ssl_certificate_info parses the certificates of SSL server keys, client
+ * keys and CAs, reporting their details and any problems with them: expiry,
+ * a chain which does not build against the vtm_ssl_ca objects, or a private
+ * key which does not match the certificate. With fail_on_problems it fails
+ * with a report of the problems, so that a plan does too.
+ */
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func dataSourceSslCertificateInfo() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSslCertificateInfoRead,
+		Schema: map[string]*schema.Schema{
+
+			// The name of an SSL server key to inspect.
+			"server_key": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_key", "ca", "all"},
+			},
+
+			// The name of an SSL client key to inspect.
+			"client_key": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"server_key", "ca", "all"},
+			},
+
+			// The name of an SSL CA to inspect.
+			"ca": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"server_key", "client_key", "all"},
+			},
+
+			// Whether to inspect every SSL server key, client key and CA.
+			//  CAs which hold no certificate, such as revocation lists, are
+			//  left out.
+			"all": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"server_key", "client_key", "ca"},
+			},
+
+			// Certificates which expire in fewer days than this are
+			//  reported as a problem. Expired certificates always are.
+			"min_days_to_expiry": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Default:      0,
+			},
+
+			// Whether a certificate whose chain does not build against the
+			//  vtm_ssl_ca objects is reported as a problem.
+			"check_chain": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// Whether to fail, with a report of the problems, if any are
+			//  found.
+			"fail_on_problems": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Whether no problems were found.
+			"valid": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// The problems found, each prefixed with the type and name of the
+			//  object.
+			"problems": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// The certificates inspected: server keys, then client keys,
+			//  then CAs, each in order of name.
+			"certificates": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						// "server_key", "client_key" or "ca".
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// The name of the object.
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// The distinguished name of the subject.
+						"subject": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// The distinguished name of the issuer.
+						"issuer": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// The DNS names in the subject alternative names.
+						"dns_names": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						// The IP addresses in the subject alternative names.
+						"ip_addresses": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						// The email addresses in the subject alternative
+						//  names.
+						"email_addresses": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						// "rsa", "ecdsa" or "ed25519".
+						"key_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// The size of the key in bits.
+						"key_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						// The serial number, in hex.
+						"serial": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// When the certificate becomes valid, in RFC 3339
+						//  format.
+						"not_before": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// When the certificate expires, in RFC 3339 format.
+						"not_after": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// The number of whole days until the certificate
+						//  expires, negative once it has.
+						"days_to_expiry": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						// The SHA-256 fingerprint of the certificate, in hex.
+						"sha256_fingerprint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// Whether the certificate chains to one of the
+						//  vtm_ssl_ca objects, through any intermediate
+						//  certificates which follow it.
+						"chain_valid": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						// Why the chain did not build.
+						"chain_error": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						// For keys, "match" or "mismatch" if the private key
+						//  is for the certificate, or "unknown". The vTM
+						//  normally reports only a hash of the private key,
+						//  so the key of the certificate signing request is
+						//  compared instead, if there is one.
+						"key_match": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// sslCertificateObject is a server key, client key or CA to inspect.
+type sslCertificateObject struct {
+	kind    string
+	name    string
+	public  string
+	private string
+	request string
+}
+
+func dataSourceSslCertificateInfoRead(d *schema.ResourceData, tm interface{}) error {
+	conn := tm.(*vtm.VirtualTrafficManager)
+	serverKey, clientKey, ca := d.Get("server_key").(string), d.Get("client_key").(string), d.Get("ca").(string)
+	all := d.Get("all").(bool)
+	if serverKey == "" && clientKey == "" && ca == "" && all == false {
+		return fmt.Errorf("vtm_ssl_certificate_info: one of server_key, client_key, ca or all must be set")
+	}
+
+	caNames, listErr := conn.ListSslCas()
+	if listErr != nil {
+		return fmt.Errorf("Failed to list vtm_ssl_ca objects: %v", listErr.ErrorText)
+	}
+	roots := x509.NewCertPool()
+	cas := []sslCertificateObject{}
+	sort.Strings(*caNames)
+	for _, name := range *caNames {
+		content, err := conn.GetSslCa(name)
+		if err != nil {
+			return fmt.Errorf("Failed to read vtm_ssl_ca '%v': %v", name, err.ErrorText)
+		}
+		certificates, _ := parsePemCertificates(content)
+		for _, certificate := range certificates {
+			roots.AddCert(certificate)
+		}
+		if (all && len(certificates) != 0) || name == ca {
+			cas = append(cas, sslCertificateObject{kind: "ca", name: name, public: content})
+		}
+	}
+	if ca != "" && len(cas) == 0 {
+		return fmt.Errorf("Failed to read vtm_ssl_ca '%v': not found", ca)
+	}
+
+	objects := []sslCertificateObject{}
+	if all || serverKey != "" {
+		names := []string{serverKey}
+		if all {
+			list, err := conn.ListSslServerKeys()
+			if err != nil {
+				return fmt.Errorf("Failed to list vtm_ssl_server_key objects: %v", err.ErrorText)
+			}
+			names = *list
+			sort.Strings(names)
+		}
+		for _, name := range names {
+			object, err := conn.GetSslServerKey(name)
+			if err != nil {
+				return fmt.Errorf("Failed to read vtm_ssl_server_key '%v': %v", name, err.ErrorText)
+			}
+			objects = append(objects, sslCertificateObject{"server_key", name, getStringValue(object.Basic.Public), getStringValue(object.Basic.Private), getStringValue(object.Basic.Request)})
+		}
+	}
+	if all || clientKey != "" {
+		names := []string{clientKey}
+		if all {
+			list, err := conn.ListSslClientKeys()
+			if err != nil {
+				return fmt.Errorf("Failed to list vtm_ssl_client_key objects: %v", err.ErrorText)
+			}
+			names = *list
+			sort.Strings(names)
+		}
+		for _, name := range names {
+			object, err := conn.GetSslClientKey(name)
+			if err != nil {
+				return fmt.Errorf("Failed to read vtm_ssl_client_key '%v': %v", name, err.ErrorText)
+			}
+			objects = append(objects, sslCertificateObject{"client_key", name, getStringValue(object.Basic.Public), getStringValue(object.Basic.Private), getStringValue(object.Basic.Request)})
+		}
+	}
+	objects = append(objects, cas...)
+
+	checkChain := d.Get("check_chain").(bool)
+	minDays := d.Get("min_days_to_expiry").(int)
+	certificateList := make([]map[string]interface{}, 0, len(objects))
+	problems := []string{}
+	for _, object := range objects {
+		info, objectProblems := inspectSslCertificate(object, roots, checkChain, minDays, time.Now())
+		certificateList = append(certificateList, info)
+		for _, problem := range objectProblems {
+			problems = append(problems, fmt.Sprintf("%s '%s': %s", object.kind, object.name, problem))
+		}
+	}
+
+	if d.Get("fail_on_problems").(bool) && len(problems) != 0 {
+		return fmt.Errorf("vtm_ssl_certificate_info found problems with SSL certificates:\n  %s", strings.Join(problems, "\n  "))
+	}
+	d.Set("certificates", certificateList)
+	d.Set("problems", problems)
+	d.Set("valid", len(problems) == 0)
+	d.SetId("ssl_certificate_info")
+	return nil
+}
+
+func getStringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+/*
+inspectSslCertificate parses the first certificate of an object and checks it, returning its
+details and the problems found. Any further certificates are used as intermediates when building
+its chain to roots.
+*/
+func inspectSslCertificate(object sslCertificateObject, roots *x509.CertPool, checkChain bool, minDays int, now time.Time) (map[string]interface{}, []string) {
+	info := map[string]interface{}{
+		"type": object.kind,
+		"name": object.name,
+	}
+	certificates, err := parsePemCertificates(object.public)
+	if err != nil {
+		return info, []string{fmt.Sprintf("certificate cannot be parsed: %v", err)}
+	}
+	if len(certificates) == 0 {
+		return info, []string{"has no certificate"}
+	}
+	certificate := certificates[0]
+	problems := []string{}
+
+	keyType, keySize := getPublicKeyDetails(certificate.PublicKey)
+	daysToExpiry := int(math.Floor(certificate.NotAfter.Sub(now).Hours() / 24))
+	ipAddresses := []string{}
+	for _, address := range certificate.IPAddresses {
+		ipAddresses = append(ipAddresses, address.String())
+	}
+	info["subject"] = certificate.Subject.String()
+	info["issuer"] = certificate.Issuer.String()
+	info["dns_names"] = certificate.DNSNames
+	info["ip_addresses"] = ipAddresses
+	info["email_addresses"] = certificate.EmailAddresses
+	info["key_type"] = keyType
+	info["key_size"] = keySize
+	info["serial"] = certificate.SerialNumber.Text(16)
+	info["not_before"] = certificate.NotBefore.UTC().Format(time.RFC3339)
+	info["not_after"] = certificate.NotAfter.UTC().Format(time.RFC3339)
+	info["days_to_expiry"] = daysToExpiry
+	info["sha256_fingerprint"] = vtm.GetCertificateFingerprint(certificate.Raw)
+
+	switch {
+	case now.Before(certificate.NotBefore):
+		problems = append(problems, fmt.Sprintf("is not valid until %s", info["not_before"]))
+	case now.After(certificate.NotAfter):
+		problems = append(problems, fmt.Sprintf("expired on %s", info["not_after"]))
+	case daysToExpiry < minDays:
+		problems = append(problems, fmt.Sprintf("expires in %d days, on %s, less than the %d required", daysToExpiry, info["not_after"], minDays))
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	_, chainErr := certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	info["chain_valid"] = chainErr == nil
+	info["chain_error"] = ""
+	if chainErr != nil {
+		info["chain_error"] = chainErr.Error()
+		if checkChain {
+			problems = append(problems, fmt.Sprintf("chain does not build against the vtm_ssl_ca objects: %v", chainErr))
+		}
+	}
+
+	info["key_match"] = ""
+	if object.kind != "ca" {
+		info["key_match"] = getSslKeyMatch(certificate, object.private, object.request)
+		if info["key_match"] == "mismatch" {
+			problems = append(problems, "private key does not match the certificate")
+		}
+	}
+	return info, problems
+}
+
+func parsePemCertificates(text string) ([]*x509.Certificate, error) {
+	certificates := []*x509.Certificate{}
+	rest := []byte(text)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 && strings.TrimSpace(text) != "" && strings.Contains(text, "-----BEGIN") == false {
+		return nil, fmt.Errorf("not PEM encoded")
+	}
+	return certificates, nil
+}
+
+func getPublicKeyDetails(key crypto.PublicKey) (string, int) {
+	switch typedKey := key.(type) {
+	case *rsa.PublicKey:
+		return "rsa", typedKey.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ecdsa", typedKey.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "ed25519", 256
+	}
+	return "unknown", 0
+}
+
+/*
+getSslKeyMatch compares the key of a certificate with the private key, if the vTM has reported it
+rather than its hash, or else with the key of the certificate signing request.
+*/
+func getSslKeyMatch(certificate *x509.Certificate, private, request string) string {
+	var key crypto.PublicKey
+	if block, _ := pem.Decode([]byte(private)); block != nil {
+		if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			key = privateKey.Public()
+		} else if privateKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			key = privateKey.Public()
+		} else if privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			if signer, ok := privateKey.(crypto.Signer); ok {
+				key = signer.Public()
+			}
+		}
+	}
+	if block, _ := pem.Decode([]byte(request)); key == nil && block != nil {
+		if csr, err := x509.ParseCertificateRequest(block.Bytes); err == nil {
+			key = csr.PublicKey
+		}
+	}
+	if key == nil {
+		return "unknown"
+	}
+	if certificateKey, ok := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); ok && certificateKey.Equal(key) {
+		return "match"
+	}
+	return "mismatch"
+}
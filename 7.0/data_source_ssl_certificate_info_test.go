@@ -0,0 +1,180 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Details of a server key whose certificate chains to a vtm_ssl_ca object
+ *   - Problems with an expired, self-signed certificate whose request is for another key
+ *   - Details of a CA
+ *   - Failing with a report of the problems with every certificate
+ */
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+/*
+getTestSignedCertificate creates a key and a certificate for it from a template, signed by parent
+and parentKey, or self-signed if they are nil. It returns the key, the PEM encoded certificate and
+its DER encoding.
+*/
+func getTestSignedCertificate(t *testing.T, template, parent *x509.Certificate, parentKey crypto.Signer) (crypto.Signer, string, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), der
+}
+
+func getTestCertificateRequest(t *testing.T, key crypto.Signer) string {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "www.example.com"}}, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate signing request: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func TestDataSourceSslCertificateInfo(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestSslCertificateInfo")
+	now := time.Now()
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(1, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caKey, caPem, _ := getTestSignedCertificate(t, caTemplate, nil, nil)
+	goodKey, goodPem, goodDer := getTestSignedCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(0x1234),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		DNSNames:     []string{"www.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.0.2.1")},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(10*24*time.Hour + time.Hour),
+	}, caTemplate, caKey)
+	otherKey, _, _ := getTestSignedCertificate(t, caTemplate, nil, nil)
+	_, badPem, _ := getTestSignedCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "expired.example.com"},
+		NotBefore:    now.AddDate(0, -1, 0),
+		NotAfter:     now.Add(-time.Hour),
+	}, nil, nil)
+
+	config := getSslCertificateInfoConfig(objName, caPem, goodPem, getTestCertificateRequest(t, goodKey), badPem, getTestCertificateRequest(t, otherKey))
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSslServerKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "valid", "true"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "problems.#", "0"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.#", "1"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.type", "server_key"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.subject", "CN=www.example.com"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.issuer", "CN=Test Root CA"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.dns_names.0", "www.example.com"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.ip_addresses.0", "192.0.2.1"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.key_type", "ecdsa"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.key_size", "256"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.serial", "1234"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.days_to_expiry", "10"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.sha256_fingerprint", vtm.GetCertificateFingerprint(goodDer)),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.chain_valid", "true"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.good", "certificates.0.key_match", "match"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.bad", "valid", "false"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.bad", "problems.#", "3"),
+					resource.TestMatchResourceAttr("data.vtm_ssl_certificate_info.bad", "problems.0", regexp.MustCompile(fmt.Sprintf("^server_key '%s-bad': expired on ", objName))),
+					resource.TestMatchResourceAttr("data.vtm_ssl_certificate_info.bad", "problems.1", regexp.MustCompile("chain does not build against the vtm_ssl_ca objects")),
+					resource.TestMatchResourceAttr("data.vtm_ssl_certificate_info.bad", "problems.2", regexp.MustCompile("private key does not match the certificate")),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.bad", "certificates.0.chain_valid", "false"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.bad", "certificates.0.key_match", "mismatch"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.ca", "valid", "true"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.ca", "certificates.0.type", "ca"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.ca", "certificates.0.chain_valid", "true"),
+					resource.TestCheckResourceAttr("data.vtm_ssl_certificate_info.ca", "certificates.0.key_match", ""),
+				),
+			},
+			{
+				Config: config + `
+				data "vtm_ssl_certificate_info" "all" {
+					all = true
+					min_days_to_expiry = 30
+					fail_on_problems = true
+					depends_on = ["vtm_ssl_ca.ca", "vtm_ssl_server_key.good", "vtm_ssl_server_key.bad"]
+				}`,
+				ExpectError: regexp.MustCompile(fmt.Sprintf("(?s)found problems with SSL certificates:.*server_key '%s-bad': expired on .*server_key '%s-good': expires in 10 days", objName, objName)),
+			},
+		},
+	})
+}
+
+func getSslCertificateInfoConfig(name, caPem, goodPem, goodRequest, badPem, badRequest string) string {
+	return fmt.Sprintf(`
+		resource "vtm_ssl_ca" "ca" {
+			name = "%[1]s-ca"
+			content = %[2]q
+		}
+
+		resource "vtm_ssl_server_key" "good" {
+			name = "%[1]s-good"
+			note = "Issued by ${vtm_ssl_ca.ca.name}"
+			private = "TEST_TEXT"
+			public = %[3]q
+			request = %[4]q
+		}
+
+		resource "vtm_ssl_server_key" "bad" {
+			name = "%[1]s-bad"
+			note = "Checked against ${vtm_ssl_ca.ca.name}"
+			private = "TEST_TEXT"
+			public = %[5]q
+			request = %[6]q
+		}
+
+		data "vtm_ssl_certificate_info" "good" {
+			server_key = vtm_ssl_server_key.good.name
+			min_days_to_expiry = 5
+		}
+
+		data "vtm_ssl_certificate_info" "bad" {
+			server_key = vtm_ssl_server_key.bad.name
+		}
+
+		data "vtm_ssl_certificate_info" "ca" {
+			ca = vtm_ssl_ca.ca.name
+		}`,
+		name, caPem, goodPem, goodRequest, badPem, badRequest,
+	)
+}
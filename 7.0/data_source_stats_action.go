@@ -44,6 +44,8 @@ func dataSourceActionStatisticsRead(d *schema.ResourceData, tm interface{}) (rea
 		return fmt.Errorf("Failed to read vtm_actions '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -70,6 +70,8 @@ func dataSourceCacheAspSessionCacheStatisticsRead(d *schema.ResourceData, tm int
 		return fmt.Errorf("Failed to read vtm_asp_session_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -77,6 +77,8 @@ func dataSourceCacheIpSessionCacheStatisticsRead(d *schema.ResourceData, tm inte
 		return fmt.Errorf("Failed to read vtm_ip_session_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -77,6 +77,8 @@ func dataSourceCacheJ2EeSessionCacheStatisticsRead(d *schema.ResourceData, tm in
 		return fmt.Errorf("Failed to read vtm_j2ee_session_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
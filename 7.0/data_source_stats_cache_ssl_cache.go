@@ -70,6 +70,8 @@ func dataSourceCacheSslCacheStatisticsRead(d *schema.ResourceData, tm interface{
 		return fmt.Errorf("Failed to read vtm_ssl_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -72,6 +72,8 @@ func dataSourceCacheSslSessionCacheStatisticsRead(d *schema.ResourceData, tm int
 		return fmt.Errorf("Failed to read vtm_ssl_session_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -77,6 +77,8 @@ func dataSourceCacheUniSessionCacheStatisticsRead(d *schema.ResourceData, tm int
 		return fmt.Errorf("Failed to read vtm_uni_session_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
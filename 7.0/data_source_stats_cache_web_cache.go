@@ -116,6 +116,8 @@ func dataSourceCacheWebCacheStatisticsRead(d *schema.ResourceData, tm interface{
 		return fmt.Errorf("Failed to read vtm_web_cache: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -59,6 +59,8 @@ func dataSourceCloudApiCredentialStatisticsRead(d *schema.ResourceData, tm inter
 		return fmt.Errorf("Failed to read vtm_cloud_api_credentials '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
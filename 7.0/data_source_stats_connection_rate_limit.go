@@ -84,6 +84,8 @@ func dataSourceConnectionRateLimitStatisticsRead(d *schema.ResourceData, tm inte
 		return fmt.Errorf("Failed to read vtm_connection_rate_limit '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
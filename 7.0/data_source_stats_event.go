@@ -44,6 +44,8 @@ func dataSourceEventStatisticsRead(d *schema.ResourceData, tm interface{}) (read
 		return fmt.Errorf("Failed to read vtm_events '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -31,6 +31,8 @@ func dataSourceExtrasUserCounters64StatisticsRead(d *schema.ResourceData, tm int
 		return fmt.Errorf("Failed to read vtm_user_counters_64: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -56,6 +56,8 @@ func dataSourceGlbServiceStatisticsRead(d *schema.ResourceData, tm interface{})
 		return fmt.Errorf("Failed to read vtm_glb_services '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -512,6 +512,8 @@ func dataSourceGlobalsStatisticsRead(d *schema.ResourceData, tm interface{}) (re
 		return fmt.Errorf("Failed to read vtm_globals: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
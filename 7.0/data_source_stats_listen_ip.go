@@ -69,6 +69,8 @@ func dataSourceListenIpStatisticsRead(d *schema.ResourceData, tm interface{}) (r
 		return fmt.Errorf("Failed to read vtm_listen_ips '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -50,6 +50,8 @@ func dataSourceLocationStatisticsRead(d *schema.ResourceData, tm interface{}) (r
 		return fmt.Errorf("Failed to read vtm_locations '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -80,6 +80,8 @@ func dataSourceNetworkInterfaceStatisticsRead(d *schema.ResourceData, tm interfa
 		return fmt.Errorf("Failed to read vtm_network_interface '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -113,6 +113,8 @@ func dataSourceNodesNodeStatisticsRead(d *schema.ResourceData, tm interface{}) (
 		return fmt.Errorf("Failed to read vtm_node '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
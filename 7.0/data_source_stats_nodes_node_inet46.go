@@ -132,6 +132,8 @@ func dataSourceNodesNodeInet46StatisticsRead(d *schema.ResourceData, tm interfac
 		return fmt.Errorf("Failed to read vtm_node_inet46 '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
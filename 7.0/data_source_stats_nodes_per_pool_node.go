@@ -132,6 +132,8 @@ func dataSourceNodesPerPoolNodeStatisticsRead(d *schema.ResourceData, tm interfa
 		return fmt.Errorf("Failed to read vtm_per_pool_node '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
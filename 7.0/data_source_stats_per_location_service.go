@@ -75,6 +75,8 @@ func dataSourcePerLocationServiceStatisticsRead(d *schema.ResourceData, tm inter
 		return fmt.Errorf("Failed to read vtm_per_location_service '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -77,6 +77,8 @@ func dataSourcePerNodeSlmPerNodeServiceLevelStatisticsRead(d *schema.ResourceDat
 		return fmt.Errorf("Failed to read vtm_per_node_service_level '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -77,6 +77,8 @@ func dataSourcePerNodeSlmPerNodeServiceLevelInet46StatisticsRead(d *schema.Resou
 		return fmt.Errorf("Failed to read vtm_per_node_service_level_inet46 '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
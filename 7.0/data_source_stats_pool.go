@@ -166,6 +166,8 @@ func dataSourcePoolStatisticsRead(d *schema.ResourceData, tm interface{}) (readE
 		return fmt.Errorf("Failed to read vtm_pools '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
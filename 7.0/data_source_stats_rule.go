@@ -84,6 +84,8 @@ func dataSourceRuleStatisticsRead(d *schema.ResourceData, tm interface{}) (readE
 		return fmt.Errorf("Failed to read vtm_rules '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -63,6 +63,8 @@ func dataSourceRuleAuthenticatorStatisticsRead(d *schema.ResourceData, tm interf
 		return fmt.Errorf("Failed to read vtm_rule_authenticators '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
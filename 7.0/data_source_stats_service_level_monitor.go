@@ -88,6 +88,8 @@ func dataSourceServiceLevelMonitorStatisticsRead(d *schema.ResourceData, tm inte
 		return fmt.Errorf("Failed to read vtm_service_level_monitors '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -101,6 +101,8 @@ func dataSourceServiceProtectionStatisticsRead(d *schema.ResourceData, tm interf
 		return fmt.Errorf("Failed to read vtm_service_protection '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
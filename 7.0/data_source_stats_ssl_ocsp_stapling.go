@@ -67,6 +67,8 @@ func dataSourceSslOcspStaplingStatisticsRead(d *schema.ResourceData, tm interfac
 		return fmt.Errorf("Failed to read vtm_ssl_ocsp_stapling: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
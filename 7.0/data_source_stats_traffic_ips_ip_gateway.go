@@ -75,6 +75,8 @@ func dataSourceTrafficIpsIpGatewayStatisticsRead(d *schema.ResourceData, tm inte
 		return fmt.Errorf("Failed to read vtm_ip_gateway: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -53,6 +53,8 @@ func dataSourceTrafficIpsTrafficIpStatisticsRead(d *schema.ResourceData, tm inte
 		return fmt.Errorf("Failed to read vtm_traffic_ip '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
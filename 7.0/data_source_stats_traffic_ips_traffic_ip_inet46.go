@@ -53,6 +53,8 @@ func dataSourceTrafficIpsTrafficIpInet46StatisticsRead(d *schema.ResourceData, t
 		return fmt.Errorf("Failed to read vtm_traffic_ip_inet46 '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -486,6 +486,8 @@ func dataSourceVirtualServerStatisticsRead(d *schema.ResourceData, tm interface{
 		return fmt.Errorf("Failed to read vtm_virtual_servers '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -27,9 +27,10 @@ import (
 )
 
 func TestDataSourceStatisticsVirtualServerEnhanced(t *testing.T) {
+	testAccRequireRealVtm(t)
 	objName := acctest.RandomWithPrefix("MyVirtualServer")
 	testRequestCount := acctest.RandIntRange(2, 20)
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: func(s *terraform.State) error { return destroyDataSourceStatisticsVirtualServerEnhancedConfig(objName) },
@@ -43,6 +43,8 @@ func dataSourceSystemInformationRead(d *schema.ResourceData, tm interface{}) (re
 		return fmt.Errorf("Failed to read vtm_information: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -169,6 +169,8 @@ func dataSourceSystemStateRead(d *schema.ResourceData, tm interface{}) (readErro
 		return fmt.Errorf("Failed to read vtm_state: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
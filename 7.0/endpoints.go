@@ -0,0 +1,180 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * The endpoints provider block applies every resource to several vTM
+ * clusters, such as identical clusters in different regions, instead of to
+ * the one at base_url. Each write is made to the first endpoint and then to
+ * the rest, as set by rollout, and a failure stops it from reaching the
+ * endpoints which have not been written to yet. Reads of configuration are
+ * compared across the endpoints: each resource reads the first endpoint's
+ * copy, and lists the endpoints on which it differs in drifted_endpoints,
+ * which plans an update that writes it to every endpoint again. Data sources
+ * for statistics and state read the first endpoint.
+ */
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func endpointsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{"services_director"},
+		Description:   "vTM clusters to apply every resource to, instead of base_url",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the endpoint in errors, such as its region (default: base_url)",
+				},
+				"base_url": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Base URL: 'https://vtm:9070/api'",
+				},
+				"username": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "admin",
+					Description: "vTM admin user",
+				},
+				"password": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+					Description: "vTM admin password",
+				},
+				"ca_cert_pem": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM encoded CA certificates for this endpoint, in place of the provider's",
+				},
+				"tls_server_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Host name to check this endpoint's SSL certificate against, in place of the provider's",
+				},
+				"pinned_sha256_fingerprints": &schema.Schema{
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateSha256Fingerprint},
+					Description: "SHA-256 fingerprints of this endpoint's SSL certificate, in place of the provider's",
+				},
+			},
+		},
+	}
+}
+
+/*
+connectEndpoints connects to every endpoint in an endpoints block. It returns the VirtualTrafficManager
+and the URL of the first endpoint, for error messages.
+*/
+func connectEndpoints(settings []interface{}, verifySslCert bool, options vtm.ConnectionOptions) (*vtm.VirtualTrafficManager, string, error) {
+	endpoints := []vtm.Endpoint{}
+	names := make(map[string]bool)
+	for _, setting := range settings {
+		endpoint := setting.(map[string]interface{})
+		name := endpoint["name"].(string)
+		if name == "" {
+			name = endpoint["base_url"].(string)
+		}
+		if names[name] {
+			return nil, "", fmt.Errorf("endpoints: '%s' is given more than once", name)
+		}
+		names[name] = true
+		fingerprints := []string{}
+		for _, fingerprint := range endpoint["pinned_sha256_fingerprints"].(*schema.Set).List() {
+			fingerprints = append(fingerprints, fingerprint.(string))
+		}
+		sort.Strings(fingerprints)
+		endpoints = append(endpoints, vtm.Endpoint{
+			Name:               name,
+			Url:                endpoint["base_url"].(string),
+			Username:           endpoint["username"].(string),
+			Password:           endpoint["password"].(string),
+			CACertPEM:          endpoint["ca_cert_pem"].(string),
+			TLSServerName:      endpoint["tls_server_name"].(string),
+			PinnedFingerprints: fingerprints,
+		})
+	}
+	tm, contactable, contactErr := vtm.NewVirtualTrafficManagerForEndpoints(endpoints, verifySslCert, true, options)
+	if contactable != true {
+		return nil, "", fmt.Errorf("Failed to connect to Virtual Traffic Manager endpoints: %v", contactErr.ErrorText)
+	}
+	return tm, endpoints[0].Url, nil
+}
+
+// Resources which do not write a whole configuration object on update, so cannot correct drift.
+var endpointDriftExcluded = map[string]bool{
+	"vtm_acme_certificate":        true,
+	"vtm_backups_full":            true,
+	"vtm_pool_node_drain":         true,
+	"vtm_ssl_ticket_key_rotation": true,
+}
+
+/*
+endpointDrift adds drifted_endpoints to a resource: the endpoints, or Services Director instances,
+on which the configuration it read differs from the first one's. While any are listed, an update is
+planned, which writes the resource to every endpoint again.
+*/
+func endpointDrift(resource *schema.Resource) *schema.Resource {
+	resource.Schema["drifted_endpoints"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Endpoints on which the object differs from the first endpoint, until the next apply corrects them",
+	}
+	read := resource.Read
+	resource.Read = func(d *schema.ResourceData, tm interface{}) error {
+		drifted := []string{}
+		isDrifted := make(map[string]bool)
+		report := func(names []string) {
+			for _, name := range names {
+				if isDrifted[name] != true {
+					isDrifted[name] = true
+					drifted = append(drifted, name)
+				}
+			}
+		}
+		if err := read(d, tm.(*vtm.VirtualTrafficManager).WithDriftReport(report)); err != nil {
+			return err
+		}
+		return d.Set("drifted_endpoints", drifted)
+	}
+	resource.Create = clearEndpointDrift(resource.Create)
+	if resource.Update != nil {
+		resource.Update = clearEndpointDrift(resource.Update)
+	}
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(d *schema.ResourceDiff, tm interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(d, tm); err != nil {
+				return err
+			}
+		}
+		if d.Id() != "" && len(d.Get("drifted_endpoints").([]interface{})) > 0 {
+			return d.SetNewComputed("drifted_endpoints")
+		}
+		return nil
+	}
+	return resource
+}
+
+// clearEndpointDrift empties drifted_endpoints after a write, which reaches every endpoint.
+func clearEndpointDrift(write func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, tm interface{}) error {
+		if err := write(d, tm); err != nil {
+			return err
+		}
+		return d.Set("drifted_endpoints", []string{})
+	}
+}
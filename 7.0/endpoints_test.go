@@ -0,0 +1,222 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * These tests cover the following cases:
+ *   - Writes reaching every endpoint, serially and in parallel
+ *   - A failed endpoint stopping a serial rollout
+ *   - Drift on other endpoints being reported, with a warning and in drifted_endpoints, and
+ *     planned away
+ *   - TLS settings given for one endpoint only applying to that endpoint
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func getTestEndpointSettings(fakes []*fakeVtm) []interface{} {
+	endpoints := []interface{}{}
+	for i, fake := range fakes {
+		endpoints = append(endpoints, map[string]interface{}{
+			"name":     fmt.Sprintf("region-%d", i+1),
+			"base_url": fake.BaseUrl(),
+			"username": fakeVtmUsername,
+			"password": fakeVtmPassword,
+		})
+	}
+	return endpoints
+}
+
+func getDirectTestVtm(t *testing.T, fake *fakeVtm) *vtm.VirtualTrafficManager {
+	tm, contactable, contactErr := vtm.NewVirtualTrafficManager(fake.BaseUrl(), fakeVtmUsername, fakeVtmPassword, false, false)
+	if contactable != true {
+		t.Fatalf("Failed to contact fake vTM: %v", contactErr)
+	}
+	return tm
+}
+
+func TestEndpointsRollout(t *testing.T) {
+	for _, rollout := range []string{"serial", "parallel"} {
+		fakes := []*fakeVtm{newFakeVtm(), newFakeVtm(), newFakeVtm()}
+		provider, err := configureTestProvider(map[string]interface{}{
+			"endpoints":   getTestEndpointSettings(fakes),
+			"rollout":     rollout,
+			"max_retries": 0,
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to configure provider: %v", rollout, err)
+		}
+		tm := provider.Meta().(*vtm.VirtualTrafficManager)
+
+		if _, applyErr := tm.NewPool("rollout").Apply(); applyErr != nil {
+			t.Fatalf("%s: failed to create pool: %v", rollout, applyErr)
+		}
+		for i, fake := range fakes {
+			if fake.HasObject("config/active/pools/rollout") != true {
+				t.Errorf("%s: pool was not created on region-%d", rollout, i+1)
+			}
+		}
+
+		fakes[1].Close()
+		_, applyErr := tm.NewPool("stopped").Apply()
+		if applyErr == nil || strings.HasPrefix(applyErr.ErrorText, "Endpoint 'region-2': ") == false {
+			t.Errorf("%s: expected the write to region-2 to fail, got %v", rollout, applyErr)
+		}
+		if fakes[0].HasObject("config/active/pools/stopped") != true {
+			t.Errorf("%s: pool was not created on region-1", rollout)
+		}
+		if created := fakes[2].HasObject("config/active/pools/stopped"); created != (rollout == "parallel") {
+			t.Errorf("%s: pool created on region-3 is %v", rollout, created)
+		}
+	}
+}
+
+func TestEndpointsDrift(t *testing.T) {
+	fakes := []*fakeVtm{newFakeVtm(), newFakeVtm(), newFakeVtm()}
+	provider, err := configureTestProvider(map[string]interface{}{
+		"endpoints":   getTestEndpointSettings(fakes),
+		"max_retries": 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure provider: %v", err)
+	}
+	tm := provider.Meta().(*vtm.VirtualTrafficManager)
+	if _, applyErr := tm.NewPool("drift").Apply(); applyErr != nil {
+		t.Fatalf("Failed to create pool: %v", applyErr)
+	}
+
+	for _, i := range []int{1, 2} {
+		region := fmt.Sprintf("region-%d", i+1)
+		pool, getErr := getDirectTestVtm(t, fakes[i]).GetPool("drift")
+		if getErr != nil {
+			t.Fatalf("Failed to read pool from %s: %v", region, getErr)
+		}
+		pool.Basic.Note = getStringAddr("changed on " + region)
+		if _, applyErr := pool.Apply(); applyErr != nil {
+			t.Fatalf("Failed to change pool on %s: %v", region, applyErr)
+		}
+	}
+	var logged bytes.Buffer
+	var drifted []string
+	logOutput := log.Writer()
+	log.SetOutput(&logged)
+	pool, getErr := tm.WithDriftReport(func(names []string) { drifted = names }).GetPool("drift")
+	log.SetOutput(logOutput)
+	if getErr != nil || pool.Basic.Note == nil || *pool.Basic.Note != "" {
+		t.Errorf("Expected the pool from region-1 to be read, got %v", getErr)
+	}
+	if strings.Join(drifted, ",") != "region-2,region-3" {
+		t.Errorf("Expected drift to be reported on region-2 and region-3, got %v", drifted)
+	}
+	if strings.Contains(logged.String(), "[WARN] ") == false || strings.Contains(logged.String(), "pools/drift differs from the first endpoint on: Endpoint 'region-2', Endpoint 'region-3'") == false {
+		t.Errorf("Expected a warning naming region-2 and region-3, got %q", logged.String())
+	}
+
+	second := getDirectTestVtm(t, fakes[1])
+
+	if deleteErr := second.DeletePool("drift"); deleteErr != nil {
+		t.Fatalf("Failed to delete pool from region-2: %v", deleteErr)
+	}
+	if _, getErr = tm.GetPool("drift"); getErr == nil || getErr.ErrorId != "resource.not_found" || strings.Contains(getErr.ErrorText, "region-2") == false {
+		t.Errorf("Expected the pool to be missing from region-2, got %v", getErr)
+	}
+}
+
+func TestEndpointsTLSSettings(t *testing.T) {
+	fakes := []*fakeVtm{newFakeVtm(), newFakeVtm()}
+	endpoints := getTestEndpointSettings(fakes)
+	endpoints[1].(map[string]interface{})["ca_cert_pem"] = "not a certificate"
+	_, err := configureTestProvider(map[string]interface{}{
+		"endpoints":   endpoints,
+		"max_retries": 0,
+	})
+	if err == nil || strings.Contains(err.Error(), "Endpoint 'region-2': The CA bundle holds no PEM encoded certificates") == false {
+		t.Errorf("Expected region-2's CA bundle to be refused, got %v", err)
+	}
+}
+
+func TestResourcePoolEndpoints(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test starts its own fake vTMs")
+	}
+	objName := acctest.RandomWithPrefix("TestPoolEndpoints")
+	fakes := []*fakeVtm{newFakeVtm(), newFakeVtm()}
+	config := fmt.Sprintf(`
+		provider "vtm" {
+			endpoints {
+				name     = "region-1"
+				base_url = "%s"
+				username = "%s"
+				password = "%s"
+			}
+			endpoints {
+				name     = "region-2"
+				base_url = "%s"
+				username = "%s"
+				password = "%s"
+			}
+		}
+
+		resource "vtm_pool" "test_vtm_pool" {
+			name = "%s"
+			note = "managed"
+		}`,
+		fakes[0].BaseUrl(), fakeVtmUsername, fakeVtmPassword,
+		fakes[1].BaseUrl(), fakeVtmUsername, fakeVtmPassword,
+		objName,
+	)
+	changeNote := func() {
+		second := getDirectTestVtm(t, fakes[1])
+		pool, getErr := second.GetPool(objName)
+		if getErr != nil {
+			t.Fatalf("Failed to read pool from region-2: %v", getErr)
+		}
+		pool.Basic.Note = getStringAddr("changed on region-2")
+		if _, applyErr := pool.Apply(); applyErr != nil {
+			t.Fatalf("Failed to change pool on region-2: %v", applyErr)
+		}
+	}
+
+	testAccTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool.test_vtm_pool", "note", "managed"),
+					resource.TestCheckResourceAttr("vtm_pool.test_vtm_pool", "drifted_endpoints.#", "0"),
+				),
+			},
+			{
+				PreConfig:          changeNote,
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool.test_vtm_pool", "drifted_endpoints.#", "0"),
+					func(*terraform.State) error {
+						pool, getErr := getDirectTestVtm(t, fakes[1]).GetPool(objName)
+						if getErr != nil || *pool.Basic.Note != "managed" {
+							return fmt.Errorf("Drift on region-2 was not corrected: %v", getErr)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
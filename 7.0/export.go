@@ -0,0 +1,467 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * "terraform-provider-vtm export" writes the configuration of an existing vTM
+ * as Terraform files, so that it can be brought under Terraform's management
+ * without writing the HCL by hand. Every resource type with a matching _list
+ * data source, which wraps the List* function for the type in go-vtm, is
+ * exported:
+ *
+ *   - <type>.tf holds a resource block for each object, leaving out the
+ *     attributes which are at their defaults. Names of objects which are
+ *     exported too, as listed in strictReferenceFields, become references to
+ *     their resources.
+ *   - Text objects, such as rules and monitor scripts, are written to
+ *     <type>/<name> and loaded with file().
+ *   - import.sh runs "terraform import" for each resource.
+ *
+ * Write-only secrets cannot be read back from the vTM, so each is set from a
+ * variable, declared in variables.tf, whose value must be given when planning.
+ * vtm_global_settings, vtm_security and vtm_appliance_nat are not listed and
+ * are not exported.
+ */
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Resource types which are listed but are not configuration.
+var exportSkippedResources = map[string]bool{
+	"vtm_backups_full": true,
+}
+
+// Defaults of list attributes, which are set when creating an object rather than in the schema.
+var exportListDefaults = map[string]map[string][]string{
+	"vtm_traffic_manager": {
+		"appliance_ntpservers": {"0.zeus.pool.ntp.org", "1.zeus.pool.ntp.org", "2.zeus.pool.ntp.org", "3.zeus.pool.ntp.org"},
+		"rest_api_bind_ips":    {"*"},
+		"snmp_allow":           {"all"},
+	},
+	"vtm_virtual_server": {
+		"gzip_include_mime":                        {"text/html", "text/plain"},
+		"transaction_export_http_header_blacklist": {"Authorization"},
+	},
+}
+
+var exportInvalidNameCharacters = regexp.MustCompile("[^a-z0-9_]+")
+
+type exporter struct {
+	provider *schema.Provider
+	dir      string
+	// The Terraform resource name for each exported object of each type
+	resourceNames map[string]map[string]string
+	// The variable blocks for write-only secrets, and the names they use
+	variables     *bytes.Buffer
+	variableNames map[string]bool
+}
+
+/*
+runExport runs the export subcommand with its command line arguments. Connection settings which
+are not given fall back to the provider's environment variables, such as VTM_BASE_URL.
+*/
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "Directory to write the Terraform files to")
+	baseUrl := flags.String("base-url", "", "Base URL of the vTM REST API (default: $VTM_BASE_URL)")
+	username := flags.String("username", "", "vTM admin user (default: admin)")
+	password := flags.String("password", "", "vTM admin password (default: $VTM_PASSWORD)")
+	verifySslCert := flags.String("verify-ssl-cert", "", "Check that the REST API's SSL certificate is trusted, true or false (default: $VTM_VERIFY_SSL_CERT or true)")
+	apiVersion := flags.String("api-version", "", "REST API version to use (default: the newest supported by both)")
+	caCertFile := flags.String("ca-cert-file", "", "File of PEM encoded CA certificates to trust for the REST API's SSL certificate")
+	clientCertFile := flags.String("client-cert-file", "", "File holding a PEM encoded client certificate to authenticate with")
+	clientKeyFile := flags.String("client-key-file", "", "File holding the PEM encoded private key of the client certificate")
+	tlsServerName := flags.String("tls-server-name", "", "Host name to check the REST API's SSL certificate against")
+	pinnedFingerprints := flags.String("pinned-sha256-fingerprints", "", "Comma-separated SHA-256 fingerprints of the REST API's SSL certificate")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	providerConfig := make(map[string]interface{})
+	for key, value := range map[string]string{
+		"base_url":        *baseUrl,
+		"username":        *username,
+		"password":        *password,
+		"verify_ssl_cert": *verifySslCert,
+		"api_version":     *apiVersion,
+		"ca_cert_file":    *caCertFile,
+		"tls_server_name": *tlsServerName,
+	} {
+		if value != "" {
+			providerConfig[key] = value
+		}
+	}
+	for key, path := range map[string]string{
+		"client_cert_pem": *clientCertFile,
+		"client_key_pem":  *clientKeyFile,
+	} {
+		if path != "" {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			providerConfig[key] = string(content)
+		}
+	}
+	if *pinnedFingerprints != "" {
+		fingerprints := []interface{}{}
+		for _, fingerprint := range strings.Split(*pinnedFingerprints, ",") {
+			fingerprints = append(fingerprints, strings.TrimSpace(fingerprint))
+		}
+		providerConfig["pinned_sha256_fingerprints"] = fingerprints
+	}
+	provider := Provider().(*schema.Provider)
+	rawConfig, err := config.NewRawConfig(providerConfig)
+	if err != nil {
+		return err
+	}
+	if err := provider.Configure(terraform.NewResourceConfig(rawConfig)); err != nil {
+		return err
+	}
+
+	export := &exporter{
+		provider:      provider,
+		dir:           *dir,
+		resourceNames: make(map[string]map[string]string),
+		variables:     &bytes.Buffer{},
+		variableNames: make(map[string]bool),
+	}
+	return export.run()
+}
+
+func (export *exporter) run() error {
+	resourceTypes := []string{}
+	for resourceType := range export.provider.ResourcesMap {
+		if _, ok := export.provider.DataSourcesMap[resourceType+"_list"]; ok && exportSkippedResources[resourceType] == false {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+
+	// Every object is named first, so that references can be made in any order
+	objectNames := make(map[string][]string)
+	for _, resourceType := range resourceTypes {
+		names, err := export.listObjects(resourceType)
+		if err != nil {
+			return err
+		}
+		objectNames[resourceType] = names
+		export.resourceNames[resourceType] = make(map[string]string)
+		used := make(map[string]bool)
+		for _, name := range names {
+			resourceName := getExportResourceName(name, used)
+			used[resourceName] = true
+			export.resourceNames[resourceType][name] = resourceName
+		}
+	}
+
+	if err := os.MkdirAll(export.dir, 0755); err != nil {
+		return err
+	}
+	imports := &bytes.Buffer{}
+	fmt.Fprintf(imports, "#!/bin/sh\n# Imports the resources exported from the vTM into the Terraform state.\nset -e\n")
+	for _, resourceType := range resourceTypes {
+		if len(objectNames[resourceType]) == 0 {
+			continue
+		}
+		hcl := &bytes.Buffer{}
+		for _, name := range objectNames[resourceType] {
+			exported, err := export.exportObject(hcl, resourceType, name)
+			if err != nil {
+				return err
+			}
+			if exported {
+				fmt.Fprintf(imports, "terraform import %s.%s %s\n", resourceType, export.resourceNames[resourceType][name], getShellQuoted(name))
+			}
+		}
+		if err := ioutil.WriteFile(filepath.Join(export.dir, resourceType+".tf"), hcl.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	if export.variables.Len() != 0 {
+		if err := ioutil.WriteFile(filepath.Join(export.dir, "variables.tf"), export.variables.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(filepath.Join(export.dir, "import.sh"), imports.Bytes(), 0755)
+}
+
+func (export *exporter) listObjects(resourceType string) ([]string, error) {
+	dataSource := export.provider.DataSourcesMap[resourceType+"_list"]
+	d := dataSource.Data(nil)
+	if err := dataSource.Read(d, export.provider.Meta()); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, name := range d.Get("object_list").([]interface{}) {
+		names = append(names, name.(string))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+/*
+exportObject writes the resource block for an object, returning false if the object no longer
+exists.
+*/
+func (export *exporter) exportObject(hcl *bytes.Buffer, resourceType, name string) (bool, error) {
+	resource := export.provider.ResourcesMap[resourceType]
+	d := resource.Data(nil)
+	// Attributes which are not stored on the vTM keep their defaults
+	for attribute, attrSchema := range resource.Schema {
+		if attrSchema.Default != nil {
+			d.Set(attribute, attrSchema.Default)
+		}
+	}
+	d.Set("name", name)
+	d.SetId(name)
+	if err := resource.Read(d, export.provider.Meta()); err != nil {
+		return false, err
+	}
+	if d.Id() == "" {
+		return false, nil
+	}
+	// Rules are checked when planning, so the check which an exported rule fails is turned off
+	if resourceType == "vtm_rule" {
+		errors, _ := splitTrafficScriptProblems(lintTrafficScript(d.Get("content").(string)))
+		d.Set("check_syntax", len(errors) == 0)
+	}
+
+	values := make(map[string]interface{})
+	for attribute := range resource.Schema {
+		values[attribute] = d.Get(attribute)
+	}
+	resourceName := export.resourceNames[resourceType][name]
+	fmt.Fprintf(hcl, "resource %q %q {\n", resourceType, resourceName)
+	if err := export.writeAttributes(hcl, "  ", resourceType, resourceName, resource.Schema, values); err != nil {
+		return false, err
+	}
+	fmt.Fprintf(hcl, "}\n\n")
+	return true, nil
+}
+
+/*
+writeAttributes writes the attributes and nested blocks of a resource, or of a block within one,
+in the layout used by "terraform fmt".
+*/
+func (export *exporter) writeAttributes(hcl *bytes.Buffer, indent, resourceType, resourceName string, attributeSchema map[string]*schema.Schema, values map[string]interface{}) error {
+	attributes := []string{}
+	for attribute := range attributeSchema {
+		attributes = append(attributes, attribute)
+	}
+	sort.Slice(attributes, func(a, b int) bool {
+		if (attributes[a] == "name") != (attributes[b] == "name") {
+			return attributes[a] == "name"
+		}
+		return attributes[a] < attributes[b]
+	})
+
+	lines := [][2]string{}
+	blocks := []string{}
+	for _, attribute := range attributes {
+		attrSchema := attributeSchema[attribute]
+		value := values[attribute]
+		if attrSchema.Optional == false && attrSchema.Required == false {
+			continue
+		}
+		// The table and its JSON form hold the same values
+		if _, hasTable := attributeSchema[strings.TrimSuffix(attribute, "_json")]; strings.HasSuffix(attribute, "_json") && hasTable {
+			continue
+		}
+		if attrSchema.Sensitive && attrSchema.StateFunc != nil {
+			if value != "" {
+				lines = append(lines, [2]string{attribute, "var." + export.addSecretVariable(resourceType, resourceName, attribute)})
+			}
+			continue
+		}
+		if attrSchema.Required == false && (isExportDefault(attrSchema, value) || isExportListDefault(resourceType, attribute, value)) {
+			continue
+		}
+
+		if nested, isBlock := attrSchema.Elem.(*schema.Resource); isBlock {
+			elements := value
+			if set, isSet := value.(*schema.Set); isSet {
+				elements = set.List()
+			}
+			for _, element := range elements.([]interface{}) {
+				block := &bytes.Buffer{}
+				fmt.Fprintf(block, "%s%s {\n", indent, attribute)
+				if err := export.writeAttributes(block, indent+"  ", resourceType, resourceName, nested.Schema, element.(map[string]interface{})); err != nil {
+					return err
+				}
+				fmt.Fprintf(block, "%s}\n", indent)
+				blocks = append(blocks, block.String())
+			}
+			continue
+		}
+
+		if attribute == "content" && attrSchema.Type == schema.TypeString {
+			contentPath, err := export.writeTextObject(resourceType, resourceName, value.(string))
+			if err != nil {
+				return err
+			}
+			lines = append(lines, [2]string{attribute, fmt.Sprintf("file(\"${path.module}/%s\")", contentPath)})
+			continue
+		}
+		lines = append(lines, [2]string{attribute, export.formatValue(strictReferenceFields[resourceType][attribute], value)})
+	}
+
+	width := 0
+	for _, line := range lines {
+		if len(line[0]) > width {
+			width = len(line[0])
+		}
+	}
+	for _, line := range lines {
+		fmt.Fprintf(hcl, "%s%-*s = %s\n", indent, width, line[0], line[1])
+	}
+	for _, block := range blocks {
+		fmt.Fprintf(hcl, "\n%s", block)
+	}
+	return nil
+}
+
+// addSecretVariable declares the variable which sets a write-only secret, returning its name.
+func (export *exporter) addSecretVariable(resourceType, resourceName, attribute string) string {
+	name := getExportResourceName(resourceType+"_"+resourceName+"_"+attribute, export.variableNames)
+	export.variableNames[name] = true
+	fmt.Fprintf(export.variables, "variable %q {\n", name)
+	fmt.Fprintf(export.variables, "  description = %q\n", fmt.Sprintf("%s of %s.%s, which is write-only and cannot be exported", attribute, resourceType, resourceName))
+	fmt.Fprintf(export.variables, "  type        = string\n")
+	fmt.Fprintf(export.variables, "}\n\n")
+	return name
+}
+
+func (export *exporter) writeTextObject(resourceType, resourceName, content string) (string, error) {
+	contentPath := filepath.ToSlash(filepath.Join(strings.TrimPrefix(resourceType, "vtm_"), resourceName))
+	if err := os.MkdirAll(filepath.Join(export.dir, filepath.Dir(contentPath)), 0755); err != nil {
+		return "", err
+	}
+	return contentPath, ioutil.WriteFile(filepath.Join(export.dir, contentPath), []byte(content), 0644)
+}
+
+/*
+formatValue formats an attribute's value in HCL. Names of exported objects of referencedType become
+references to the resources for those objects.
+*/
+func (export *exporter) formatValue(referencedType string, value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		if resourceName, ok := export.resourceNames[referencedType][typedValue]; ok {
+			return fmt.Sprintf("%s.%s.name", referencedType, resourceName)
+		}
+		return getHclQuoted(typedValue)
+	case *schema.Set:
+		return export.formatValue(referencedType, typedValue.List())
+	case []interface{}:
+		elements := make([]string, len(typedValue))
+		for i, element := range typedValue {
+			elements[i] = export.formatValue(referencedType, element)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		elements := make([]string, len(keys))
+		for i, key := range keys {
+			elements[i] = fmt.Sprintf("%s = %s", getHclQuoted(key), export.formatValue("", typedValue[key]))
+		}
+		return "{ " + strings.Join(elements, ", ") + " }"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func isExportDefault(attrSchema *schema.Schema, value interface{}) bool {
+	if attrSchema.Default != nil {
+		return fmt.Sprintf("%v", attrSchema.Default) == fmt.Sprintf("%v", value)
+	}
+	switch typedValue := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typedValue == ""
+	case int:
+		return typedValue == 0
+	case float64:
+		return typedValue == 0
+	case bool:
+		return typedValue == false
+	case []interface{}:
+		return len(typedValue) == 0
+	case map[string]interface{}:
+		return len(typedValue) == 0
+	case *schema.Set:
+		return typedValue.Len() == 0
+	}
+	return false
+}
+
+func isExportListDefault(resourceType, attribute string, value interface{}) bool {
+	defaults, ok := exportListDefaults[resourceType][attribute]
+	if ok == false {
+		return false
+	}
+	if set, isSet := value.(*schema.Set); isSet {
+		value = set.List()
+	}
+	elements, isList := value.([]interface{})
+	if isList == false || len(elements) != len(defaults) {
+		return false
+	}
+	values := make([]string, len(elements))
+	for i, element := range elements {
+		values[i] = fmt.Sprintf("%v", element)
+	}
+	sort.Strings(values)
+	return reflect.DeepEqual(values, defaults)
+}
+
+/*
+getExportResourceName turns an object name into a Terraform resource name which has not been used.
+*/
+func getExportResourceName(name string, used map[string]bool) string {
+	base := strings.Trim(exportInvalidNameCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "_" + base
+	}
+	resourceName := base
+	for i := 2; used[resourceName]; i++ {
+		resourceName = fmt.Sprintf("%s_%d", base, i)
+	}
+	return resourceName
+}
+
+func getHclQuoted(value string) string {
+	quoted := strings.NewReplacer(
+		"\\", "\\\\",
+		"\"", "\\\"",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\t", "\\t",
+		"${", "$${",
+		"%{", "%%{",
+	).Replace(value)
+	return "\"" + quoted + "\""
+}
+
+func getShellQuoted(value string) string {
+	return "'" + strings.Replace(value, "'", "'\\''", -1) + "'"
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Exporting a virtual server, its pool and its rule, with references between them
+ *   - Writing text objects to separate files and listing the terraform import commands
+ *   - Turning off the syntax check of exported rules which fail it, and leaving it on for others
+ *   - Setting write-only secrets from variables declared in variables.tf
+ *   - Naming resources after objects whose names are not valid Terraform names
+ *   - The exported files passing validation against the provider's schemas
+ */
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configload"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestExport(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestExport")
+	resourceName := strings.ToLower(strings.Replace(objName, "-", "_", -1))
+	tm, err := getTestVtm()
+	if err != nil {
+		t.Fatalf("Fatal error: %+v", err)
+	}
+	if ruleErr := tm.SetRule(objName, "log.info('exported');"); ruleErr != nil {
+		t.Fatalf("Failed to create rule %s: %#v", objName, ruleErr)
+	}
+	defer tm.DeleteRule(objName)
+	if ruleErr := tm.SetRule(objName+"_invalid", "log.info(('invalid');"); ruleErr != nil {
+		t.Fatalf("Failed to create rule %s_invalid: %#v", objName, ruleErr)
+	}
+	defer tm.DeleteRule(objName + "_invalid")
+	nodesTable := vtm.PoolNodesTableTable{vtm.PoolNodesTable{Node: getStringAddr("192.168.0.1:80")}}
+	pool := tm.NewPool(objName)
+	pool.Basic.NodesTable = &nodesTable
+	pool.Basic.Note = getStringAddr("Say \"hello\"")
+	if _, applyErr := pool.Apply(); applyErr != nil {
+		t.Fatalf("Failed to create pool %s: %#v", objName, applyErr)
+	}
+	defer tm.DeletePool(objName)
+	virtualServer := tm.NewVirtualServer(objName, objName, 8080)
+	virtualServer.Basic.RequestRules = &[]string{objName}
+	if _, applyErr := virtualServer.Apply(); applyErr != nil {
+		t.Fatalf("Failed to create virtual server %s: %#v", objName, applyErr)
+	}
+	defer tm.DeleteVirtualServer(objName)
+	if _, applyErr := tm.NewSslServerKey(objName, "", "private key", "", "").Apply(); applyErr != nil {
+		t.Fatalf("Failed to create SSL server key %s: %#v", objName, applyErr)
+	}
+	defer tm.DeleteSslServerKey(objName)
+
+	dir, err := ioutil.TempDir("", "TestExport")
+	if err != nil {
+		t.Fatalf("Fatal error: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := runExport([]string{"-dir", dir}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	for file, expected := range map[string][]string{
+		"vtm_virtual_server.tf": {
+			fmt.Sprintf("resource \"vtm_virtual_server\" %q {\n  name          = %q\n", resourceName, objName),
+			fmt.Sprintf("  pool          = vtm_pool.%s.name\n", resourceName),
+			"  port          = 8080\n",
+			fmt.Sprintf("  request_rules = [vtm_rule.%s.name]\n", resourceName),
+		},
+		"vtm_pool.tf": {
+			fmt.Sprintf("resource \"vtm_pool\" %q {\n  name = %q\n  note = \"Say \\\"hello\\\"\"\n", resourceName, objName),
+			"  nodes_table {\n    node = \"192.168.0.1:80\"\n  }\n",
+		},
+		"vtm_rule.tf": {
+			fmt.Sprintf("resource \"vtm_rule\" %q {\n  name    = %q\n  content = file(\"${path.module}/rule/%s\")\n}\n", resourceName, objName, resourceName),
+			fmt.Sprintf("  check_syntax = false\n  content      = file(\"${path.module}/rule/%s_invalid\")\n", resourceName),
+		},
+		"vtm_ssl_server_key.tf": {
+			fmt.Sprintf("  private = var.vtm_ssl_server_key_%s_private\n", resourceName),
+		},
+		"variables.tf": {
+			fmt.Sprintf("variable \"vtm_ssl_server_key_%s_private\" {\n", resourceName),
+			fmt.Sprintf("  description = \"private of vtm_ssl_server_key.%s, which is write-only and cannot be exported\"\n  type        = string\n}\n", resourceName),
+		},
+		filepath.Join("rule", resourceName): {
+			"log.info('exported');",
+		},
+		"import.sh": {
+			fmt.Sprintf("terraform import vtm_virtual_server.%s '%s'\n", resourceName, objName),
+			fmt.Sprintf("terraform import vtm_pool.%s '%s'\n", resourceName, objName),
+		},
+	} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("Export did not write %s: %v", file, err)
+		}
+		for _, text := range expected {
+			if strings.Contains(string(content), text) == false {
+				t.Errorf("Exported %s does not contain %q:\n%s", file, text, content)
+			}
+		}
+	}
+	if content, _ := ioutil.ReadFile(filepath.Join(dir, "vtm_virtual_server.tf")); strings.Contains(string(content), "enabled") {
+		t.Errorf("Exported vtm_virtual_server.tf contains the default for enabled:\n%s", content)
+	}
+	if err := validateTestExport(dir); err != nil {
+		t.Errorf("Exported files are not valid: %v", err)
+	}
+}
+
+// validateTestExport checks exported files against this provider's schemas, as "terraform validate" does.
+func validateTestExport(dir string) error {
+	loader, err := configload.NewLoader(&configload.Config{ModulesDir: filepath.Join(dir, ".terraform", "modules")})
+	if err != nil {
+		return err
+	}
+	config, configDiags := loader.LoadConfig(dir)
+	if configDiags.HasErrors() {
+		return configDiags
+	}
+	// Variables are unknown when validating, as they are for "terraform validate"
+	variables := make(terraform.InputValues)
+	for name := range config.Module.Variables {
+		variables[name] = &terraform.InputValue{Value: cty.UnknownVal(cty.DynamicPseudoType), SourceType: terraform.ValueFromCLIArg}
+	}
+	ctx, ctxDiags := terraform.NewContext(&terraform.ContextOpts{
+		Config:    config,
+		Variables: variables,
+		ProviderResolver: providers.ResolverFixed(map[string]providers.Factory{
+			"vtm": func() (providers.Interface, error) {
+				return resource.GRPCTestProvider(Provider()), nil
+			},
+		}),
+	})
+	if ctxDiags.HasErrors() {
+		return ctxDiags.Err()
+	}
+	if validateDiags := ctx.Validate(); validateDiags.HasErrors() {
+		return validateDiags.Err()
+	}
+	return nil
+}
+
+func TestGetExportResourceName(t *testing.T) {
+	used := map[string]bool{"web_pool": true}
+	for _, test := range []struct {
+		name     string
+		expected string
+	}{
+		{"Web Pool", "web_pool_2"},
+		{"api.example.com", "api_example_com"},
+		{"443-secure", "_443_secure"},
+		{"***", "_"},
+	} {
+		if result := getExportResourceName(test.name, used); result != test.expected {
+			t.Errorf("getExportResourceName(%q) = %q, expected %q", test.name, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * A fake ACME (RFC 8555) server for the vtm_acme_certificate tests. It
+ * checks the nonce, URL and signature of every request, and issues
+ * certificates from a test CA. Rather than fetching HTTP-01 challenge
+ * responses, it passes the token and expected key authorization to a
+ * validate function, which the tests use to check the vTM's configuration.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fakeAcmeOrder struct {
+	order       acmeOrder
+	accountURL  string
+	certificate string
+}
+
+type fakeAcmeAuthorization struct {
+	authorization acmeAuthorization
+	orderURL      string
+}
+
+type fakeAcme struct {
+	server *httptest.Server
+	mutex  sync.Mutex
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+	nextId int
+	nonces map[string]bool
+	// Account keys, keyed by account URL
+	accounts       map[string]*ecdsa.PublicKey
+	orders         map[string]*fakeAcmeOrder
+	authorizations map[string]*fakeAcmeAuthorization
+	// The validity of the certificates issued
+	validity time.Duration
+	// Called to check the response to each HTTP-01 challenge
+	validate func(token, keyAuthorization string) error
+	// The number of challenges which have been validated
+	validated int
+}
+
+// newFakeAcme starts a fake ACME server over TLS. Its directory is at "<server URL>/directory".
+func newFakeAcme(validate func(token, keyAuthorization string) error) *fakeAcme {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake ACME CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDer, _ := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	caCert, _ := x509.ParseCertificate(caDer)
+	fake := &fakeAcme{
+		caKey:          caKey,
+		caCert:         caCert,
+		nonces:         make(map[string]bool),
+		accounts:       make(map[string]*ecdsa.PublicKey),
+		orders:         make(map[string]*fakeAcmeOrder),
+		authorizations: make(map[string]*fakeAcmeAuthorization),
+		validity:       90 * 24 * time.Hour,
+		validate:       validate,
+	}
+	fake.server = httptest.NewTLSServer(http.HandlerFunc(fake.serveHTTP))
+	return fake
+}
+
+func (fake *fakeAcme) directoryUrl() string {
+	return fake.server.URL + "/directory"
+}
+
+// caCertPem returns the certificate of the server's TLS listener, to trust in directory_ca_cert_pem.
+func (fake *fakeAcme) caCertPem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: fake.server.Certificate().Raw}))
+}
+
+func (fake *fakeAcme) close() {
+	fake.server.Close()
+}
+
+func (fake *fakeAcme) newUrl(kind string) string {
+	fake.nextId++
+	return fmt.Sprintf("%s/%s/%d", fake.server.URL, kind, fake.nextId)
+}
+
+// setNonce adds a new nonce to a response.
+func (fake *fakeAcme) setNonce(w http.ResponseWriter) {
+	fake.nextId++
+	nonce := "nonce-" + strconv.Itoa(fake.nextId)
+	fake.nonces[nonce] = true
+	w.Header().Set("Replay-Nonce", nonce)
+}
+
+func (fake *fakeAcme) writeJson(w http.ResponseWriter, status int, body interface{}) {
+	fake.setNonce(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (fake *fakeAcme) writeProblem(w http.ResponseWriter, status int, problemType, detail string) {
+	fake.writeJson(w, status, acmeProblem{Type: "urn:ietf:params:acme:error:" + problemType, Detail: detail, Status: status})
+}
+
+func (fake *fakeAcme) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	url := fake.server.URL + r.URL.Path
+	kind := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+	switch {
+	case kind == "directory":
+		fake.writeJson(w, http.StatusOK, acmeDirectory{
+			NewNonce:   fake.server.URL + "/new-nonce",
+			NewAccount: fake.server.URL + "/new-account",
+			NewOrder:   fake.server.URL + "/new-order",
+		})
+		return
+	case kind == "new-nonce":
+		fake.writeJson(w, http.StatusOK, nil)
+		return
+	case r.Method != http.MethodPost:
+		fake.writeProblem(w, http.StatusMethodNotAllowed, "malformed", "method not allowed")
+		return
+	}
+
+	payload, accountURL, key, err := fake.verify(r, url)
+	if err != nil {
+		fake.writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+	if kind != "new-account" && fake.accounts[accountURL] == nil {
+		fake.writeProblem(w, http.StatusUnauthorized, "accountDoesNotExist", "unknown account")
+		return
+	}
+
+	switch kind {
+	case "new-account":
+		// An account which already exists for the key is returned, as a real ACME server does
+		for existingURL, existingKey := range fake.accounts {
+			if existingKey.X.Cmp(key.X) == 0 && existingKey.Y.Cmp(key.Y) == 0 {
+				w.Header().Set("Location", existingURL)
+				fake.writeJson(w, http.StatusOK, map[string]string{"status": "valid"})
+				return
+			}
+		}
+		accountURL := fake.newUrl("account")
+		fake.accounts[accountURL] = key
+		w.Header().Set("Location", accountURL)
+		fake.writeJson(w, http.StatusCreated, map[string]string{"status": "valid"})
+	case "new-order":
+		var request struct{ Identifiers []acmeIdentifier }
+		json.Unmarshal(payload, &request)
+		orderURL := fake.newUrl("order")
+		order := &fakeAcmeOrder{accountURL: accountURL}
+		order.order = acmeOrder{Status: "pending", Identifiers: request.Identifiers, Finalize: fake.newUrl("finalize")}
+		for _, identifier := range request.Identifiers {
+			authorizationURL := fake.newUrl("authz")
+			token := base64.RawURLEncoding.EncodeToString([]byte(authorizationURL))
+			fake.authorizations[authorizationURL] = &fakeAcmeAuthorization{
+				authorization: acmeAuthorization{
+					Status:     "pending",
+					Identifier: identifier,
+					Challenges: []acmeChallenge{
+						{Type: "dns-01", URL: fake.newUrl("challenge"), Status: "pending", Token: token},
+						{Type: "http-01", URL: fake.newUrl("challenge"), Status: "pending", Token: token},
+					},
+				},
+				orderURL: orderURL,
+			}
+			order.order.Authorizations = append(order.order.Authorizations, authorizationURL)
+		}
+		fake.orders[orderURL] = order
+		w.Header().Set("Location", orderURL)
+		fake.writeJson(w, http.StatusCreated, order.order)
+	case "authz":
+		if authorization, ok := fake.authorizations[url]; ok {
+			fake.writeJson(w, http.StatusOK, authorization.authorization)
+		} else {
+			fake.writeProblem(w, http.StatusNotFound, "malformed", "no such authorization")
+		}
+	case "challenge":
+		fake.serveChallenge(w, url, accountURL)
+	case "finalize":
+		fake.serveFinalize(w, url, payload)
+	case "order":
+		if order, ok := fake.orders[url]; ok {
+			fake.writeJson(w, http.StatusOK, order.order)
+		} else {
+			fake.writeProblem(w, http.StatusNotFound, "malformed", "no such order")
+		}
+	case "cert":
+		for _, order := range fake.orders {
+			if order.order.Certificate == url {
+				fake.setNonce(w)
+				w.Header().Set("Content-Type", "application/pem-certificate-chain")
+				w.Write([]byte(order.certificate))
+				return
+			}
+		}
+		fake.writeProblem(w, http.StatusNotFound, "malformed", "no such certificate")
+	default:
+		fake.writeProblem(w, http.StatusNotFound, "malformed", "not found")
+	}
+}
+
+// verify checks a JWS request, returning its payload and either the URL of its account or its JWK.
+func (fake *fakeAcme) verify(r *http.Request, url string) (payload []byte, accountURL string, key *ecdsa.PublicKey, err error) {
+	body, _ := ioutil.ReadAll(r.Body)
+	var request struct{ Protected, Payload, Signature string }
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, "", nil, err
+	}
+	protectedJson, _ := base64.RawURLEncoding.DecodeString(request.Protected)
+	var protected struct {
+		Alg   string
+		Nonce string
+		Url   string
+		Kid   string
+		Jwk   map[string]string
+	}
+	if err := json.Unmarshal(protectedJson, &protected); err != nil {
+		return nil, "", nil, err
+	}
+	if fake.nonces[protected.Nonce] == false {
+		return nil, "", nil, fmt.Errorf("invalid nonce '%s'", protected.Nonce)
+	}
+	delete(fake.nonces, protected.Nonce)
+	if protected.Url != url {
+		return nil, "", nil, fmt.Errorf("request for %s signed for %s", url, protected.Url)
+	}
+	if protected.Alg != "ES256" {
+		return nil, "", nil, fmt.Errorf("unsupported algorithm %s", protected.Alg)
+	}
+	if protected.Kid != "" {
+		key = fake.accounts[protected.Kid]
+		if key == nil {
+			return nil, "", nil, fmt.Errorf("unknown account %s", protected.Kid)
+		}
+	} else {
+		x, _ := base64.RawURLEncoding.DecodeString(protected.Jwk["x"])
+		y, _ := base64.RawURLEncoding.DecodeString(protected.Jwk["y"])
+		key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(request.Signature)
+	digest := sha256.Sum256([]byte(request.Protected + "." + request.Payload))
+	if len(signature) != 64 || ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) == false {
+		return nil, "", nil, fmt.Errorf("invalid signature")
+	}
+	payload, _ = base64.RawURLEncoding.DecodeString(request.Payload)
+	return payload, protected.Kid, key, nil
+}
+
+func (fake *fakeAcme) serveChallenge(w http.ResponseWriter, url, accountURL string) {
+	for _, authorization := range fake.authorizations {
+		for i := range authorization.authorization.Challenges {
+			challenge := &authorization.authorization.Challenges[i]
+			if challenge.URL != url {
+				continue
+			}
+			thumbprint, _ := getAcmeJwkThumbprint(fake.accounts[accountURL])
+			fake.validated++
+			if err := fake.validate(challenge.Token, challenge.Token+"."+thumbprint); err != nil {
+				challenge.Status = "invalid"
+				challenge.Error = &acmeProblem{Type: "urn:ietf:params:acme:error:unauthorized", Detail: err.Error(), Status: http.StatusForbidden}
+				authorization.authorization.Status = "invalid"
+				fake.orders[authorization.orderURL].order.Status = "invalid"
+			} else {
+				challenge.Status = "valid"
+				authorization.authorization.Status = "valid"
+				fake.updateOrder(authorization.orderURL)
+			}
+			fake.writeJson(w, http.StatusOK, challenge)
+			return
+		}
+	}
+	fake.writeProblem(w, http.StatusNotFound, "malformed", "no such challenge")
+}
+
+// updateOrder makes an order ready once all of its authorizations are valid.
+func (fake *fakeAcme) updateOrder(orderURL string) {
+	order := fake.orders[orderURL]
+	for _, authorizationURL := range order.order.Authorizations {
+		if fake.authorizations[authorizationURL].authorization.Status != "valid" {
+			return
+		}
+	}
+	order.order.Status = "ready"
+}
+
+func (fake *fakeAcme) serveFinalize(w http.ResponseWriter, url string, payload []byte) {
+	for _, order := range fake.orders {
+		if order.order.Finalize != url {
+			continue
+		}
+		if order.order.Status != "ready" {
+			fake.writeProblem(w, http.StatusForbidden, "orderNotReady", "order is "+order.order.Status)
+			return
+		}
+		var request struct{ Csr string }
+		json.Unmarshal(payload, &request)
+		der, _ := base64.RawURLEncoding.DecodeString(request.Csr)
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			fake.writeProblem(w, http.StatusBadRequest, "badCSR", err.Error())
+			return
+		}
+		names := []string{}
+		for _, identifier := range order.order.Identifiers {
+			names = append(names, identifier.Value)
+		}
+		if strings.Join(csr.DNSNames, ",") != strings.Join(names, ",") {
+			fake.writeProblem(w, http.StatusBadRequest, "badCSR", fmt.Sprintf("CSR is for %v, not %v", csr.DNSNames, names))
+			return
+		}
+		serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+		certificate, _ := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: serial,
+			Subject:      pkix.Name{CommonName: names[0]},
+			DNSNames:     names,
+			NotBefore:    time.Now().Add(-time.Minute),
+			NotAfter:     time.Now().Add(fake.validity),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}, fake.caCert, csr.PublicKey, fake.caKey)
+		order.certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})) +
+			string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: fake.caCert.Raw}))
+		order.order.Status = "valid"
+		order.order.Certificate = fake.newUrl("cert")
+		fake.writeJson(w, http.StatusOK, order.order)
+		return
+	}
+	fake.writeProblem(w, http.StatusNotFound, "malformed", "no such order")
+}
@@ -5,20 +5,32 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	vtm "github.com/pulse-vadc/go-vtm/7.0"
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"base_url": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VTM_BASE_URL", nil),
-				Description: "Base URL: 'https://vtm:9070/api' or 'https://sd:8100/api/tmcm/<ver>/instance/<vtm>",
+				Description: "Base URL: 'https://vtm:9070/api' or 'https://sd:8100/api/tmcm/<ver>/instance/<vtm>' (required unless services_director is set)",
+			},
+			"services_director": servicesDirectorSchema(),
+			"endpoints":         endpointsSchema(),
+			"rollout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "serial",
+				ValidateFunc: validation.StringInSlice([]string{"serial", "parallel"}, false),
+				Description:  "How writes reach the endpoints or Services Director instances after the first: 'serial', in turn, or 'parallel', at once",
 			},
 			"username": &schema.Schema{
 				Type:        schema.TypeString,
@@ -28,9 +40,9 @@ func Provider() terraform.ResourceProvider {
 			},
 			"password": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VTM_PASSWORD", nil),
-				Description: "vTM admin password",
+				Description: "vTM admin password (required unless endpoints is set)",
 			},
 			"verify_ssl_cert": &schema.Schema{
 				Type:        schema.TypeBool,
@@ -38,51 +50,151 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("VTM_VERIFY_SSL_CERT", true),
 				Description: "Check that vTM REST interface SSL certificate is trusted",
 			},
+			"api_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VTM_API_VERSION", nil),
+				ValidateFunc: validation.StringInSlice(vtm.SupportedApiVersions, false),
+				Description:  "REST API version to use (default: the newest version supported by both the vTM and the provider)",
+			},
+			"request_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VTM_REQUEST_TIMEOUT", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in seconds for each request to the REST API (0 for no timeout)",
+			},
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VTM_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a failed request to the REST API is retried",
+			},
+			"retry_backoff_min": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Delay in seconds before the first retry, doubled with jitter for each further retry",
+			},
+			"retry_backoff_max": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum delay in seconds between retries",
+			},
+			"retryable_status_codes": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(400, 599)},
+				Description: "HTTP status codes which are retried (default: 429, 502, 503, 504)",
+			},
+			"ca_cert_pem": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA certificates trusted to sign the REST interface SSL certificate, in place of the system roots",
+			},
+			"ca_cert_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path of a file of PEM encoded CA certificates, as for ca_cert_pem",
+			},
+			"client_cert_pem": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded client certificate to authenticate to the REST interface with",
+			},
+			"client_key_pem": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of client_cert_pem",
+			},
+			"tls_server_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Host name to check the REST interface SSL certificate against, if not that of base_url",
+			},
+			"pinned_sha256_fingerprints": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateSha256Fingerprint},
+				Description: "SHA-256 fingerprints of the REST interface SSL certificate, and with wait_for_replication those of the other cluster members; when set, a certificate matching one of them is trusted even if it is self-signed",
+			},
+			"wait_for_replication": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "After each change, wait until every traffic manager in the cluster has the same configuration for the object",
+			},
+			"replication_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Time in seconds to wait for each change to be replicated before failing, with wait_for_replication",
+			},
+			"strict_references": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check when planning that the objects named by virtual servers and pools exist or are being created",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"vtm_backups_full":          resourceSystemBackupsFull(),
-			"vtm_action":                resourceAction(),
-			"vtm_action_program":        resourceActionProgram(),
-			"vtm_appliance_nat":         resourceApplianceNat(),
-			"vtm_aptimizer_profile":     resourceAptimizerProfile(),
-			"vtm_aptimizer_scope":       resourceAptimizerScope(),
-			"vtm_bandwidth":             resourceBandwidth(),
-			"vtm_bgpneighbor":           resourceBgpneighbor(),
-			"vtm_cloud_api_credential":  resourceCloudApiCredential(),
-			"vtm_custom":                resourceCustom(),
-			"vtm_dns_server_zone":       resourceDnsServerZone(),
-			"vtm_dns_server_zone_file":  resourceDnsServerZoneFile(),
-			"vtm_event_type":            resourceEventType(),
-			"vtm_extra_file":            resourceExtraFile(),
-			"vtm_glb_service":           resourceGlbService(),
-			"vtm_global_settings":       resourceGlobalSettings(),
-			"vtm_kerberos_keytab":       resourceKerberosKeytab(),
-			"vtm_kerberos_krb5conf":     resourceKerberosKrb5Conf(),
-			"vtm_kerberos_principal":    resourceKerberosPrincipal(),
-			"vtm_license_key":           resourceLicenseKey(),
-			"vtm_location":              resourceLocation(),
-			"vtm_log_export":            resourceLogExport(),
-			"vtm_monitor":               resourceMonitor(),
-			"vtm_monitor_script":        resourceMonitorScript(),
-			"vtm_persistence":           resourcePersistence(),
-			"vtm_pool":                  resourcePool(),
-			"vtm_protection":            resourceProtection(),
-			"vtm_rate":                  resourceRate(),
-			"vtm_rule":                  resourceRule(),
-			"vtm_rule_authenticator":    resourceRuleAuthenticator(),
-			"vtm_saml_trustedidp":       resourceSamlTrustedidp(),
-			"vtm_security":              resourceSecurity(),
-			"vtm_service_level_monitor": resourceServiceLevelMonitor(),
-			"vtm_servicediscovery":      resourceServicediscovery(),
-			"vtm_ssl_ca":                resourceSslCa(),
-			"vtm_ssl_client_key":        resourceSslClientKey(),
-			"vtm_ssl_server_key":        resourceSslServerKey(),
-			"vtm_ssl_ticket_key":        resourceSslTicketKey(),
-			"vtm_traffic_ip_group":      resourceTrafficIpGroup(),
-			"vtm_traffic_manager":       resourceTrafficManager(),
-			"vtm_user_authenticator":    resourceUserAuthenticator(),
-			"vtm_user_group":            resourceUserGroup(),
-			"vtm_virtual_server":        resourceVirtualServer(),
+			"vtm_acme_certificate":                strictReferences("vtm_acme_certificate", resourceAcmeCertificate()),
+			"vtm_backups_full":                    resourceSystemBackupsFull(),
+			"vtm_action":                          resourceAction(),
+			"vtm_action_program":                  resourceActionProgram(),
+			"vtm_appliance_nat":                   resourceApplianceNat(),
+			"vtm_aptimizer_profile":               resourceAptimizerProfile(),
+			"vtm_aptimizer_scope":                 resourceAptimizerScope(),
+			"vtm_bandwidth":                       strictReferences("vtm_bandwidth", resourceBandwidth()),
+			"vtm_bgpneighbor":                     resourceBgpneighbor(),
+			"vtm_cloud_api_credential":            strictReferences("vtm_cloud_api_credential", resourceCloudApiCredential()),
+			"vtm_config_object":                   resourceConfigObject(),
+			"vtm_custom":                          resourceCustom(),
+			"vtm_dns_server_zone":                 resourceDnsServerZone(),
+			"vtm_dns_server_zone_file":            resourceDnsServerZoneFile(),
+			"vtm_event_type":                      resourceEventType(),
+			"vtm_extra_file":                      resourceExtraFile(),
+			"vtm_glb_service":                     strictReferences("vtm_glb_service", resourceGlbService()),
+			"vtm_global_settings":                 resourceGlobalSettings(),
+			"vtm_kerberos_keytab":                 resourceKerberosKeytab(),
+			"vtm_kerberos_krb5conf":               resourceKerberosKrb5Conf(),
+			"vtm_kerberos_principal":              resourceKerberosPrincipal(),
+			"vtm_license_key":                     resourceLicenseKey(),
+			"vtm_location":                        resourceLocation(),
+			"vtm_log_export":                      resourceLogExport(),
+			"vtm_monitor":                         strictReferences("vtm_monitor", resourceMonitor()),
+			"vtm_monitor_script":                  resourceMonitorScript(),
+			"vtm_persistence":                     strictReferences("vtm_persistence", resourcePersistence()),
+			"vtm_pool":                            strictReferences("vtm_pool", resourcePool()),
+			"vtm_pool_node":                       resourcePoolNode(),
+			"vtm_pool_node_drain":                 resourcePoolNodeDrain(),
+			"vtm_protection":                      strictReferences("vtm_protection", resourceProtection()),
+			"vtm_rate":                            resourceRate(),
+			"vtm_rule":                            strictReferences("vtm_rule", resourceRule()),
+			"vtm_rule_authenticator":              resourceRuleAuthenticator(),
+			"vtm_saml_trustedidp":                 resourceSamlTrustedidp(),
+			"vtm_security":                        resourceSecurity(),
+			"vtm_service_level_monitor":           strictReferences("vtm_service_level_monitor", resourceServiceLevelMonitor()),
+			"vtm_servicediscovery":                resourceServicediscovery(),
+			"vtm_ssl_ca":                          strictReferences("vtm_ssl_ca", resourceSslCa()),
+			"vtm_ssl_client_key":                  resourceSslClientKey(),
+			"vtm_ssl_server_key":                  strictReferences("vtm_ssl_server_key", resourceSslServerKey()),
+			"vtm_ssl_ticket_key":                  resourceSslTicketKey(),
+			"vtm_ssl_ticket_key_rotation":         resourceSslTicketKeyRotation(),
+			"vtm_traffic_ip_group":                resourceTrafficIpGroup(),
+			"vtm_traffic_manager":                 resourceTrafficManager(),
+			"vtm_user_authenticator":              resourceUserAuthenticator(),
+			"vtm_user_group":                      resourceUserGroup(),
+			"vtm_virtual_server":                  strictReferences("vtm_virtual_server", resourceVirtualServer()),
+			"vtm_virtual_server_ssl_host_mapping": strictReferences("vtm_virtual_server_ssl_host_mapping", resourceVirtualServerSslHostMapping()),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"vtm_backups_full":                                     dataSourceSystemBackupsFull(),
@@ -92,7 +204,7 @@ func Provider() terraform.ResourceProvider {
 			"vtm_action_list":                                      dataSourceActionList(),
 			"vtm_action_program":                                   dataSourceActionProgram(),
 			"vtm_action_program_list":                              dataSourceActionProgramList(),
-			"vtm_action_stats":                                     dataSourceActionStatistics(),
+			"vtm_action_stats":                                     clusterStatistics(dataSourceActionStatistics()),
 			"vtm_appliance_nat":                                    dataSourceApplianceNat(),
 			"vtm_appliance_nat_many_to_one_all_ports_table":        dataSourceApplianceNatManyToOneAllPortsTable(),
 			"vtm_appliance_nat_many_to_one_port_locked_table":      dataSourceApplianceNatManyToOnePortLockedTable(),
@@ -104,20 +216,21 @@ func Provider() terraform.ResourceProvider {
 			"vtm_aptimizer_scope_list":                             dataSourceAptimizerScopeList(),
 			"vtm_bandwidth":                                        dataSourceBandwidth(),
 			"vtm_bandwidth_list":                                   dataSourceBandwidthList(),
-			"vtm_bandwidth_stats":                                  dataSourceBandwidthStatistics(),
+			"vtm_bandwidth_stats":                                  clusterStatistics(dataSourceBandwidthStatistics()),
 			"vtm_bgpneighbor":                                      dataSourceBgpneighbor(),
 			"vtm_bgpneighbor_list":                                 dataSourceBgpneighborList(),
-			"vtm_cache_asp_session_cache_stats":                    dataSourceCacheAspSessionCacheStatistics(),
-			"vtm_cache_ip_session_cache_stats":                     dataSourceCacheIpSessionCacheStatistics(),
-			"vtm_cache_j2ee_session_cache_stats":                   dataSourceCacheJ2EeSessionCacheStatistics(),
-			"vtm_cache_ssl_cache_stats":                            dataSourceCacheSslCacheStatistics(),
-			"vtm_cache_ssl_session_cache_stats":                    dataSourceCacheSslSessionCacheStatistics(),
-			"vtm_cache_uni_session_cache_stats":                    dataSourceCacheUniSessionCacheStatistics(),
-			"vtm_cache_web_cache_stats":                            dataSourceCacheWebCacheStatistics(),
+			"vtm_cache_asp_session_cache_stats":                    clusterStatistics(dataSourceCacheAspSessionCacheStatistics()),
+			"vtm_cache_ip_session_cache_stats":                     clusterStatistics(dataSourceCacheIpSessionCacheStatistics()),
+			"vtm_cache_j2ee_session_cache_stats":                   clusterStatistics(dataSourceCacheJ2EeSessionCacheStatistics()),
+			"vtm_cache_ssl_cache_stats":                            clusterStatistics(dataSourceCacheSslCacheStatistics()),
+			"vtm_cache_ssl_session_cache_stats":                    clusterStatistics(dataSourceCacheSslSessionCacheStatistics()),
+			"vtm_cache_uni_session_cache_stats":                    clusterStatistics(dataSourceCacheUniSessionCacheStatistics()),
+			"vtm_cache_web_cache_stats":                            clusterStatistics(dataSourceCacheWebCacheStatistics()),
 			"vtm_cloud_api_credential":                             dataSourceCloudApiCredential(),
 			"vtm_cloud_api_credential_list":                        dataSourceCloudApiCredentialList(),
-			"vtm_cloud_api_credential_stats":                       dataSourceCloudApiCredentialStatistics(),
-			"vtm_connection_rate_limit_stats":                      dataSourceConnectionRateLimitStatistics(),
+			"vtm_cloud_api_credential_stats":                       clusterStatistics(dataSourceCloudApiCredentialStatistics()),
+			"vtm_config_object":                                    dataSourceConfigObject(),
+			"vtm_connection_rate_limit_stats":                      clusterStatistics(dataSourceConnectionRateLimitStatistics()),
 			"vtm_custom":                                           dataSourceCustom(),
 			"vtm_custom_list":                                      dataSourceCustomList(),
 			"vtm_custom_string_lists_table":                        dataSourceCustomStringListsTable(),
@@ -125,21 +238,22 @@ func Provider() terraform.ResourceProvider {
 			"vtm_dns_server_zone_file":                             dataSourceDnsServerZoneFile(),
 			"vtm_dns_server_zone_file_list":                        dataSourceDnsServerZoneFileList(),
 			"vtm_dns_server_zone_list":                             dataSourceDnsServerZoneList(),
-			"vtm_event_stats":                                      dataSourceEventStatistics(),
+			"vtm_event_stats":                                      clusterStatistics(dataSourceEventStatistics()),
 			"vtm_event_type":                                       dataSourceEventType(),
 			"vtm_event_type_list":                                  dataSourceEventTypeList(),
 			"vtm_extra_file":                                       dataSourceExtraFile(),
 			"vtm_extra_file_list":                                  dataSourceExtraFileList(),
-			"vtm_extras_user_counters_32_stats":                    dataSourceExtrasUserCounters32Statistics(),
-			"vtm_extras_user_counters_64_stats":                    dataSourceExtrasUserCounters64Statistics(),
+			"vtm_extras_user_counters_32_stats":                    clusterStatistics(dataSourceExtrasUserCounters32Statistics()),
+			"vtm_extras_user_counters_64_stats":                    clusterStatistics(dataSourceExtrasUserCounters64Statistics()),
 			"vtm_glb_service":                                      dataSourceGlbService(),
 			"vtm_glb_service_dnssec_keys_table":                    dataSourceGlbServiceDnssecKeysTable(),
 			"vtm_glb_service_list":                                 dataSourceGlbServiceList(),
 			"vtm_glb_service_location_settings_table":              dataSourceGlbServiceLocationSettingsTable(),
-			"vtm_glb_service_stats":                                dataSourceGlbServiceStatistics(),
+			"vtm_glb_service_stats":                                clusterStatistics(dataSourceGlbServiceStatistics()),
 			"vtm_global_settings":                                  dataSourceGlobalSettings(),
 			"vtm_global_settings_appliance_returnpath_table":       dataSourceGlobalSettingsApplianceReturnpathTable(),
-			"vtm_globals_stats":                                    dataSourceGlobalsStatistics(),
+			"vtm_globals_stats":                                    clusterStatistics(dataSourceGlobalsStatistics()),
+			"vtm_health_gate":                                      dataSourceHealthGate(),
 			"vtm_information":                                      dataSourceSystemInformation(),
 			"vtm_kerberos_keytab":                                  dataSourceKerberosKeytab(),
 			"vtm_kerberos_keytab_list":                             dataSourceKerberosKeytabList(),
@@ -149,10 +263,10 @@ func Provider() terraform.ResourceProvider {
 			"vtm_kerberos_principal_list":                          dataSourceKerberosPrincipalList(),
 			"vtm_license_key":                                      dataSourceLicenseKey(),
 			"vtm_license_key_list":                                 dataSourceLicenseKeyList(),
-			"vtm_listen_ip_stats":                                  dataSourceListenIpStatistics(),
+			"vtm_listen_ip_stats":                                  clusterStatistics(dataSourceListenIpStatistics()),
 			"vtm_location":                                         dataSourceLocation(),
 			"vtm_location_list":                                    dataSourceLocationList(),
-			"vtm_location_stats":                                   dataSourceLocationStatistics(),
+			"vtm_location_stats":                                   clusterStatistics(dataSourceLocationStatistics()),
 			"vtm_log_export":                                       dataSourceLogExport(),
 			"vtm_log_export_list":                                  dataSourceLogExportList(),
 			"vtm_log_export_metadata_table":                        dataSourceLogExportMetadataTable(),
@@ -161,19 +275,19 @@ func Provider() terraform.ResourceProvider {
 			"vtm_monitor_list":                                     dataSourceMonitorList(),
 			"vtm_monitor_script":                                   dataSourceMonitorScript(),
 			"vtm_monitor_script_list":                              dataSourceMonitorScriptList(),
-			"vtm_network_interface_stats":                          dataSourceNetworkInterfaceStatistics(),
-			"vtm_nodes_node_inet46_stats":                          dataSourceNodesNodeInet46Statistics(),
-			"vtm_nodes_node_stats":                                 dataSourceNodesNodeStatistics(),
-			"vtm_nodes_per_pool_node_stats":                        dataSourceNodesPerPoolNodeStatistics(),
-			"vtm_per_location_service_stats":                       dataSourcePerLocationServiceStatistics(),
-			"vtm_per_node_slm_per_node_service_level_inet46_stats": dataSourcePerNodeSlmPerNodeServiceLevelInet46Statistics(),
-			"vtm_per_node_slm_per_node_service_level_stats":        dataSourcePerNodeSlmPerNodeServiceLevelStatistics(),
+			"vtm_network_interface_stats":                          clusterStatistics(dataSourceNetworkInterfaceStatistics()),
+			"vtm_nodes_node_inet46_stats":                          clusterStatistics(dataSourceNodesNodeInet46Statistics()),
+			"vtm_nodes_node_stats":                                 clusterStatistics(dataSourceNodesNodeStatistics()),
+			"vtm_nodes_per_pool_node_stats":                        clusterStatistics(dataSourceNodesPerPoolNodeStatistics()),
+			"vtm_per_location_service_stats":                       clusterStatistics(dataSourcePerLocationServiceStatistics()),
+			"vtm_per_node_slm_per_node_service_level_inet46_stats": clusterStatistics(dataSourcePerNodeSlmPerNodeServiceLevelInet46Statistics()),
+			"vtm_per_node_slm_per_node_service_level_stats":        clusterStatistics(dataSourcePerNodeSlmPerNodeServiceLevelStatistics()),
 			"vtm_persistence":                                      dataSourcePersistence(),
 			"vtm_persistence_list":                                 dataSourcePersistenceList(),
 			"vtm_pool":                                             dataSourcePool(),
 			"vtm_pool_list":                                        dataSourcePoolList(),
 			"vtm_pool_nodes_table_table":                           dataSourcePoolNodesTableTable(),
-			"vtm_pool_stats":                                       dataSourcePoolStatistics(),
+			"vtm_pool_stats":                                       clusterStatistics(dataSourcePoolStatistics()),
 			"vtm_protection":                                       dataSourceProtection(),
 			"vtm_protection_list":                                  dataSourceProtectionList(),
 			"vtm_rate":                                             dataSourceRate(),
@@ -181,23 +295,25 @@ func Provider() terraform.ResourceProvider {
 			"vtm_rule":                                             dataSourceRule(),
 			"vtm_rule_authenticator":                               dataSourceRuleAuthenticator(),
 			"vtm_rule_authenticator_list":                          dataSourceRuleAuthenticatorList(),
-			"vtm_rule_authenticator_stats":                         dataSourceRuleAuthenticatorStatistics(),
+			"vtm_rule_authenticator_stats":                         clusterStatistics(dataSourceRuleAuthenticatorStatistics()),
+			"vtm_rule_lint":                                        dataSourceRuleLint(),
 			"vtm_rule_list":                                        dataSourceRuleList(),
-			"vtm_rule_stats":                                       dataSourceRuleStatistics(),
+			"vtm_rule_stats":                                       clusterStatistics(dataSourceRuleStatistics()),
 			"vtm_saml_trustedidp":                                  dataSourceSamlTrustedidp(),
 			"vtm_saml_trustedidp_list":                             dataSourceSamlTrustedidpList(),
 			"vtm_security":                                         dataSourceSecurity(),
 			"vtm_service_level_monitor":                            dataSourceServiceLevelMonitor(),
 			"vtm_service_level_monitor_list":                       dataSourceServiceLevelMonitorList(),
-			"vtm_service_level_monitor_stats":                      dataSourceServiceLevelMonitorStatistics(),
-			"vtm_service_protection_stats":                         dataSourceServiceProtectionStatistics(),
+			"vtm_service_level_monitor_stats":                      clusterStatistics(dataSourceServiceLevelMonitorStatistics()),
+			"vtm_service_protection_stats":                         clusterStatistics(dataSourceServiceProtectionStatistics()),
 			"vtm_servicediscovery":                                 dataSourceServicediscovery(),
 			"vtm_servicediscovery_list":                            dataSourceServicediscoveryList(),
 			"vtm_ssl_ca":                                           dataSourceSslCa(),
 			"vtm_ssl_ca_list":                                      dataSourceSslCaList(),
+			"vtm_ssl_certificate_info":                             dataSourceSslCertificateInfo(),
 			"vtm_ssl_client_key":                                   dataSourceSslClientKey(),
 			"vtm_ssl_client_key_list":                              dataSourceSslClientKeyList(),
-			"vtm_ssl_ocsp_stapling_stats":                          dataSourceSslOcspStaplingStatistics(),
+			"vtm_ssl_ocsp_stapling_stats":                          clusterStatistics(dataSourceSslOcspStaplingStatistics()),
 			"vtm_ssl_server_key":                                   dataSourceSslServerKey(),
 			"vtm_ssl_server_key_list":                              dataSourceSslServerKeyList(),
 			"vtm_ssl_ticket_key":                                   dataSourceSslTicketKey(),
@@ -206,9 +322,9 @@ func Provider() terraform.ResourceProvider {
 			"vtm_traffic_ip_group":                                 dataSourceTrafficIpGroup(),
 			"vtm_traffic_ip_group_ip_mapping_table":                dataSourceTrafficIpGroupIpMappingTable(),
 			"vtm_traffic_ip_group_list":                            dataSourceTrafficIpGroupList(),
-			"vtm_traffic_ips_ip_gateway_stats":                     dataSourceTrafficIpsIpGatewayStatistics(),
-			"vtm_traffic_ips_traffic_ip_inet46_stats":              dataSourceTrafficIpsTrafficIpInet46Statistics(),
-			"vtm_traffic_ips_traffic_ip_stats":                     dataSourceTrafficIpsTrafficIpStatistics(),
+			"vtm_traffic_ips_ip_gateway_stats":                     clusterStatistics(dataSourceTrafficIpsIpGatewayStatistics()),
+			"vtm_traffic_ips_traffic_ip_inet46_stats":              clusterStatistics(dataSourceTrafficIpsTrafficIpInet46Statistics()),
+			"vtm_traffic_ips_traffic_ip_stats":                     clusterStatistics(dataSourceTrafficIpsTrafficIpStatistics()),
 			"vtm_traffic_manager":                                  dataSourceTrafficManager(),
 			"vtm_traffic_manager_appliance_card_table":             dataSourceTrafficManagerApplianceCardTable(),
 			"vtm_traffic_manager_appliance_sysctl_table":           dataSourceTrafficManagerApplianceSysctlTable(),
@@ -228,10 +344,16 @@ func Provider() terraform.ResourceProvider {
 			"vtm_virtual_server_ocsp_issuers_table":                dataSourceVirtualServerOcspIssuersTable(),
 			"vtm_virtual_server_profile_table":                     dataSourceVirtualServerProfileTable(),
 			"vtm_virtual_server_server_cert_host_mapping_table":    dataSourceVirtualServerServerCertHostMappingTable(),
-			"vtm_virtual_server_stats":                             dataSourceVirtualServerStatistics(),
+			"vtm_virtual_server_stats":                             clusterStatistics(dataSourceVirtualServerStatistics()),
 		},
 		ConfigureFunc: configureProvider,
 	}
+	for resourceType, resource := range provider.ResourcesMap {
+		if endpointDriftExcluded[resourceType] != true {
+			provider.ResourcesMap[resourceType] = endpointDrift(resource)
+		}
+	}
+	return provider
 }
 
 func configureProvider(d *schema.ResourceData) (interface{}, error) {
@@ -240,9 +362,90 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	password := d.Get("password").(string)
 	verifySslCert := d.Get("verify_ssl_cert").(bool)
 
-	tm, contactable, contactErr := vtm.NewVirtualTrafficManager(baseUrl, username, password, verifySslCert, true)
-	if contactable != true {
-		return nil, fmt.Errorf("Failed to connect to Virtual Traffic Manager at '%v': %v", baseUrl, contactErr.ErrorText)
+	options := vtm.DefaultConnectionOptions()
+	options.RequestTimeout = time.Duration(d.Get("request_timeout").(int)) * time.Second
+	options.MaxRetries = d.Get("max_retries").(int)
+	options.RetryBackoffMin = time.Duration(d.Get("retry_backoff_min").(int)) * time.Second
+	options.RetryBackoffMax = time.Duration(d.Get("retry_backoff_max").(int)) * time.Second
+	if codes := d.Get("retryable_status_codes").(*schema.Set); codes.Len() > 0 {
+		options.RetryableStatusCodes = []int{}
+		for _, code := range codes.List() {
+			options.RetryableStatusCodes = append(options.RetryableStatusCodes, code.(int))
+		}
+	}
+	if options.RetryBackoffMax < options.RetryBackoffMin {
+		return nil, fmt.Errorf("retry_backoff_max (%d) must not be less than retry_backoff_min (%d)", d.Get("retry_backoff_max").(int), d.Get("retry_backoff_min").(int))
+	}
+	if err := setTLSOptions(d, &options); err != nil {
+		return nil, err
+	}
+	options.ParallelWrites = d.Get("rollout").(string) == "parallel"
+	if d.Get("wait_for_replication").(bool) {
+		if len(d.Get("services_director").([]interface{})) > 0 {
+			return nil, fmt.Errorf("wait_for_replication cannot be used with services_director")
+		}
+		options.ReplicationTimeout = time.Duration(d.Get("replication_timeout").(int)) * time.Second
+	}
+
+	if password == "" && len(d.Get("endpoints").([]interface{})) == 0 {
+		return nil, fmt.Errorf("password must be set")
+	}
+
+	var tm *vtm.VirtualTrafficManager
+	if sdSettings := d.Get("services_director").([]interface{}); len(sdSettings) > 0 {
+		var err error
+		if tm, baseUrl, err = connectServicesDirector(sdSettings[0].(map[string]interface{}), username, password, verifySslCert, options); err != nil {
+			return nil, err
+		}
+	} else if endpoints := d.Get("endpoints").([]interface{}); len(endpoints) > 0 {
+		var err error
+		if tm, baseUrl, err = connectEndpoints(endpoints, verifySslCert, options); err != nil {
+			return nil, err
+		}
+	} else {
+		if baseUrl == "" {
+			return nil, fmt.Errorf("One of base_url, services_director or endpoints must be set")
+		}
+		directTm, contactable, contactErr := vtm.NewVirtualTrafficManagerWithOptions(baseUrl, username, password, verifySslCert, true, options)
+		if contactable != true {
+			return nil, fmt.Errorf("Failed to connect to Virtual Traffic Manager at '%v': %v", baseUrl, contactErr.ErrorText)
+		}
+		tm = directTm
+	}
+	if _, versionErr := tm.NegotiateApiVersion(d.Get("api_version").(string)); versionErr != nil {
+		return nil, fmt.Errorf("Failed to select a REST API version for Virtual Traffic Manager at '%v': %v", baseUrl, versionErr.ErrorText)
+	}
+	if d.Get("strict_references").(bool) {
+		enableStrictReferences(tm)
 	}
 	return tm, nil
 }
+
+// setTLSOptions copies the CA bundle, client certificate, server name and pinned fingerprints into options.
+func setTLSOptions(d *schema.ResourceData, options *vtm.ConnectionOptions) error {
+	options.CACertPEM = d.Get("ca_cert_pem").(string)
+	if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+		content, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read ca_cert_file: %v", err)
+		}
+		options.CACertPEM = string(content)
+	}
+	options.ClientCertPEM = d.Get("client_cert_pem").(string)
+	options.ClientKeyPEM = d.Get("client_key_pem").(string)
+	if (options.ClientCertPEM == "") != (options.ClientKeyPEM == "") {
+		return fmt.Errorf("client_cert_pem and client_key_pem must be set together")
+	}
+	options.TLSServerName = d.Get("tls_server_name").(string)
+	for _, fingerprint := range d.Get("pinned_sha256_fingerprints").(*schema.Set).List() {
+		options.PinnedFingerprints = append(options.PinnedFingerprints, fingerprint.(string))
+	}
+	return nil
+}
+
+func validateSha256Fingerprint(v interface{}, k string) (ws []string, es []error) {
+	if _, err := vtm.NormaliseFingerprint(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be a SHA-256 fingerprint such as \"AB:CD:...\", got %q", k, v))
+	}
+	return
+}
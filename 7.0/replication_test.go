@@ -0,0 +1,131 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * These tests cover the following cases:
+ *   - Writes succeeding once every cluster member has the object
+ *   - Writes waiting for a member which catches up later
+ *   - Writes failing when a member has not caught up before the timeout
+ *   - Members whose REST API cannot be reached being checked through the first member's state,
+ *     and writes of objects other than pools failing on them as not verifiable
+ */
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+/*
+getTestReplicatedVtm sets up a cluster of two fake vTMs, "localhost" and "127.0.0.1", and configures
+a provider for the first which waits for replication. Nothing is replicated between the fakes, so
+the tests make changes to the second member themselves.
+*/
+func getTestReplicatedVtm(t *testing.T) (*vtm.VirtualTrafficManager, *vtm.VirtualTrafficManager) {
+	fakes := []*fakeVtm{newFakeVtm(), newFakeVtm()}
+	return configureTestReplicatedVtm(t, fakes[0], fakes[1].URL()), getDirectTestVtm(t, fakes[1])
+}
+
+// configureTestReplicatedVtm makes a cluster of the fake, as "localhost", and a second member,
+// "127.0.0.1", at the given URL, and configures a provider for the fake which waits for replication.
+func configureTestReplicatedVtm(t *testing.T, fake *fakeVtm, secondUrl string) *vtm.VirtualTrafficManager {
+	first := getDirectTestVtm(t, fake)
+	for i, name := range []string{"localhost", "127.0.0.1"} {
+		serverUrl, _ := url.Parse([]string{fake.URL(), secondUrl}[i])
+		port, _ := strconv.Atoi(serverUrl.Port())
+		member := first.NewTrafficManager(name)
+		member.RestApi.Port = &port
+		if _, applyErr := member.Apply(); applyErr != nil {
+			t.Fatalf("Failed to add cluster member %s: %v", name, applyErr)
+		}
+	}
+	if deleteErr := first.DeleteTrafficManager(fakeVtmHostname); deleteErr != nil {
+		t.Fatalf("Failed to remove cluster member %s: %v", fakeVtmHostname, deleteErr)
+	}
+
+	provider, err := configureTestProvider(map[string]interface{}{
+		"base_url":             fake.BaseUrl(),
+		"username":             fakeVtmUsername,
+		"password":             fakeVtmPassword,
+		"wait_for_replication": true,
+		"replication_timeout":  2,
+		"max_retries":          0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure provider: %v", err)
+	}
+	return provider.Meta().(*vtm.VirtualTrafficManager)
+}
+
+func TestWaitForReplication(t *testing.T) {
+	tm, second := getTestReplicatedVtm(t)
+
+	if _, applyErr := second.NewPool("replicated").Apply(); applyErr != nil {
+		t.Fatalf("Failed to create pool on the second member: %v", applyErr)
+	}
+	if _, applyErr := tm.NewPool("replicated").Apply(); applyErr != nil {
+		t.Errorf("Expected the replicated pool to be created, got %v", applyErr)
+	}
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		second.NewPool("delayed").Apply()
+	}()
+	if _, applyErr := tm.NewPool("delayed").Apply(); applyErr != nil {
+		t.Errorf("Expected the pool to be created once replicated, got %v", applyErr)
+	}
+
+	_, applyErr := tm.NewPool("unreplicated").Apply()
+	if applyErr == nil || applyErr.ErrorId != "replication.timeout" || strings.Contains(applyErr.ErrorText, "was not replicated to 127.0.0.1 within 2s") == false {
+		t.Errorf("Expected the pool not to be replicated, got %v", applyErr)
+	}
+
+	deleteErr := tm.DeletePool("replicated")
+	if deleteErr == nil || deleteErr.ErrorId != "replication.timeout" {
+		t.Errorf("Expected the deletion not to be replicated, got %v", deleteErr)
+	}
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		second.DeletePool("delayed")
+	}()
+	if deleteErr := tm.DeletePool("delayed"); deleteErr != nil {
+		t.Errorf("Expected the pool to be deleted once replicated, got %v", deleteErr)
+	}
+}
+
+func TestWaitForReplicationThroughState(t *testing.T) {
+	// Nothing listens on the second member's port, so it is checked through the first's state
+	closed := newFakeVtm()
+	closedUrl := closed.URL()
+	closed.Close()
+	tm := configureTestReplicatedVtm(t, newFakeVtm(), closedUrl)
+
+	if _, applyErr := tm.NewPool("replicated").Apply(); applyErr != nil {
+		t.Errorf("Expected the pool to be found in the member's state, got %v", applyErr)
+	}
+	_, applyErr := tm.NewMonitor("unverifiable").Apply()
+	if applyErr == nil || applyErr.ErrorId != "replication.timeout" || strings.Contains(applyErr.ErrorText, "replication could not be verified on 127.0.0.1") == false {
+		t.Errorf("Expected the monitor's replication not to be verifiable, got %v", applyErr)
+	}
+	if deleteErr := tm.DeletePool("replicated"); deleteErr != nil {
+		t.Errorf("Expected the pool to be gone from the member's state, got %v", deleteErr)
+	}
+}
+
+func TestWaitForReplicationSettings(t *testing.T) {
+	_, err := configureTestProvider(map[string]interface{}{
+		"username":             fakeSdUsername,
+		"password":             fakeSdPassword,
+		"wait_for_replication": true,
+		"services_director":    []interface{}{map[string]interface{}{"url": "https://sd.example.com:8100/api", "api_version": fakeSdApiVersion, "instance_id": "vtm-a"}},
+	})
+	if err == nil || strings.Contains(err.Error(), "wait_for_replication cannot be used with services_director") == false {
+		t.Errorf("Expected wait_for_replication to be refused with services_director, got %v", err)
+	}
+}
@@ -0,0 +1,631 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_acme_certificate obtains a certificate from an ACME server, such as
+ * Let's Encrypt, and stores it with a newly generated key as the named
+ * vtm_ssl_server_key, which virtual servers can then use.
+ *
+ * HTTP-01 challenges are answered by the vTM itself: a TrafficScript rule
+ * which serves the key authorizations is added to the front of the request
+ * rules of virtual_server, which must receive the HTTP traffic on port 80
+ * for every name in the certificate. The rule is removed once the challenges
+ * are done, whether or not they succeeded, and any rule left by an
+ * interrupted run is removed before the next order.
+ *
+ * Each plan checks whether not_after is within renew_before_days, and if so
+ * an apply renews the certificate with a new key.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// Attributes computed from the issued certificate, which change when it is renewed.
+var acmeCertificateComputedFields = []string{"certificate_pem", "issuer_pem", "not_before", "not_after"}
+
+// Attributes which only take effect by issuing a new certificate.
+var acmeCertificateKeyFields = []string{"key_algorithm", "key_bits", "key_curve"}
+
+func resourceAcmeCertificate() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourceAcmeCertificateRead,
+		Exists: resourceAcmeCertificateExists,
+		Create: resourceAcmeCertificateCreate,
+		Update: resourceAcmeCertificateUpdate,
+		Delete: resourceAcmeCertificateDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: resourceAcmeCertificateCustomizeDiff,
+
+		Schema: getResourceAcmeCertificateSchema(),
+	}
+}
+
+func getResourceAcmeCertificateSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		// The name of the vtm_ssl_server_key to store the certificate and
+		//  its key as.
+		"name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// The URL of the ACME server's directory, for example
+		//  https://acme-v02.api.letsencrypt.org/directory.
+		"directory_url": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// PEM encoded CA certificates to trust for the ACME server's TLS
+		//  certificate, instead of the system's roots, such as for a private
+		//  ACME server.
+		"directory_ca_cert_pem": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// The PEM encoded private key of the ACME account, either ECDSA
+		//  P-256 or RSA. If not set, an account is registered with a
+		//  generated key, which is kept in generated_account_key_pem.
+		"account_key_pem": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validateAcmeAccountKey,
+		},
+
+		// The account key generated when account_key_pem is not set, which
+		//  is reused for renewals. Every resource with the same directory_url
+		//  and email is given the same key in a run, so that the ACME server
+		//  registers one account for them rather than one for each.
+		"generated_account_key_pem": &schema.Schema{
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		// The contact email address for the ACME account.
+		"email": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// The host name the certificate is issued for.
+		"common_name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// Further host names for the certificate.
+		"subject_alternative_names": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+
+		// The algorithm of the certificate's key, "rsa" or "ecdsa".
+		"key_algorithm": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ecdsa", "rsa"}, false),
+			Default:      "ecdsa",
+		},
+
+		// The size of an RSA key in bits.
+		"key_bits": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+			Default:      2048,
+		},
+
+		// The curve of an ECDSA key.
+		"key_curve": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"P-256", "P-384", "P-521"}, false),
+			Default:      "P-256",
+		},
+
+		// The virtual server which receives HTTP traffic on port 80 for the
+		//  certificate's host names, to answer HTTP-01 challenges.
+		"virtual_server": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// How many days before not_after to renew the certificate.
+		"renew_before_days": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Default:      30,
+		},
+
+		// The issued certificate.
+		"certificate_pem": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		// The intermediate certificates issued with it.
+		"issuer_pem": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		// The start of the certificate's validity, in RFC 3339 format.
+		"not_before": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		// The end of the certificate's validity, in RFC 3339 format.
+		"not_after": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func validateAcmeAccountKey(v interface{}, k string) (ws []string, es []error) {
+	key, err := parseAcmeAccountKey(v.(string))
+	if err == nil {
+		err = checkAcmeAccountKey(key)
+	}
+	if err != nil {
+		es = append(es, fmt.Errorf("%q: %v", k, err))
+	}
+	return
+}
+
+// isAcmeCertificateRenewalDue reports whether a certificate ending at notAfter is to be renewed at now.
+func isAcmeCertificateRenewalDue(notAfter string, renewBeforeDays int, now time.Time) bool {
+	end, err := time.Parse(time.RFC3339, notAfter)
+	if err != nil {
+		return true
+	}
+	return now.After(end.AddDate(0, 0, -renewBeforeDays))
+}
+
+func getAcmeCertificateNames(d *schema.ResourceData) []string {
+	names := []string{d.Get("common_name").(string)}
+	for _, name := range expandStringList(d.Get("subject_alternative_names").([]interface{})) {
+		if name != names[0] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func getAcmeChallengeRuleName(name string) string {
+	return "acme-http-01-" + name
+}
+
+// getAcmeChallengeRule returns a TrafficScript rule answering HTTP-01 challenges, given the key authorization for each token.
+func getAcmeChallengeRule(name string, keyAuthorizations map[string]string) string {
+	tokens := []string{}
+	for token := range keyAuthorizations {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	rule := fmt.Sprintf("# Answers ACME HTTP-01 challenges for vtm_acme_certificate '%s'. It is removed when they are done.\n", name)
+	rule += "$path = http.getPath();\n"
+	for _, token := range tokens {
+		rule += fmt.Sprintf("if( $path == \"/.well-known/acme-challenge/%s\" ) {\n", token)
+		rule += fmt.Sprintf("   http.sendResponse( \"200 OK\", \"text/plain\", \"%s\", \"\" );\n}\n", keyAuthorizations[token])
+	}
+	return rule
+}
+
+// installAcmeChallengeRule uploads a challenge rule and adds it to the front of a virtual server's request rules.
+func installAcmeChallengeRule(tm interface{}, virtualServerName, ruleName, content string) error {
+	if err := tm.(*vtm.VirtualTrafficManager).SetRule(ruleName, content); err != nil {
+		return fmt.Errorf("Failed to create ACME challenge rule '%s': %v", ruleName, err.ErrorText)
+	}
+	defer lockVirtualServer(virtualServerName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+	if err != nil {
+		return fmt.Errorf("Failed to read virtual server '%s': %v", virtualServerName, err.ErrorText)
+	}
+	requestRules := []string{ruleName}
+	if object.Basic.RequestRules != nil {
+		requestRules = append(requestRules, *object.Basic.RequestRules...)
+	}
+	object.Basic.RequestRules = &requestRules
+	if err := applyVirtualServer(tm, object); err != nil {
+		return fmt.Errorf("Failed to add ACME challenge rule '%s' to virtual server '%s': %v", ruleName, virtualServerName, err)
+	}
+	return nil
+}
+
+// removeAcmeChallengeRule takes a challenge rule out of a virtual server's request rules, if it is there, and deletes it.
+func removeAcmeChallengeRule(tm interface{}, virtualServerName, ruleName string) error {
+	removeErr := func() error {
+		defer lockVirtualServer(virtualServerName).Unlock()
+		object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+		if err != nil {
+			if err.ErrorId == "resource.not_found" {
+				return nil
+			}
+			return fmt.Errorf("Failed to read virtual server '%s': %v", virtualServerName, err.ErrorText)
+		}
+		if object.Basic.RequestRules == nil {
+			return nil
+		}
+		requestRules := []string{}
+		for _, rule := range *object.Basic.RequestRules {
+			// Rules are disabled by prefixing their name with a slash
+			if strings.TrimPrefix(rule, "/") != ruleName {
+				requestRules = append(requestRules, rule)
+			}
+		}
+		if len(requestRules) == len(*object.Basic.RequestRules) {
+			return nil
+		}
+		object.Basic.RequestRules = &requestRules
+		if err := applyVirtualServer(tm, object); err != nil {
+			return fmt.Errorf("Failed to remove ACME challenge rule '%s' from virtual server '%s': %v", ruleName, virtualServerName, err)
+		}
+		return nil
+	}()
+	if removeErr != nil {
+		return removeErr
+	}
+	if err := tm.(*vtm.VirtualTrafficManager).DeleteRule(ruleName); err != nil && err.ErrorId != "resource.not_found" {
+		return fmt.Errorf("Failed to delete ACME challenge rule '%s': %v", ruleName, err.ErrorText)
+	}
+	return nil
+}
+
+// The account keys generated in this run, keyed by ACME directory URL and contact email.
+var acmeGeneratedAccountKeys = struct {
+	sync.Mutex
+	keys map[string]string
+}{keys: make(map[string]string)}
+
+// getGeneratedAcmeAccountKey returns the PEM encoded account key generated for a directory and email.
+func getGeneratedAcmeAccountKey(directoryURL, email string) (string, error) {
+	acmeGeneratedAccountKeys.Lock()
+	defer acmeGeneratedAccountKeys.Unlock()
+	account := directoryURL + " " + email
+	if keyPem, ok := acmeGeneratedAccountKeys.keys[account]; ok {
+		return keyPem, nil
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("Failed to generate ACME account key: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("Failed to encode ACME account key: %v", err)
+	}
+	keyPem := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	acmeGeneratedAccountKeys.keys[account] = keyPem
+	return keyPem, nil
+}
+
+/*
+issueAcmeCertificate orders a certificate for the resource's names, answers its challenges, and
+stores the certificate with a new key as the resource's vtm_ssl_server_key.
+*/
+func issueAcmeCertificate(d *schema.ResourceData, tm interface{}, timeout time.Duration) (issueErr error) {
+	deadline := time.Now().Add(timeout)
+	name := d.Get("name").(string)
+	virtualServerName := d.Get("virtual_server").(string)
+	ruleName := getAcmeChallengeRuleName(name)
+
+	// Remove any rule left by an earlier run which was interrupted
+	if err := removeAcmeChallengeRule(tm, virtualServerName, ruleName); err != nil {
+		return err
+	}
+
+	accountKeyPem := d.Get("account_key_pem").(string)
+	if accountKeyPem == "" {
+		accountKeyPem = d.Get("generated_account_key_pem").(string)
+	}
+	if accountKeyPem == "" {
+		generatedKeyPem, err := getGeneratedAcmeAccountKey(d.Get("directory_url").(string), d.Get("email").(string))
+		if err != nil {
+			return err
+		}
+		accountKeyPem = generatedKeyPem
+		d.Set("generated_account_key_pem", generatedKeyPem)
+	}
+	accountKey, err := parseAcmeAccountKey(accountKeyPem)
+	if err != nil {
+		return fmt.Errorf("Failed to parse account_key_pem: %v", err)
+	}
+	client, err := newAcmeClient(d.Get("directory_url").(string), d.Get("directory_ca_cert_pem").(string), accountKey)
+	if err != nil {
+		return err
+	}
+	if err := client.register(d.Get("email").(string)); err != nil {
+		return err
+	}
+	names := getAcmeCertificateNames(d)
+	order, orderURL, err := client.newOrder(names)
+	if err != nil {
+		return err
+	}
+
+	keyAuthorizations := make(map[string]string)
+	challenges := []acmeChallenge{}
+	pending := []string{}
+	for _, url := range order.Authorizations {
+		authorization, err := client.getAuthorization(url)
+		if err != nil {
+			return err
+		}
+		if authorization.Status == "valid" {
+			continue
+		}
+		found := false
+		for _, challenge := range authorization.Challenges {
+			if challenge.Type != "http-01" {
+				continue
+			}
+			keyAuthorization, err := client.keyAuthorization(challenge.Token)
+			if err != nil {
+				return err
+			}
+			keyAuthorizations[challenge.Token] = keyAuthorization
+			challenges = append(challenges, challenge)
+			found = true
+			break
+		}
+		if found == false {
+			return fmt.Errorf("The ACME server offers no http-01 challenge for %s", authorization.Identifier.Value)
+		}
+		pending = append(pending, url)
+	}
+
+	if len(pending) > 0 {
+		defer func() {
+			if err := removeAcmeChallengeRule(tm, virtualServerName, ruleName); err != nil {
+				if issueErr == nil {
+					issueErr = err
+				} else {
+					issueErr = fmt.Errorf("%v; %v", issueErr, err)
+				}
+			}
+		}()
+		if err := installAcmeChallengeRule(tm, virtualServerName, ruleName, getAcmeChallengeRule(name, keyAuthorizations)); err != nil {
+			return err
+		}
+		for _, challenge := range challenges {
+			if err := client.acceptChallenge(challenge); err != nil {
+				return err
+			}
+		}
+		for _, url := range pending {
+			if err := client.waitForAuthorization(url, deadline); err != nil {
+				return err
+			}
+		}
+	}
+
+	dnsNames := []interface{}{}
+	for _, dnsName := range names {
+		dnsNames = append(dnsNames, dnsName)
+	}
+	private, _, request, err := generateSslKey(map[string]interface{}{
+		"algorithm": d.Get("key_algorithm"),
+		"bits":      d.Get("key_bits"),
+		"curve":     d.Get("key_curve"),
+		"subject": []interface{}{map[string]interface{}{
+			"common_name":         names[0],
+			"organization":        "",
+			"organizational_unit": "",
+			"locality":            "",
+			"province":            "",
+			"country":             "",
+		}},
+		"dns_names":    dnsNames,
+		"ip_addresses": []interface{}{},
+		"self_signed":  false,
+	}, x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode([]byte(request))
+	order, err = client.finalize(order, orderURL, block.Bytes, deadline)
+	if err != nil {
+		return err
+	}
+	chain, err := client.downloadCertificate(order.Certificate)
+	if err != nil {
+		return err
+	}
+	if certificates, err := parsePemCertificates(chain); err != nil || len(certificates) == 0 {
+		return fmt.Errorf("The ACME server returned no valid certificate: %v", err)
+	}
+	return storeAcmeCertificate(tm, name, d.Get("directory_url").(string), private, chain, request)
+}
+
+func storeAcmeCertificate(tm interface{}, name, directoryURL, private, public, request string) error {
+	object, err := tm.(*vtm.VirtualTrafficManager).GetSslServerKey(name)
+	if err != nil {
+		if err.ErrorId != "resource.not_found" {
+			return fmt.Errorf("Failed to read vtm_ssl_server_key '%s': %v", name, err.ErrorText)
+		}
+		object = tm.(*vtm.VirtualTrafficManager).NewSslServerKey(name, "Issued by ACME server "+directoryURL, private, public, request)
+	} else {
+		object.Basic.Private = &private
+		object.Basic.Public = &public
+		object.Basic.Request = &request
+	}
+	if err := stripUnsupportedFields(tm, object, getResourceSslServerKeySchema()); err != nil {
+		return err
+	}
+	if _, applyErr := object.Apply(); applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("Failed to store the certificate as vtm_ssl_server_key '%s': %s %s", name, applyErr.ErrorText, info)
+	}
+	return nil
+}
+
+func resourceAcmeCertificateCustomizeDiff(d *schema.ResourceDiff, tm interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	renew := isAcmeCertificateRenewalDue(d.Get("not_after").(string), d.Get("renew_before_days").(int), time.Now())
+	for _, field := range acmeCertificateKeyFields {
+		renew = renew || d.HasChange(field)
+	}
+	if renew {
+		for _, field := range acmeCertificateComputedFields {
+			d.SetNewComputed(field)
+		}
+	}
+	return nil
+}
+
+func resourceAcmeCertificateRead(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	if objectName == "" {
+		objectName = d.Id()
+		d.Set("name", objectName)
+	}
+	object, err := tm.(*vtm.VirtualTrafficManager).GetSslServerKey(objectName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_acme_certificate '%v': %v", objectName, err.ErrorText)
+	}
+	for _, field := range acmeCertificateComputedFields {
+		d.Set(field, "")
+	}
+	// A certificate which cannot be parsed leaves not_after empty, so that it is renewed
+	certificates, parseErr := parsePemCertificates(getStringValue(object.Basic.Public))
+	if parseErr == nil && len(certificates) > 0 {
+		issuers := ""
+		for _, certificate := range certificates[1:] {
+			issuers += string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}))
+		}
+		d.Set("certificate_pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificates[0].Raw})))
+		d.Set("issuer_pem", issuers)
+		d.Set("not_before", certificates[0].NotBefore.UTC().Format(time.RFC3339))
+		d.Set("not_after", certificates[0].NotAfter.UTC().Format(time.RFC3339))
+		if d.Get("common_name").(string) == "" {
+			setImportedAcmeCertificateFields(d, certificates[0])
+		}
+	}
+	d.SetId(objectName)
+	return nil
+}
+
+// An imported certificate has none of the attributes it was requested with,
+// so fill those which would otherwise replace or re-issue it from the certificate.
+func setImportedAcmeCertificateFields(d *schema.ResourceData, certificate *x509.Certificate) {
+	commonName := certificate.Subject.CommonName
+	if commonName == "" && len(certificate.DNSNames) > 0 {
+		commonName = certificate.DNSNames[0]
+	}
+	alternativeNames := []string{}
+	for _, name := range certificate.DNSNames {
+		if name != commonName {
+			alternativeNames = append(alternativeNames, name)
+		}
+	}
+	d.Set("common_name", commonName)
+	d.Set("subject_alternative_names", alternativeNames)
+
+	d.Set("key_algorithm", "ecdsa")
+	d.Set("key_bits", 2048)
+	d.Set("key_curve", "P-256")
+	switch key := certificate.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		d.Set("key_curve", key.Params().Name)
+	case *rsa.PublicKey:
+		d.Set("key_algorithm", "rsa")
+		d.Set("key_bits", key.N.BitLen())
+	}
+}
+
+func resourceAcmeCertificateExists(d *schema.ResourceData, tm interface{}) (bool, error) {
+	objectName := d.Get("name").(string)
+	if objectName == "" {
+		objectName = d.Id()
+	}
+	_, err := tm.(*vtm.VirtualTrafficManager).GetSslServerKey(objectName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			return false, nil
+		}
+		return false, fmt.Errorf("%v", err.ErrorText)
+	}
+	return true, nil
+}
+
+func resourceAcmeCertificateCreate(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	if _, err := tm.(*vtm.VirtualTrafficManager).GetSslServerKey(objectName); err == nil {
+		return fmt.Errorf("Error creating vtm_acme_certificate '%s': vtm_ssl_server_key '%s' already exists, import it to manage it", objectName, objectName)
+	}
+	if err := issueAcmeCertificate(d, tm, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error creating vtm_acme_certificate '%s': %v", objectName, err)
+	}
+	d.SetId(objectName)
+	return resourceAcmeCertificateRead(d, tm)
+}
+
+func resourceAcmeCertificateUpdate(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	renew := isAcmeCertificateRenewalDue(d.Get("not_after").(string), d.Get("renew_before_days").(int), time.Now())
+	for _, field := range acmeCertificateKeyFields {
+		renew = renew || d.HasChange(field)
+	}
+	if renew {
+		if err := issueAcmeCertificate(d, tm, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("Error renewing vtm_acme_certificate '%s': %v", objectName, err)
+		}
+	}
+	return resourceAcmeCertificateRead(d, tm)
+}
+
+func resourceAcmeCertificateDelete(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	err := tm.(*vtm.VirtualTrafficManager).DeleteSslServerKey(objectName)
+	if err != nil && err.ErrorId != "resource.not_found" {
+		return fmt.Errorf("Failed to delete vtm_acme_certificate '%v': %v", objectName, err.ErrorText)
+	}
+	d.SetId("")
+	return nil
+}
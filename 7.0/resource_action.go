@@ -147,8 +147,10 @@ func getResourceActionSchema() map[string]*schema.Schema {
 
 		// The password for HTTP basic authentication.
 		"soap_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The address of the server implementing the SOAP interface (For
@@ -175,15 +177,19 @@ func getResourceActionSchema() map[string]*schema.Schema {
 		// The authentication password for sending a Notify over SNMPv3.
 		//  Blank to send unauthenticated traps.
 		"trap_auth_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The community string to use when sending a Trap over SNMPv1 or
 		//  a Notify over SNMPv2c.
 		"trap_community": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The hash algorithm for SNMPv3 authentication.
@@ -198,8 +204,10 @@ func getResourceActionSchema() map[string]*schema.Schema {
 		//  Requires that authentication also be configured. Blank to send
 		//  unencrypted traps.
 		"trap_priv_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The hostname or IPv4 address and optional port number that should
@@ -240,6 +248,8 @@ func resourceActionRead(d *schema.ResourceData, tm interface{}) (readError error
 		return fmt.Errorf("Failed to read vtm_action '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -290,7 +300,7 @@ func resourceActionRead(d *schema.ResourceData, tm interface{}) (readError error
 	lastAssignedField = "soap_additional_data"
 	d.Set("soap_additional_data", string(*object.Soap.AdditionalData))
 	lastAssignedField = "soap_password"
-	d.Set("soap_password", string(*object.Soap.Password))
+	d.Set("soap_password", getSecretFingerprint(d, "soap_password", string(*object.Soap.Password)))
 	lastAssignedField = "soap_proxy"
 	d.Set("soap_proxy", string(*object.Soap.Proxy))
 	lastAssignedField = "soap_username"
@@ -298,13 +308,13 @@ func resourceActionRead(d *schema.ResourceData, tm interface{}) (readError error
 	lastAssignedField = "syslog_sysloghost"
 	d.Set("syslog_sysloghost", string(*object.Syslog.Sysloghost))
 	lastAssignedField = "trap_auth_password"
-	d.Set("trap_auth_password", string(*object.Trap.AuthPassword))
+	d.Set("trap_auth_password", getSecretFingerprint(d, "trap_auth_password", string(*object.Trap.AuthPassword)))
 	lastAssignedField = "trap_community"
-	d.Set("trap_community", string(*object.Trap.Community))
+	d.Set("trap_community", getSecretFingerprint(d, "trap_community", string(*object.Trap.Community)))
 	lastAssignedField = "trap_hash_algorithm"
 	d.Set("trap_hash_algorithm", string(*object.Trap.HashAlgorithm))
 	lastAssignedField = "trap_priv_password"
-	d.Set("trap_priv_password", string(*object.Trap.PrivPassword))
+	d.Set("trap_priv_password", getSecretFingerprint(d, "trap_priv_password", string(*object.Trap.PrivPassword)))
 	lastAssignedField = "trap_traphost"
 	d.Set("trap_traphost", string(*object.Trap.Traphost))
 	lastAssignedField = "trap_username"
@@ -334,9 +344,12 @@ func resourceActionCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewAction(objectName, d.Get("type").(string))
 	resourceActionObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceActionSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_action '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_action '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -350,9 +363,12 @@ func resourceActionUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_action '%v': %v", objectName, err)
 	}
 	resourceActionObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceActionSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_action '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_action '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -394,14 +410,14 @@ func resourceActionObjectFieldAssignments(d *schema.ResourceData, object *vtm.Ac
 	}
 	setString(&object.Program.Program, d, "program_program")
 	setString(&object.Soap.AdditionalData, d, "soap_additional_data")
-	setString(&object.Soap.Password, d, "soap_password")
+	setSecret(&object.Soap.Password, d, "soap_password")
 	setString(&object.Soap.Proxy, d, "soap_proxy")
 	setString(&object.Soap.Username, d, "soap_username")
 	setString(&object.Syslog.Sysloghost, d, "syslog_sysloghost")
-	setString(&object.Trap.AuthPassword, d, "trap_auth_password")
-	setString(&object.Trap.Community, d, "trap_community")
+	setSecret(&object.Trap.AuthPassword, d, "trap_auth_password")
+	setSecret(&object.Trap.Community, d, "trap_community")
 	setString(&object.Trap.HashAlgorithm, d, "trap_hash_algorithm")
-	setString(&object.Trap.PrivPassword, d, "trap_priv_password")
+	setSecret(&object.Trap.PrivPassword, d, "trap_priv_password")
 	setString(&object.Trap.Traphost, d, "trap_traphost")
 	setString(&object.Trap.Username, d, "trap_username")
 	setString(&object.Trap.Version, d, "trap_version")
@@ -21,7 +21,7 @@ import (
 func TestResourceActionProgram(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestActionProgram")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckActionProgramDestroy,
@@ -206,6 +206,8 @@ func resourceApplianceNatRead(d *schema.ResourceData, tm interface{}) (readError
 		return fmt.Errorf("Failed to read vtm_nat: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -381,9 +383,12 @@ func resourceApplianceNatUpdate(d *schema.ResourceData, tm interface{}) error {
 		d.Set("port_mapping", make([]map[string]interface{}, 0, len(*object.Basic.PortMapping)))
 	}
 
+	if err := stripUnsupportedFields(tm, object, getResourceApplianceNatSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_nat: %v", err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_nat: %s %s", applyErr.ErrorText, info)
 	}
 	d.SetId("nat")
@@ -94,6 +94,8 @@ func resourceAptimizerProfileRead(d *schema.ResourceData, tm interface{}) (readE
 		return fmt.Errorf("Failed to read vtm_profile '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -134,9 +136,12 @@ func resourceAptimizerProfileCreate(d *schema.ResourceData, tm interface{}) erro
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewAptimizerProfile(objectName)
 	resourceAptimizerProfileObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceAptimizerProfileSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_profile '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_profile '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -150,9 +155,12 @@ func resourceAptimizerProfileUpdate(d *schema.ResourceData, tm interface{}) erro
 		return fmt.Errorf("Failed to update vtm_profile '%v': %v", objectName, err)
 	}
 	resourceAptimizerProfileObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceAptimizerProfileSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_profile '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_profile '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -76,6 +76,8 @@ func resourceAptimizerScopeRead(d *schema.ResourceData, tm interface{}) (readErr
 		return fmt.Errorf("Failed to read vtm_scope '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -114,9 +116,12 @@ func resourceAptimizerScopeCreate(d *schema.ResourceData, tm interface{}) error
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewAptimizerScope(objectName)
 	resourceAptimizerScopeObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceAptimizerScopeSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_scope '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_scope '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -130,9 +135,12 @@ func resourceAptimizerScopeUpdate(d *schema.ResourceData, tm interface{}) error
 		return fmt.Errorf("Failed to update vtm_scope '%v': %v", objectName, err)
 	}
 	resourceAptimizerScopeObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceAptimizerScopeSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_scope '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_scope '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -77,6 +77,8 @@ func resourceBandwidthRead(d *schema.ResourceData, tm interface{}) (readError er
 		return fmt.Errorf("Failed to read vtm_bandwidth '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -115,9 +117,12 @@ func resourceBandwidthCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewBandwidth(objectName)
 	resourceBandwidthObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceBandwidthSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_bandwidth '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_bandwidth '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -131,9 +136,12 @@ func resourceBandwidthUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_bandwidth '%v': %v", objectName, err)
 	}
 	resourceBandwidthObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceBandwidthSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_bandwidth '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_bandwidth '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceBandwidth(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestBandwidth")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckBandwidthDestroy,
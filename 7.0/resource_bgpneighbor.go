@@ -64,8 +64,10 @@ func getResourceBgpneighborSchema() map[string]*schema.Schema {
 
 		// The password to be used for authentication of sessions with neighbors
 		"authentication_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The period after which the BGP session with the neighbor is deemed
@@ -111,6 +113,8 @@ func resourceBgpneighborRead(d *schema.ResourceData, tm interface{}) (readError
 		return fmt.Errorf("Failed to read vtm_bgpneighbor '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -127,7 +131,7 @@ func resourceBgpneighborRead(d *schema.ResourceData, tm interface{}) (readError
 	lastAssignedField = "as_number"
 	d.Set("as_number", int(*object.Basic.AsNumber))
 	lastAssignedField = "authentication_password"
-	d.Set("authentication_password", string(*object.Basic.AuthenticationPassword))
+	d.Set("authentication_password", getSecretFingerprint(d, "authentication_password", string(*object.Basic.AuthenticationPassword)))
 	lastAssignedField = "holdtime"
 	d.Set("holdtime", int(*object.Basic.Holdtime))
 	lastAssignedField = "keepalive"
@@ -157,9 +161,12 @@ func resourceBgpneighborCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewBgpneighbor(objectName)
 	resourceBgpneighborObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceBgpneighborSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_bgpneighbor '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_bgpneighbor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -173,9 +180,12 @@ func resourceBgpneighborUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_bgpneighbor '%v': %v", objectName, err)
 	}
 	resourceBgpneighborObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceBgpneighborSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_bgpneighbor '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_bgpneighbor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -186,7 +196,7 @@ func resourceBgpneighborObjectFieldAssignments(d *schema.ResourceData, object *v
 	setString(&object.Basic.Address, d, "address")
 	setInt(&object.Basic.AdvertisementInterval, d, "advertisement_interval")
 	setInt(&object.Basic.AsNumber, d, "as_number")
-	setString(&object.Basic.AuthenticationPassword, d, "authentication_password")
+	setSecret(&object.Basic.AuthenticationPassword, d, "authentication_password")
 	setInt(&object.Basic.Holdtime, d, "holdtime")
 	setInt(&object.Basic.Keepalive, d, "keepalive")
 
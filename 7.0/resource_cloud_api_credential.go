@@ -56,22 +56,27 @@ func getResourceCloudApiCredentialSchema() map[string]*schema.Schema {
 		// The first part of the credentials for the cloud user.  Typically
 		//  this is some variation on the username concept.
 		"cred1": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
 		},
 
 		// The second part of the credentials for the cloud user.  Typically
 		//  this is some variation on the password concept.
 		"cred2": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The third part of the credentials for the cloud user.  Typically
 		//  this is some variation on the authentication token concept.
 		"cred3": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The script to call for communication with the cloud API.
@@ -107,6 +112,8 @@ func resourceCloudApiCredentialRead(d *schema.ResourceData, tm interface{}) (rea
 		return fmt.Errorf("Failed to read vtm_cloud_api_credential '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -123,9 +130,9 @@ func resourceCloudApiCredentialRead(d *schema.ResourceData, tm interface{}) (rea
 	lastAssignedField = "cred1"
 	d.Set("cred1", string(*object.Basic.Cred1))
 	lastAssignedField = "cred2"
-	d.Set("cred2", string(*object.Basic.Cred2))
+	d.Set("cred2", getSecretFingerprint(d, "cred2", string(*object.Basic.Cred2)))
 	lastAssignedField = "cred3"
-	d.Set("cred3", string(*object.Basic.Cred3))
+	d.Set("cred3", getSecretFingerprint(d, "cred3", string(*object.Basic.Cred3)))
 	lastAssignedField = "script"
 	d.Set("script", string(*object.Basic.Script))
 	lastAssignedField = "update_interval"
@@ -153,9 +160,12 @@ func resourceCloudApiCredentialCreate(d *schema.ResourceData, tm interface{}) er
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewCloudApiCredential(objectName)
 	resourceCloudApiCredentialObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceCloudApiCredentialSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_cloud_api_credential '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_cloud_api_credential '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -169,9 +179,12 @@ func resourceCloudApiCredentialUpdate(d *schema.ResourceData, tm interface{}) er
 		return fmt.Errorf("Failed to update vtm_cloud_api_credential '%v': %v", objectName, err)
 	}
 	resourceCloudApiCredentialObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceCloudApiCredentialSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_cloud_api_credential '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_cloud_api_credential '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -182,8 +195,8 @@ func resourceCloudApiCredentialObjectFieldAssignments(d *schema.ResourceData, ob
 	setString(&object.Basic.ApiServer, d, "api_server")
 	setInt(&object.Basic.CloudApiTimeout, d, "cloud_api_timeout")
 	setString(&object.Basic.Cred1, d, "cred1")
-	setString(&object.Basic.Cred2, d, "cred2")
-	setString(&object.Basic.Cred3, d, "cred3")
+	setSecret(&object.Basic.Cred2, d, "cred2")
+	setSecret(&object.Basic.Cred3, d, "cred3")
 	setString(&object.Basic.Script, d, "script")
 	setInt(&object.Basic.UpdateInterval, d, "update_interval")
 }
@@ -0,0 +1,255 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_config_object manages any configuration object by its REST path
+ * relative to config/active, for collections which the provider does not
+ * model, such as ssl/admin_cas, or which are newer than the provider.
+ *
+ * A standard object is described by a JSON properties document, holding the
+ * sections of the object and the properties in each. Only the properties in
+ * the document are managed: the document is compared with the live values of
+ * the same properties, after both are normalised, and the object's other
+ * properties are left alone. Removing a property from the document stops it
+ * from being managed, but does not reset it on the vTM. A text object, such
+ * as an extra file, is described by its content instead.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func resourceConfigObject() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourceConfigObjectRead,
+		Exists: resourceConfigObjectExists,
+		Create: resourceConfigObjectCreate,
+		Update: resourceConfigObjectUpdate,
+		Delete: resourceConfigObjectDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceConfigObjectCustomizeDiff,
+
+		Schema: getResourceConfigObjectSchema(),
+	}
+}
+
+func getResourceConfigObjectSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		// The path of the object relative to config/active, for example
+		//  "ssl/admin_cas/my-ca".
+		"path": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateConfigObjectPath,
+		},
+
+		// JSON document holding the managed properties of a standard
+		//  object, keyed by section and then by property name.
+		"properties": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ConflictsWith:    []string{"content"},
+			ValidateFunc:     validateConfigObjectProperties,
+			DiffSuppressFunc: suppressConfigObjectPropertiesDiff,
+		},
+
+		// The content of a text object.
+		"content": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"properties"},
+		},
+	}
+}
+
+func validateConfigObjectPath(v interface{}, k string) (ws []string, es []error) {
+	path := v.(string)
+	if path == "" || strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		es = append(es, fmt.Errorf("%q must be a path relative to config/active, such as \"ssl/admin_cas/my-ca\", got %q", k, path))
+		return
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			es = append(es, fmt.Errorf("%q must not contain empty, \".\" or \"..\" segments, got %q", k, path))
+			return
+		}
+	}
+	return
+}
+
+func validateConfigObjectProperties(v interface{}, k string) (ws []string, es []error) {
+	if _, err := parseConfigObjectProperties(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q %v", k, err))
+	}
+	return
+}
+
+// parseConfigObjectProperties decodes a properties document, which may be empty.
+func parseConfigObjectProperties(document string) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	if strings.TrimSpace(document) == "" {
+		return properties, nil
+	}
+	if err := json.Unmarshal([]byte(document), &properties); err != nil {
+		return nil, fmt.Errorf("must be a JSON object: %v", err)
+	}
+	for section, fields := range properties {
+		if _, ok := fields.(map[string]interface{}); ok != true {
+			return nil, fmt.Errorf("must hold an object of properties in each section, but section '%s' is not an object", section)
+		}
+	}
+	return properties, nil
+}
+
+// formatConfigObjectProperties encodes properties as a JSON document with its keys sorted.
+func formatConfigObjectProperties(properties map[string]interface{}) string {
+	document, _ := json.Marshal(properties)
+	return string(document)
+}
+
+/*
+getConfigObjectManagedProperties returns the live values of the properties named in managed, or
+all of the live properties if managed is empty.
+*/
+func getConfigObjectManagedProperties(live, managed map[string]interface{}) map[string]interface{} {
+	if len(managed) == 0 {
+		return live
+	}
+	properties := make(map[string]interface{})
+	for section, fields := range managed {
+		liveFields, _ := live[section].(map[string]interface{})
+		sectionProperties := make(map[string]interface{})
+		for field := range fields.(map[string]interface{}) {
+			if value, ok := liveFields[field]; ok == true {
+				sectionProperties[field] = value
+			}
+		}
+		properties[section] = sectionProperties
+	}
+	return properties
+}
+
+/*
+suppressConfigObjectPropertiesDiff suppresses the diff when every property in the new document has
+the same value in the old one. Decoding both documents normalises their formatting, the order of
+their keys and the representation of their numbers.
+*/
+func suppressConfigObjectPropertiesDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldProperties, oldErr := parseConfigObjectProperties(old)
+	newProperties, newErr := parseConfigObjectProperties(new)
+	if oldErr != nil || newErr != nil {
+		return false
+	}
+	return reflect.DeepEqual(getConfigObjectManagedProperties(oldProperties, newProperties), newProperties)
+}
+
+func resourceConfigObjectCustomizeDiff(d *schema.ResourceDiff, tm interface{}) error {
+	if d.NewValueKnown("properties") == false || d.NewValueKnown("content") == false {
+		return nil
+	}
+	if d.Get("properties") == "" && d.Get("content") == "" {
+		return fmt.Errorf("vtm_config_object '%v' must set one of properties or content", d.Get("path"))
+	}
+	return nil
+}
+
+func resourceConfigObjectRead(d *schema.ResourceData, tm interface{}) error {
+	path := d.Get("path").(string)
+	if path == "" {
+		path = d.Id()
+		d.Set("path", path)
+	}
+	object, err := tm.(*vtm.VirtualTrafficManager).GetConfigObject(path)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_config_object '%v': %v", path, err.ErrorText)
+	}
+	if object.Properties == nil {
+		d.Set("properties", "")
+		d.Set("content", object.Content)
+	} else {
+		managed, _ := parseConfigObjectProperties(d.Get("properties").(string))
+		d.Set("properties", formatConfigObjectProperties(getConfigObjectManagedProperties(object.Properties, managed)))
+		d.Set("content", "")
+	}
+	d.SetId(path)
+	return nil
+}
+
+func resourceConfigObjectExists(d *schema.ResourceData, tm interface{}) (bool, error) {
+	path := d.Get("path").(string)
+	if path == "" {
+		path = d.Id()
+	}
+	_, err := tm.(*vtm.VirtualTrafficManager).GetConfigObject(path)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			return false, nil
+		}
+		return false, fmt.Errorf("%v", err.ErrorText)
+	}
+	return true, nil
+}
+
+func resourceConfigObjectCreate(d *schema.ResourceData, tm interface{}) error {
+	path := d.Get("path").(string)
+	if err := applyConfigObject(d, tm); err != nil {
+		return fmt.Errorf("Error creating vtm_config_object '%s': %v", path, err)
+	}
+	d.SetId(path)
+	return resourceConfigObjectRead(d, tm)
+}
+
+func resourceConfigObjectUpdate(d *schema.ResourceData, tm interface{}) error {
+	if err := applyConfigObject(d, tm); err != nil {
+		return fmt.Errorf("Error updating vtm_config_object '%s': %v", d.Id(), err)
+	}
+	return resourceConfigObjectRead(d, tm)
+}
+
+func resourceConfigObjectDelete(d *schema.ResourceData, tm interface{}) error {
+	path := d.Get("path").(string)
+	err := tm.(*vtm.VirtualTrafficManager).DeleteConfigObject(path)
+	if err != nil && err.ErrorId != "resource.not_found" {
+		return fmt.Errorf("Failed to delete vtm_config_object '%v': %v", path, err.ErrorText)
+	}
+	d.SetId("")
+	return nil
+}
+
+// applyConfigObject writes the properties or the content of a resource to the vTM.
+func applyConfigObject(d *schema.ResourceData, tm interface{}) error {
+	path := d.Get("path").(string)
+	if content, ok := d.GetOk("content"); ok {
+		if err := tm.(*vtm.VirtualTrafficManager).SetConfigObjectText(path, content.(string)); err != nil {
+			return fmt.Errorf("%v", err.ErrorText)
+		}
+		return nil
+	}
+	properties, parseErr := parseConfigObjectProperties(d.Get("properties").(string))
+	if parseErr != nil {
+		return fmt.Errorf("properties %v", parseErr)
+	}
+	if _, applyErr := tm.(*vtm.VirtualTrafficManager).SetConfigObject(path, properties); applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("%s %s", applyErr.ErrorText, info)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Managing some of the properties of a standard object by path, leaving its others alone
+ *   - Ignoring differences in formatting, key order and number representation
+ *   - Reverting a managed property which was changed outside Terraform
+ *   - Managing a text object by path, and importing it
+ *   - Reading a standard object with the vtm_config_object data source
+ *   - Rejecting a properties document whose sections are not objects
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func TestResourceConfigObject(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestConfigObject")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      getConfigObjectConfig(objName, `{"basic": "note"}`, "hello"),
+				ExpectError: regexp.MustCompile("section 'basic' is not an object"),
+			},
+			{
+				Config: getConfigObjectConfig(objName, `{"basic": {"note": "first", "max_idle_connections_pernode": 10}}`, "hello"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigObjectExists,
+					testAccCheckConfigObjectPoolNote(objName, "first"),
+					resource.TestCheckResourceAttr("vtm_config_object.pool", "properties", `{"basic":{"max_idle_connections_pernode":10,"note":"first"}}`),
+					resource.TestCheckResourceAttr("vtm_config_object.file", "content", "hello"),
+					resource.TestMatchResourceAttr("data.vtm_config_object.pool", "properties", regexp.MustCompile(`"monitors":\[\]`)),
+					resource.TestCheckResourceAttr("data.vtm_config_object.file", "content", "hello"),
+				),
+			},
+			// Reformatting the document does not change the plan
+			{
+				Config:   getConfigObjectConfig(objName, `{"basic": {"max_idle_connections_pernode": 10.0, "note": "first"}}`, "hello"),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+					pool, _ := tm.GetPool(objName)
+					pool.Basic.Note = getStringAddr("changed")
+					pool.Apply()
+				},
+				Config: getConfigObjectConfig(objName, `{"basic": {"note": "first", "max_idle_connections_pernode": 10}}`, "hello"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigObjectPoolNote(objName, "first"),
+				),
+			},
+			{
+				Config: getConfigObjectConfig(objName, `{"basic": {"note": "second", "max_idle_connections_pernode": 10}}`, "goodbye"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigObjectPoolNote(objName, "second"),
+					resource.TestCheckResourceAttr("vtm_config_object.file", "content", "goodbye"),
+				),
+			},
+			{
+				ResourceName:      "vtm_config_object.file",
+				ImportState:       true,
+				ImportStateId:     "extra_files/" + objName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckConfigObjectExists(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_config_object" {
+			continue
+		}
+		path := tfResource.Primary.Attributes["path"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.GetConfigObject(path); err != nil {
+			return fmt.Errorf("Configuration object %s does not exist: %#v", path, err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckConfigObjectDestroy(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_config_object" {
+			continue
+		}
+		path := tfResource.Primary.Attributes["path"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.GetConfigObject(path); err == nil {
+			return fmt.Errorf("Configuration object %s still exists", path)
+		}
+	}
+	return nil
+}
+
+func testAccCheckConfigObjectPoolNote(poolName, note string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		pool, err := tm.GetPool(poolName)
+		if err != nil {
+			return fmt.Errorf("Pool %s does not exist: %#v", poolName, err)
+		}
+		if pool.Basic.Note == nil || *pool.Basic.Note != note {
+			return fmt.Errorf("Pool %s has note %v, expected %q", poolName, pool.Basic.Note, note)
+		}
+		return nil
+	}
+}
+
+func TestSuppressConfigObjectPropertiesDiff(t *testing.T) {
+	for _, test := range []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{`{"basic":{"a":1,"b":"x"}}`, `{ "basic": { "b": "x", "a": 1.0 } }`, true},
+		{`{"basic":{"a":1,"b":"x"},"ssl":{"c":true}}`, `{"basic":{"a":1}}`, true},
+		{`{"basic":{"a":1}}`, `{"basic":{"a":2}}`, false},
+		{`{"basic":{"a":1}}`, `{"basic":{"a":1,"b":"x"}}`, false},
+		{`{"basic":{"a":[1,2]}}`, `{"basic":{"a":[2,1]}}`, false},
+		{"", `{"basic":{"a":1}}`, false},
+	} {
+		if result := suppressConfigObjectPropertiesDiff("properties", test.old, test.new, nil); result != test.expected {
+			t.Errorf("suppressConfigObjectPropertiesDiff(%q, %q) = %v, expected %v", test.old, test.new, result, test.expected)
+		}
+	}
+}
+
+func getConfigObjectConfig(name, properties, content string) string {
+	return fmt.Sprintf(`
+		resource "vtm_config_object" "pool" {
+			path = "pools/%s"
+			properties = <<EOF
+%s
+EOF
+		}
+
+		resource "vtm_config_object" "file" {
+			path = "extra_files/%s"
+			content = "%s"
+		}
+
+		data "vtm_config_object" "pool" {
+			path = "${vtm_config_object.pool.path}"
+		}
+
+		data "vtm_config_object" "file" {
+			path = "${vtm_config_object.file.path}"
+		}`,
+		name, properties, name, content,
+	)
+}
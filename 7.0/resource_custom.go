@@ -85,6 +85,8 @@ func resourceCustomRead(d *schema.ResourceData, tm interface{}) (readError error
 		return fmt.Errorf("Failed to read vtm_custom '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -132,9 +134,12 @@ func resourceCustomCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewCustom(objectName)
 	resourceCustomObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceCustomSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_custom '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_custom '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -148,9 +153,12 @@ func resourceCustomUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_custom '%v': %v", objectName, err)
 	}
 	resourceCustomObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceCustomSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_custom '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_custom '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
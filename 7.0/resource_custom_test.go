@@ -21,7 +21,7 @@ import (
 func TestResourceCustom(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestCustom")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCustomDestroy,
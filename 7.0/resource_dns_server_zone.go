@@ -66,6 +66,8 @@ func resourceDnsServerZoneRead(d *schema.ResourceData, tm interface{}) (readErro
 		return fmt.Errorf("Failed to read vtm_zone '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -102,9 +104,12 @@ func resourceDnsServerZoneCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewDnsServerZone(objectName, d.Get("origin").(string), d.Get("zonefile").(string))
 	resourceDnsServerZoneObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceDnsServerZoneSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_zone '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_zone '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -118,9 +123,12 @@ func resourceDnsServerZoneUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_zone '%v': %v", objectName, err)
 	}
 	resourceDnsServerZoneObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceDnsServerZoneSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_zone '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_zone '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -280,6 +280,8 @@ func resourceEventTypeRead(d *schema.ResourceData, tm interface{}) (readError er
 		return fmt.Errorf("Failed to read vtm_event_type '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -376,9 +378,12 @@ func resourceEventTypeCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewEventType(objectName)
 	resourceEventTypeObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceEventTypeSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_event_type '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_event_type '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -392,9 +397,12 @@ func resourceEventTypeUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_event_type '%v': %v", objectName, err)
 	}
 	resourceEventTypeObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceEventTypeSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_event_type '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_event_type '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -268,6 +268,8 @@ func resourceGlbServiceRead(d *schema.ResourceData, tm interface{}) (readError e
 		return fmt.Errorf("Failed to read vtm_glb_service '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -370,9 +372,12 @@ func resourceGlbServiceCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewGlbService(objectName)
 	resourceGlbServiceObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceGlbServiceSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_glb_service '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_glb_service '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -386,9 +391,12 @@ func resourceGlbServiceUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_glb_service '%v': %v", objectName, err)
 	}
 	resourceGlbServiceObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceGlbServiceSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_glb_service '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_glb_service '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
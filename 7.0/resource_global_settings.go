@@ -289,8 +289,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 		// The password used to protect the bootloader. An empty string
 		//  means there will be no protection.
 		"appliance_bootloader_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// Whether or not the traffic manager will attempt to route response
@@ -542,8 +544,9 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 
 		// Amazon EC2 Access Key ID.
 		"ec2_access_key_id": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
 		},
 
 		// The maximum amount of time requests to the AWS Query API can
@@ -571,8 +574,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 
 		// Amazon EC2 Secret Access Key.
 		"ec2_secret_access_key": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// Whether to verify Amazon EC2 endpoint's certificate using CA(s)
@@ -903,8 +908,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 		// The HTTP Event Collector token to use for HTTP authentication
 		//  with a Splunk server.
 		"log_export_auth_hec_token": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The HTTP authentication method to use when exporting log entries.
@@ -917,8 +924,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 
 		// The password to use for HTTP basic authentication.
 		"log_export_auth_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The username to use for HTTP basic authentication.
@@ -1001,15 +1010,19 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 		// OSPFv2 authentication shared secret (MD5). If set to blank, which
 		//  is the default value, the key is disabled.
 		"ospfv2_authentication_shared_secret_a": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// OSPFv2 authentication shared secret (MD5). If set to blank, which
 		//  is the default value, the key is disabled.
 		"ospfv2_authentication_shared_secret_b": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The number of seconds before declaring a silent router down.
@@ -1105,8 +1118,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 
 		// The secret associated with the Owner.
 		"remote_licensing_owner_secret": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The auto-accept Policy ID that this instance should attempt to
@@ -1816,8 +1831,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 		// The client secret used when accessing the Microsoft Azure Key
 		//  Vault.
 		"ssl_hardware_azure_client_secret": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The URL for the REST API of the Microsoft Azure Key Vault.
@@ -1867,8 +1884,10 @@ func getResourceGlobalSettingsSchema() map[string]*schema.Schema {
 
 		// The User PIN for the PKCS token (PKCS#11 devices only).
 		"ssl_hardware_driver_pkcs11_user_pin": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The number of consecutive failures from the SSL hardware that
@@ -2146,6 +2165,8 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 		return fmt.Errorf("Failed to read vtm_global_setting: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -2214,7 +2235,7 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "admin_support_tls1_3"
 	d.Set("admin_support_tls1_3", bool(*object.Admin.SupportTls13))
 	lastAssignedField = "appliance_bootloader_password"
-	d.Set("appliance_bootloader_password", string(*object.Appliance.BootloaderPassword))
+	d.Set("appliance_bootloader_password", getSecretFingerprint(d, "appliance_bootloader_password", string(*object.Appliance.BootloaderPassword)))
 	lastAssignedField = "appliance_return_path_routing_enabled"
 	d.Set("appliance_return_path_routing_enabled", bool(*object.Appliance.ReturnPathRoutingEnabled))
 	lastAssignedField = "aptimizer_max_dependent_fetch_size"
@@ -2276,7 +2297,7 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "ec2_query_server"
 	d.Set("ec2_query_server", string(*object.Ec2.QueryServer))
 	lastAssignedField = "ec2_secret_access_key"
-	d.Set("ec2_secret_access_key", string(*object.Ec2.SecretAccessKey))
+	d.Set("ec2_secret_access_key", getSecretFingerprint(d, "ec2_secret_access_key", string(*object.Ec2.SecretAccessKey)))
 	lastAssignedField = "ec2_verify_query_server_cert"
 	d.Set("ec2_verify_query_server_cert", bool(*object.Ec2.VerifyQueryServerCert))
 	lastAssignedField = "eventing_mail_interval"
@@ -2362,11 +2383,11 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "log_time"
 	d.Set("log_time", int(*object.Log.Time))
 	lastAssignedField = "log_export_auth_hec_token"
-	d.Set("log_export_auth_hec_token", string(*object.LogExport.AuthHecToken))
+	d.Set("log_export_auth_hec_token", getSecretFingerprint(d, "log_export_auth_hec_token", string(*object.LogExport.AuthHecToken)))
 	lastAssignedField = "log_export_auth_http"
 	d.Set("log_export_auth_http", string(*object.LogExport.AuthHttp))
 	lastAssignedField = "log_export_auth_password"
-	d.Set("log_export_auth_password", string(*object.LogExport.AuthPassword))
+	d.Set("log_export_auth_password", getSecretFingerprint(d, "log_export_auth_password", string(*object.LogExport.AuthPassword)))
 	lastAssignedField = "log_export_auth_username"
 	d.Set("log_export_auth_username", string(*object.LogExport.AuthUsername))
 	lastAssignedField = "log_export_enabled"
@@ -2386,9 +2407,9 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "ospfv2_authentication_key_id_b"
 	d.Set("ospfv2_authentication_key_id_b", int(*object.Ospfv2.AuthenticationKeyIdB))
 	lastAssignedField = "ospfv2_authentication_shared_secret_a"
-	d.Set("ospfv2_authentication_shared_secret_a", string(*object.Ospfv2.AuthenticationSharedSecretA))
+	d.Set("ospfv2_authentication_shared_secret_a", getSecretFingerprint(d, "ospfv2_authentication_shared_secret_a", string(*object.Ospfv2.AuthenticationSharedSecretA)))
 	lastAssignedField = "ospfv2_authentication_shared_secret_b"
-	d.Set("ospfv2_authentication_shared_secret_b", string(*object.Ospfv2.AuthenticationSharedSecretB))
+	d.Set("ospfv2_authentication_shared_secret_b", getSecretFingerprint(d, "ospfv2_authentication_shared_secret_b", string(*object.Ospfv2.AuthenticationSharedSecretB)))
 	lastAssignedField = "ospfv2_dead_interval"
 	d.Set("ospfv2_dead_interval", int(*object.Ospfv2.DeadInterval))
 	lastAssignedField = "ospfv2_enabled"
@@ -2410,7 +2431,7 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "remote_licensing_owner"
 	d.Set("remote_licensing_owner", string(*object.RemoteLicensing.Owner))
 	lastAssignedField = "remote_licensing_owner_secret"
-	d.Set("remote_licensing_owner_secret", string(*object.RemoteLicensing.OwnerSecret))
+	d.Set("remote_licensing_owner_secret", getSecretFingerprint(d, "remote_licensing_owner_secret", string(*object.RemoteLicensing.OwnerSecret)))
 	lastAssignedField = "remote_licensing_policy_id"
 	d.Set("remote_licensing_policy_id", string(*object.RemoteLicensing.PolicyId))
 	lastAssignedField = "remote_licensing_registration_server"
@@ -2564,7 +2585,7 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "ssl_hardware_azure_client_id"
 	d.Set("ssl_hardware_azure_client_id", string(*object.SslHardware.AzureClientId))
 	lastAssignedField = "ssl_hardware_azure_client_secret"
-	d.Set("ssl_hardware_azure_client_secret", string(*object.SslHardware.AzureClientSecret))
+	d.Set("ssl_hardware_azure_client_secret", getSecretFingerprint(d, "ssl_hardware_azure_client_secret", string(*object.SslHardware.AzureClientSecret)))
 	lastAssignedField = "ssl_hardware_azure_vault_url"
 	d.Set("ssl_hardware_azure_vault_url", string(*object.SslHardware.AzureVaultUrl))
 	lastAssignedField = "ssl_hardware_azure_verify_rest_api_cert"
@@ -2578,7 +2599,7 @@ func resourceGlobalSettingsRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "ssl_hardware_driver_pkcs11_slot_type"
 	d.Set("ssl_hardware_driver_pkcs11_slot_type", string(*object.SslHardware.DriverPkcs11SlotType))
 	lastAssignedField = "ssl_hardware_driver_pkcs11_user_pin"
-	d.Set("ssl_hardware_driver_pkcs11_user_pin", string(*object.SslHardware.DriverPkcs11UserPin))
+	d.Set("ssl_hardware_driver_pkcs11_user_pin", getSecretFingerprint(d, "ssl_hardware_driver_pkcs11_user_pin", string(*object.SslHardware.DriverPkcs11UserPin)))
 	lastAssignedField = "ssl_hardware_failure_count"
 	d.Set("ssl_hardware_failure_count", int(*object.SslHardware.FailureCount))
 	lastAssignedField = "ssl_hardware_library"
@@ -2683,7 +2704,7 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setBool(&object.Admin.SupportTls11, d, "admin_support_tls1_1")
 	setBool(&object.Admin.SupportTls12, d, "admin_support_tls1_2")
 	setBool(&object.Admin.SupportTls13, d, "admin_support_tls1_3")
-	setString(&object.Appliance.BootloaderPassword, d, "appliance_bootloader_password")
+	setSecret(&object.Appliance.BootloaderPassword, d, "appliance_bootloader_password")
 	setBool(&object.Appliance.ReturnPathRoutingEnabled, d, "appliance_return_path_routing_enabled")
 	setString(&object.Aptimizer.MaxDependentFetchSize, d, "aptimizer_max_dependent_fetch_size")
 	setString(&object.Aptimizer.MaxOriginalContentBufferSize, d, "aptimizer_max_original_content_buffer_size")
@@ -2720,7 +2741,7 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setInt(&object.Ec2.AwstoolTimeout, d, "ec2_awstool_timeout")
 	setString(&object.Ec2.MetadataServer, d, "ec2_metadata_server")
 	setString(&object.Ec2.QueryServer, d, "ec2_query_server")
-	setString(&object.Ec2.SecretAccessKey, d, "ec2_secret_access_key")
+	setSecret(&object.Ec2.SecretAccessKey, d, "ec2_secret_access_key")
 	setBool(&object.Ec2.VerifyQueryServerCert, d, "ec2_verify_query_server_cert")
 	setInt(&object.Eventing.MailInterval, d, "eventing_mail_interval")
 	setInt(&object.Eventing.MaxAttempts, d, "eventing_max_attempts")
@@ -2777,9 +2798,9 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setInt(&object.Log.Rate, d, "log_rate")
 	setInt(&object.Log.Reopen, d, "log_reopen")
 	setInt(&object.Log.Time, d, "log_time")
-	setString(&object.LogExport.AuthHecToken, d, "log_export_auth_hec_token")
+	setSecret(&object.LogExport.AuthHecToken, d, "log_export_auth_hec_token")
 	setString(&object.LogExport.AuthHttp, d, "log_export_auth_http")
-	setString(&object.LogExport.AuthPassword, d, "log_export_auth_password")
+	setSecret(&object.LogExport.AuthPassword, d, "log_export_auth_password")
 	setString(&object.LogExport.AuthUsername, d, "log_export_auth_username")
 	setBool(&object.LogExport.Enabled, d, "log_export_enabled")
 	setString(&object.LogExport.Endpoint, d, "log_export_endpoint")
@@ -2789,8 +2810,8 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setString(&object.Ospfv2.AreaType, d, "ospfv2_area_type")
 	setInt(&object.Ospfv2.AuthenticationKeyIdA, d, "ospfv2_authentication_key_id_a")
 	setInt(&object.Ospfv2.AuthenticationKeyIdB, d, "ospfv2_authentication_key_id_b")
-	setString(&object.Ospfv2.AuthenticationSharedSecretA, d, "ospfv2_authentication_shared_secret_a")
-	setString(&object.Ospfv2.AuthenticationSharedSecretB, d, "ospfv2_authentication_shared_secret_b")
+	setSecret(&object.Ospfv2.AuthenticationSharedSecretA, d, "ospfv2_authentication_shared_secret_a")
+	setSecret(&object.Ospfv2.AuthenticationSharedSecretB, d, "ospfv2_authentication_shared_secret_b")
 	setInt(&object.Ospfv2.DeadInterval, d, "ospfv2_dead_interval")
 	setBool(&object.Ospfv2.Enabled, d, "ospfv2_enabled")
 	setInt(&object.Ospfv2.HelloInterval, d, "ospfv2_hello_interval")
@@ -2801,7 +2822,7 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setBool(&object.RemoteLicensing.CommChannelEnabled, d, "remote_licensing_comm_channel_enabled")
 	setInt(&object.RemoteLicensing.CommChannelPort, d, "remote_licensing_comm_channel_port")
 	setString(&object.RemoteLicensing.Owner, d, "remote_licensing_owner")
-	setString(&object.RemoteLicensing.OwnerSecret, d, "remote_licensing_owner_secret")
+	setSecret(&object.RemoteLicensing.OwnerSecret, d, "remote_licensing_owner_secret")
 	setString(&object.RemoteLicensing.PolicyId, d, "remote_licensing_policy_id")
 	setString(&object.RemoteLicensing.RegistrationServer, d, "remote_licensing_registration_server")
 	setString(&object.RemoteLicensing.ServerCertificate, d, "remote_licensing_server_certificate")
@@ -2884,14 +2905,14 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setBool(&object.Ssl.ValidateServerCertificatesCatalog, d, "ssl_validate_server_certificates_catalog")
 	setBool(&object.SslHardware.Accel, d, "ssl_hardware_accel")
 	setString(&object.SslHardware.AzureClientId, d, "ssl_hardware_azure_client_id")
-	setString(&object.SslHardware.AzureClientSecret, d, "ssl_hardware_azure_client_secret")
+	setSecret(&object.SslHardware.AzureClientSecret, d, "ssl_hardware_azure_client_secret")
 	setString(&object.SslHardware.AzureVaultUrl, d, "ssl_hardware_azure_vault_url")
 	setBool(&object.SslHardware.AzureVerifyRestApiCert, d, "ssl_hardware_azure_verify_rest_api_cert")
 	setBool(&object.SslHardware.DriverPkcs11Debug, d, "ssl_hardware_driver_pkcs11_debug")
 	setString(&object.SslHardware.DriverPkcs11Lib, d, "ssl_hardware_driver_pkcs11_lib")
 	setString(&object.SslHardware.DriverPkcs11SlotDesc, d, "ssl_hardware_driver_pkcs11_slot_desc")
 	setString(&object.SslHardware.DriverPkcs11SlotType, d, "ssl_hardware_driver_pkcs11_slot_type")
-	setString(&object.SslHardware.DriverPkcs11UserPin, d, "ssl_hardware_driver_pkcs11_user_pin")
+	setSecret(&object.SslHardware.DriverPkcs11UserPin, d, "ssl_hardware_driver_pkcs11_user_pin")
 	setInt(&object.SslHardware.FailureCount, d, "ssl_hardware_failure_count")
 	setString(&object.SslHardware.Library, d, "ssl_hardware_library")
 	setBool(&object.Telemetry.AutotestSchedule, d, "telemetry_autotest_schedule")
@@ -2920,9 +2941,12 @@ func resourceGlobalSettingsUpdate(d *schema.ResourceData, tm interface{}) error
 	setString(&object.WebCache.Size, d, "web_cache_size")
 	setBool(&object.WebCache.Verbose, d, "web_cache_verbose")
 
+	if err := stripUnsupportedFields(tm, object, getResourceGlobalSettingsSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_global_setting: %v", err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_global_setting: %s %s", applyErr.ErrorText, info)
 	}
 	d.SetId("global_setting")
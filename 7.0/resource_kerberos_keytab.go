@@ -40,8 +40,10 @@ func getResourceKerberosKeytabSchema() map[string]*schema.Schema {
 
 		// Object text
 		"content": &schema.Schema{
-			Type:     schema.TypeString,
-			Required: true,
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 	}
 }
@@ -70,7 +72,7 @@ func resourceKerberosKeytabRead(d *schema.ResourceData, tm interface{}) (readErr
 		}
 	}()
 
-	d.Set("content", object)
+	d.Set("content", hashSecret(object))
 	d.SetId(objectName)
 	return nil
 }
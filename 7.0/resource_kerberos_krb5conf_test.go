@@ -21,7 +21,7 @@ import (
 func TestResourceKerberosKrb5Conf(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestKerberosKrb5Conf")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckKerberosKrb5ConfDestroy,
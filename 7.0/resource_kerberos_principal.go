@@ -90,6 +90,8 @@ func resourceKerberosPrincipalRead(d *schema.ResourceData, tm interface{}) (read
 		return fmt.Errorf("Failed to read vtm_principal '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -132,9 +134,12 @@ func resourceKerberosPrincipalCreate(d *schema.ResourceData, tm interface{}) err
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewKerberosPrincipal(objectName, d.Get("keytab").(string), d.Get("service").(string))
 	resourceKerberosPrincipalObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceKerberosPrincipalSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_principal '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_principal '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -148,9 +153,12 @@ func resourceKerberosPrincipalUpdate(d *schema.ResourceData, tm interface{}) err
 		return fmt.Errorf("Failed to update vtm_principal '%v': %v", objectName, err)
 	}
 	resourceKerberosPrincipalObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceKerberosPrincipalSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_principal '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_principal '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
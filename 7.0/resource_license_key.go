@@ -40,8 +40,9 @@ func getResourceLicenseKeySchema() map[string]*schema.Schema {
 
 		// Object text
 		"content": &schema.Schema{
-			Type:     schema.TypeString,
-			Required: true,
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
 		},
 	}
 }
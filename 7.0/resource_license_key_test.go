@@ -21,7 +21,7 @@ import (
 func TestResourceLicenseKey(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestLicenseKey")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckLicenseKeyDestroy,
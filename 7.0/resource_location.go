@@ -90,6 +90,8 @@ func resourceLocationRead(d *schema.ResourceData, tm interface{}) (readError err
 		return fmt.Errorf("Failed to read vtm_location '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -132,9 +134,12 @@ func resourceLocationCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewLocation(objectName, d.Get("identifier").(int))
 	resourceLocationObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceLocationSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_location '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_location '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -148,9 +153,12 @@ func resourceLocationUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_location '%v': %v", objectName, err)
 	}
 	resourceLocationObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceLocationSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_location '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_location '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -129,6 +129,8 @@ func resourceLogExportRead(d *schema.ResourceData, tm interface{}) (readError er
 		return fmt.Errorf("Failed to read vtm_log_export '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -188,9 +190,12 @@ func resourceLogExportCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewLogExport(objectName)
 	resourceLogExportObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceLogExportSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_log_export '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_log_export '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -204,9 +209,12 @@ func resourceLogExportUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_log_export '%v': %v", objectName, err)
 	}
 	resourceLogExportObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceLogExportSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_log_export '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_log_export '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
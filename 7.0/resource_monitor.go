@@ -128,8 +128,10 @@ func getResourceMonitorSchema() map[string]*schema.Schema {
 		// The HTTP basic-auth "<user>:<password>" to use for the test HTTP
 		//  request.
 		"http_authentication": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// A regular expression that the HTTP response body must match.
@@ -299,6 +301,8 @@ func resourceMonitorRead(d *schema.ResourceData, tm interface{}) (readError erro
 		return fmt.Errorf("Failed to read vtm_monitor '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -331,7 +335,7 @@ func resourceMonitorRead(d *schema.ResourceData, tm interface{}) (readError erro
 	lastAssignedField = "verbose"
 	d.Set("verbose", bool(*object.Basic.Verbose))
 	lastAssignedField = "http_authentication"
-	d.Set("http_authentication", string(*object.Http.Authentication))
+	d.Set("http_authentication", getSecretFingerprint(d, "http_authentication", string(*object.Http.Authentication)))
 	lastAssignedField = "http_body_regex"
 	d.Set("http_body_regex", string(*object.Http.BodyRegex))
 	lastAssignedField = "http_host_header"
@@ -405,9 +409,12 @@ func resourceMonitorCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewMonitor(objectName)
 	resourceMonitorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceMonitorSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_monitor '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -421,9 +428,12 @@ func resourceMonitorUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_monitor '%v': %v", objectName, err)
 	}
 	resourceMonitorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceMonitorSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_monitor '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -442,7 +452,7 @@ func resourceMonitorObjectFieldAssignments(d *schema.ResourceData, object *vtm.M
 	setString(&object.Basic.Type, d, "type")
 	setBool(&object.Basic.UseSsl, d, "use_ssl")
 	setBool(&object.Basic.Verbose, d, "verbose")
-	setString(&object.Http.Authentication, d, "http_authentication")
+	setSecret(&object.Http.Authentication, d, "http_authentication")
 	setString(&object.Http.BodyRegex, d, "http_body_regex")
 	setString(&object.Http.HostHeader, d, "http_host_header")
 	setString(&object.Http.Path, d, "http_path")
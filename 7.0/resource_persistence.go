@@ -141,6 +141,8 @@ func resourcePersistenceRead(d *schema.ResourceData, tm interface{}) (readError
 		return fmt.Errorf("Failed to read vtm_persistence '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -193,9 +195,12 @@ func resourcePersistenceCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewPersistence(objectName)
 	resourcePersistenceObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourcePersistenceSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_persistence '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_persistence '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -209,9 +214,12 @@ func resourcePersistenceUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_persistence '%v': %v", objectName, err)
 	}
 	resourcePersistenceObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourcePersistenceSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_persistence '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_persistence '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -892,6 +892,8 @@ func resourcePoolRead(d *schema.ResourceData, tm interface{}) (readError error)
 		return fmt.Errorf("Failed to read vtm_pool '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -1122,9 +1124,12 @@ func resourcePoolCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewPool(objectName)
 	resourcePoolObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourcePoolSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_pool '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_pool '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -1133,14 +1138,24 @@ func resourcePoolCreate(d *schema.ResourceData, tm interface{}) error {
 
 func resourcePoolUpdate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
+	defer lockPool(objectName).Unlock()
 	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(objectName)
 	if err != nil {
 		return fmt.Errorf("Failed to update vtm_pool '%v': %v", objectName, err)
 	}
+	// Keep the nodes on the vTM unless the nodes table itself has changed, so that nodes added
+	// by vtm_pool_node are not removed by changes to the pool's other settings.
+	nodesTable := object.Basic.NodesTable
 	resourcePoolObjectFieldAssignments(d, object)
+	if d.HasChange("nodes_table") != true && d.HasChange("nodes_table_json") != true {
+		object.Basic.NodesTable = nodesTable
+	}
+	if err := stripUnsupportedFields(tm, object, getResourcePoolSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_pool '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_pool '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
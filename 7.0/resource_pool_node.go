@@ -0,0 +1,288 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_pool_node manages a single entry in the nodes table of a pool, leaving
+ * the rest of the table alone. This allows nodes to be added to a shared pool
+ * from several places; the vtm_pool resource for the pool should then ignore
+ * changes to its nodes_table and nodes_table_json attributes.
+ */
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// Each change to a node rewrites the whole nodes table of its pool, so changes
+// to the nodes of one pool, and updates by vtm_pool itself, are serialised.
+var poolLocks = struct {
+	sync.Mutex
+	pools map[string]*sync.Mutex
+}{pools: make(map[string]*sync.Mutex)}
+
+func lockPool(poolName string) *sync.Mutex {
+	poolLocks.Lock()
+	poolLock, ok := poolLocks.pools[poolName]
+	if !ok {
+		poolLock = new(sync.Mutex)
+		poolLocks.pools[poolName] = poolLock
+	}
+	poolLocks.Unlock()
+	poolLock.Lock()
+	return poolLock
+}
+
+func validatePoolNode(v interface{}, k string) (ws []string, es []error) {
+	if _, _, err := net.SplitHostPort(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be of the form host:port, got %q", k, v))
+	}
+	return
+}
+
+func resourcePoolNode() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourcePoolNodeRead,
+		Exists: resourcePoolNodeExists,
+		Create: resourcePoolNodeCreate,
+		Update: resourcePoolNodeUpdate,
+		Delete: resourcePoolNodeDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourcePoolNodeImport,
+		},
+
+		Schema: getResourcePoolNodeSchema(),
+	}
+}
+
+func getResourcePoolNodeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		// The pool the node belongs to.
+		"pool": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// A node is a combination of an ip address and port
+		"node": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validatePoolNode,
+		},
+
+		// The priority of the node, higher values signify higher priority.
+		"priority": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Default:      1,
+		},
+
+		// The source address the Traffic Manager uses to connect to this
+		//  node.
+		"source_ip": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// The state of the node, which can either be Active, Draining or
+		//  Disabled
+		"state": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"active", "disabled", "draining"}, false),
+			Default:      "active",
+		},
+
+		// Weight for the node.
+		"weight": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(1, 100),
+			Default:      1,
+		},
+	}
+}
+
+func getPoolNodeId(poolName, node string) string {
+	return poolName + "/" + node
+}
+
+// Nodes never contain a slash, so the ID is split at the last one.
+func parsePoolNodeId(id string) (poolName, node string, err error) {
+	separator := strings.LastIndex(id, "/")
+	if separator <= 0 || separator == len(id)-1 {
+		return "", "", fmt.Errorf("Invalid vtm_pool_node ID '%s': expected <pool>/<node>", id)
+	}
+	return id[:separator], id[separator+1:], nil
+}
+
+func findPoolNode(object *vtm.Pool, node string) int {
+	if object.Basic.NodesTable == nil {
+		return -1
+	}
+	for i, item := range *object.Basic.NodesTable {
+		if item.Node != nil && *item.Node == node {
+			return i
+		}
+	}
+	return -1
+}
+
+func resourcePoolNodeImport(d *schema.ResourceData, tm interface{}) ([]*schema.ResourceData, error) {
+	poolName, node, err := parsePoolNodeId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("pool", poolName)
+	d.Set("node", node)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePoolNodeRead(d *schema.ResourceData, tm interface{}) error {
+	poolName := d.Get("pool").(string)
+	node := d.Get("node").(string)
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_pool_node '%v': %v", d.Id(), err.ErrorText)
+	}
+	index := findPoolNode(object, node)
+	if index < 0 {
+		d.SetId("")
+		return nil
+	}
+	item := (*object.Basic.NodesTable)[index]
+	priority, weight := 1, 1
+	if item.Priority != nil {
+		priority = *item.Priority
+	}
+	if item.Weight != nil {
+		weight = *item.Weight
+	}
+	d.Set("priority", priority)
+	d.Set("weight", weight)
+	if item.SourceIp != nil {
+		d.Set("source_ip", *item.SourceIp)
+	}
+	if item.State != nil {
+		d.Set("state", *item.State)
+	}
+	return nil
+}
+
+func resourcePoolNodeExists(d *schema.ResourceData, tm interface{}) (bool, error) {
+	poolName, node, idErr := parsePoolNodeId(d.Id())
+	if idErr != nil {
+		return false, idErr
+	}
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			return false, nil
+		}
+		return false, fmt.Errorf("%v", err.ErrorText)
+	}
+	return findPoolNode(object, node) >= 0, nil
+}
+
+func resourcePoolNodeCreate(d *schema.ResourceData, tm interface{}) error {
+	poolName := d.Get("pool").(string)
+	node := d.Get("node").(string)
+	defer lockPool(poolName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		return fmt.Errorf("Error creating vtm_pool_node '%s': %v", getPoolNodeId(poolName, node), err)
+	}
+	if findPoolNode(object, node) >= 0 {
+		return fmt.Errorf("Error creating vtm_pool_node '%s': node already exists in pool '%s', import it to manage it", getPoolNodeId(poolName, node), poolName)
+	}
+	if object.Basic.NodesTable == nil {
+		object.Basic.NodesTable = &vtm.PoolNodesTableTable{}
+	}
+	item := vtm.PoolNodesTable{Node: getStringAddr(node)}
+	resourcePoolNodeObjectFieldAssignments(d, &item)
+	*object.Basic.NodesTable = append(*object.Basic.NodesTable, item)
+	if err := applyPoolNodes(tm, object); err != nil {
+		return fmt.Errorf("Error creating vtm_pool_node '%s': %v", getPoolNodeId(poolName, node), err)
+	}
+	d.SetId(getPoolNodeId(poolName, node))
+	return nil
+}
+
+func resourcePoolNodeUpdate(d *schema.ResourceData, tm interface{}) error {
+	poolName := d.Get("pool").(string)
+	node := d.Get("node").(string)
+	defer lockPool(poolName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		return fmt.Errorf("Failed to update vtm_pool_node '%v': %v", d.Id(), err)
+	}
+	index := findPoolNode(object, node)
+	if index < 0 {
+		return fmt.Errorf("Failed to update vtm_pool_node '%v': node is no longer in pool '%s'", d.Id(), poolName)
+	}
+	resourcePoolNodeObjectFieldAssignments(d, &(*object.Basic.NodesTable)[index])
+	if err := applyPoolNodes(tm, object); err != nil {
+		return fmt.Errorf("Error updating vtm_pool_node '%s': %v", d.Id(), err)
+	}
+	return nil
+}
+
+func resourcePoolNodeDelete(d *schema.ResourceData, tm interface{}) error {
+	poolName := d.Get("pool").(string)
+	node := d.Get("node").(string)
+	defer lockPool(poolName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to delete vtm_pool_node '%v': %v", d.Id(), err.ErrorText)
+	}
+	if index := findPoolNode(object, node); index >= 0 {
+		nodesTable := *object.Basic.NodesTable
+		*object.Basic.NodesTable = append(nodesTable[:index], nodesTable[index+1:]...)
+		if err := applyPoolNodes(tm, object); err != nil {
+			return fmt.Errorf("Failed to delete vtm_pool_node '%v': %v", d.Id(), err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourcePoolNodeObjectFieldAssignments(d *schema.ResourceData, item *vtm.PoolNodesTable) {
+	setInt(&item.Priority, d, "priority")
+	setString(&item.SourceIp, d, "source_ip")
+	setString(&item.State, d, "state")
+	setInt(&item.Weight, d, "weight")
+}
+
+// applyPoolNodes writes a pool back to the vTM after its nodes table has been modified.
+func applyPoolNodes(tm interface{}, object *vtm.Pool) error {
+	if err := stripUnsupportedFields(tm, object, getResourcePoolSchema()); err != nil {
+		return err
+	}
+	_, applyErr := object.Apply()
+	if applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("%s %s", applyErr.ErrorText, info)
+	}
+	return nil
+}
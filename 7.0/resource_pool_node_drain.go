@@ -0,0 +1,250 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_pool_node_drain drains a node in a pool: it sets the node to draining,
+ * waits for the node's current connections and requests to reach zero, and then
+ * optionally disables the node or removes it from the pool. The wait is bounded
+ * by the resource's create timeout.
+ *
+ * Changing after_drain or force_after_timeout drains the node again, bounded by
+ * the update timeout, and then carries out the new after_drain action. A node
+ * which has been removed is not drained again: it is left removed if after_drain
+ * is still "remove", and otherwise must be added back to the pool first.
+ * Destroying a vtm_pool_node_drain leaves the node as it is.
+ */
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func resourcePoolNodeDrain() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourcePoolNodeDrainRead,
+		Create: resourcePoolNodeDrainCreate,
+		Update: resourcePoolNodeDrainUpdate,
+		Delete: resourcePoolNodeDrainDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: getResourcePoolNodeDrainSchema(),
+	}
+}
+
+func getResourcePoolNodeDrainSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		// The pool containing the node.
+		"pool": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// The node to drain, as host:port.
+		"node": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validatePoolNode,
+		},
+
+		// What to do with the node once it has drained: leave it draining,
+		//  disable it or remove it from the pool.
+		"after_drain": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"none", "disable", "remove"}, false),
+			Default:      "none",
+		},
+
+		// Carry out the after_drain action even if the node still has
+		//  connections when the timeout expires, rather than failing.
+		"force_after_timeout": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		// How often to check the node's connections, in seconds.
+		"poll_interval": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Default:      5,
+		},
+
+		// Whether the node had no connections or requests left when the
+		//  drain completed.
+		"drained": &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+
+		// The state of the node in the pool, or "removed" once it has been
+		//  removed.
+		"node_state": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+// Statistics for a node in a specific pool are named <pool>-<node>.
+func getPerPoolNodeStatisticsName(poolName, node string) string {
+	return poolName + "-" + node
+}
+
+/*
+getPoolNodeActivity returns the number of current connections and requests to a node in a pool.
+Only the node's statistics for that pool are used, as its statistics across all pools include
+traffic from other pools which draining it in this one does not stop. A node with no per-pool
+statistics has never been sent any traffic by the pool.
+*/
+func getPoolNodeActivity(tm *vtm.VirtualTrafficManager, poolName, node string) (int, error) {
+	perPoolStats, err := tm.GetNodesPerPoolNodeStatistics(getPerPoolNodeStatisticsName(poolName, node))
+	if err == nil {
+		return getIntValue(perPoolStats.Statistics.CurrentConn) + getIntValue(perPoolStats.Statistics.CurrentRequests), nil
+	}
+	if err.ErrorId != "resource.not_found" {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func getIntValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func setPoolNodeState(tm interface{}, poolName, node, state string) error {
+	defer lockPool(poolName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		return err
+	}
+	index := findPoolNode(object, node)
+	if index < 0 {
+		return fmt.Errorf("node '%s' is not in pool '%s'", node, poolName)
+	}
+	if state == "removed" {
+		nodesTable := *object.Basic.NodesTable
+		*object.Basic.NodesTable = append(nodesTable[:index], nodesTable[index+1:]...)
+	} else {
+		(*object.Basic.NodesTable)[index].State = getStringAddr(state)
+	}
+	return applyPoolNodes(tm, object)
+}
+
+/*
+drainPoolNode sets the node to draining, waits up to timeout for it to drain and then carries out
+the after_drain action.
+*/
+func drainPoolNode(d *schema.ResourceData, tm interface{}, timeout time.Duration) error {
+	poolName := d.Get("pool").(string)
+	node := d.Get("node").(string)
+	id := getPoolNodeId(poolName, node)
+	if err := setPoolNodeState(tm, poolName, node, "draining"); err != nil {
+		return err
+	}
+	d.SetId(id)
+
+	waiter := &resource.StateChangeConf{
+		Pending:      []string{"draining"},
+		Target:       []string{"drained"},
+		Timeout:      timeout,
+		PollInterval: time.Duration(d.Get("poll_interval").(int)) * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			active, err := getPoolNodeActivity(tm.(*vtm.VirtualTrafficManager), poolName, node)
+			if err != nil {
+				return nil, "", err
+			}
+			log.Printf("[DEBUG] vtm_pool_node_drain '%s': %d connections and requests remaining", id, active)
+			if active > 0 {
+				return active, "draining", nil
+			}
+			return active, "drained", nil
+		},
+	}
+	_, waitErr := waiter.WaitForState()
+	d.Set("drained", waitErr == nil)
+	if waitErr != nil {
+		if _, isTimeout := waitErr.(*resource.TimeoutError); !isTimeout || d.Get("force_after_timeout") != true {
+			return waitErr
+		}
+		log.Printf("[WARN] vtm_pool_node_drain '%s' still active after timeout: %v", id, waitErr)
+	}
+
+	afterState := map[string]string{"disable": "disabled", "remove": "removed"}[d.Get("after_drain").(string)]
+	if afterState != "" {
+		return setPoolNodeState(tm, poolName, node, afterState)
+	}
+	return nil
+}
+
+func resourcePoolNodeDrainCreate(d *schema.ResourceData, tm interface{}) error {
+	id := getPoolNodeId(d.Get("pool").(string), d.Get("node").(string))
+	if err := drainPoolNode(d, tm, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error draining vtm_pool_node_drain '%s': %v", id, err)
+	}
+	return resourcePoolNodeDrainRead(d, tm)
+}
+
+func resourcePoolNodeDrainUpdate(d *schema.ResourceData, tm interface{}) error {
+	if d.Get("node_state") == "removed" {
+		if d.Get("after_drain") != "remove" {
+			return fmt.Errorf("Error draining vtm_pool_node_drain '%s': node '%s' has been removed from pool '%s'; add it back to the pool to drain it again",
+				d.Id(), d.Get("node"), d.Get("pool"))
+		}
+		return resourcePoolNodeDrainRead(d, tm)
+	}
+	if d.HasChange("after_drain") || d.HasChange("force_after_timeout") {
+		if err := drainPoolNode(d, tm, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("Error draining vtm_pool_node_drain '%s': %v", d.Id(), err)
+		}
+	}
+	return resourcePoolNodeDrainRead(d, tm)
+}
+
+func resourcePoolNodeDrainRead(d *schema.ResourceData, tm interface{}) error {
+	poolName := d.Get("pool").(string)
+	node := d.Get("node").(string)
+	object, err := tm.(*vtm.VirtualTrafficManager).GetPool(poolName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_pool_node_drain '%v': %v", d.Id(), err.ErrorText)
+	}
+	index := findPoolNode(object, node)
+	switch {
+	case index < 0:
+		d.Set("node_state", "removed")
+	case (*object.Basic.NodesTable)[index].State != nil:
+		d.Set("node_state", *(*object.Basic.NodesTable)[index].State)
+	default:
+		d.Set("node_state", "active")
+	}
+	return nil
+}
+
+func resourcePoolNodeDrainDelete(d *schema.ResourceData, tm interface{}) error {
+	d.SetId("")
+	return nil
+}
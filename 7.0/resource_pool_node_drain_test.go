@@ -0,0 +1,174 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Draining an idle node and then disabling it
+ *   - Draining it again to remove it when after_drain changes
+ *   - Leaving a removed node removed when force_after_timeout changes, and failing to disable it
+ *   - Ignoring connections to the node from other pools (fake vTM only)
+ *   - Timing out while a node still has connections (fake vTM only)
+ *   - Removing a node after the timeout when force_after_timeout is set (fake vTM only)
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func TestResourcePoolNodeDrain(t *testing.T) {
+	poolName := acctest.RandomWithPrefix("TestPoolNodeDrain")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPoolNodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getBasicPoolNodeDrainConfig(poolName, "disable", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "drained", "true"),
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "node_state", "disabled"),
+					testAccCheckPoolNodeState(poolName, "192.168.0.1:80", "disabled"),
+					testAccCheckPoolNodeState(poolName, "192.168.0.2:80", "active"),
+				),
+			},
+			{
+				Config: getBasicPoolNodeDrainConfig(poolName, "remove", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "drained", "true"),
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "node_state", "removed"),
+					testAccCheckPoolNodeCount(poolName, 1),
+				),
+			},
+			{
+				Config: getBasicPoolNodeDrainConfig(poolName, "remove", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "force_after_timeout", "true"),
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "node_state", "removed"),
+					testAccCheckPoolNodeCount(poolName, 1),
+				),
+			},
+			{
+				Config:      getBasicPoolNodeDrainConfig(poolName, "disable", true),
+				ExpectError: regexp.MustCompile("node '192.168.0.1:80' has been removed from pool '.*'; add it back to the pool"),
+			},
+		},
+	})
+}
+
+func TestResourcePoolNodeDrainSharedNode(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test requires the fake vTM, to simulate connections to a node")
+	}
+	poolName := acctest.RandomWithPrefix("TestPoolNodeDrain")
+	// The node is busy in another pool, which draining it in this pool does not affect
+	statistics := "nodes/node/192.168.0.1:80"
+	nodeStatistics := new(vtm.NodesNodeStatistics)
+	nodeStatistics.Statistics.CurrentConn = getIntAddr(3)
+	testFakeVtm.SetStatistics(statistics, nodeStatistics)
+	defer testFakeVtm.SetStatistics(statistics, nil)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPoolNodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getBasicPoolNodeDrainConfig(poolName, "disable", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "drained", "true"),
+					testAccCheckPoolNodeState(poolName, "192.168.0.1:80", "disabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourcePoolNodeDrainTimeout(t *testing.T) {
+	if testFakeVtm == nil {
+		t.Skip("This test requires the fake vTM, to simulate connections to a node")
+	}
+	poolName := acctest.RandomWithPrefix("TestPoolNodeDrain")
+	statistics := fmt.Sprintf("nodes/per_pool_node/%s", getPerPoolNodeStatisticsName(poolName, "192.168.0.1:80"))
+	nodeStatistics := new(vtm.NodesPerPoolNodeStatistics)
+	nodeStatistics.Statistics.CurrentConn = getIntAddr(3)
+	testFakeVtm.SetStatistics(statistics, nodeStatistics)
+	defer testFakeVtm.SetStatistics(statistics, nil)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPoolNodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      getBasicPoolNodeDrainConfig(poolName, "remove", false),
+				ExpectError: regexp.MustCompile("timeout while waiting"),
+			},
+			{
+				Config: getBasicPoolNodeDrainConfig(poolName, "remove", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "drained", "false"),
+					resource.TestCheckResourceAttr("vtm_pool_node_drain.drain", "node_state", "removed"),
+					testAccCheckPoolNodeCount(poolName, 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPoolNodeState(poolName, node, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		pool, err := tm.GetPool(poolName)
+		if err != nil {
+			return fmt.Errorf("Pool %s does not exist: %#v", poolName, err)
+		}
+		index := findPoolNode(pool, node)
+		if index < 0 {
+			return fmt.Errorf("Node %s does not exist in pool %s", node, poolName)
+		}
+		if nodeState := *(*pool.Basic.NodesTable)[index].State; nodeState != state {
+			return fmt.Errorf("Node %s in pool %s is %s, expected %s", node, poolName, nodeState, state)
+		}
+		return nil
+	}
+}
+
+func getBasicPoolNodeDrainConfig(name, afterDrain string, force bool) string {
+	return fmt.Sprintf(`
+		resource "vtm_pool" "test_vtm_pool" {
+			name = "%s"
+			nodes_table {
+				node = "192.168.0.1:80"
+			}
+			nodes_table {
+				node = "192.168.0.2:80"
+			}
+			lifecycle {
+				ignore_changes = ["nodes_table", "nodes_table_json"]
+			}
+		}
+
+		resource "vtm_pool_node_drain" "drain" {
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			node = "192.168.0.1:80"
+			after_drain = "%s"
+			force_after_timeout = %t
+			poll_interval = 1
+			timeouts {
+				create = "2s"
+				update = "2s"
+			}
+		}`,
+		name, afterDrain, force,
+	)
+}
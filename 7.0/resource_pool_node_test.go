@@ -0,0 +1,181 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Adding nodes to a pool with vtm_pool_node, alongside nodes added to the pool elsewhere
+ *   - Updating a node in place
+ *   - Updating the pool's other settings leaves the nodes added by vtm_pool_node in place
+ *   - Importing a node by pool/node
+ *   - Removing a node leaves the rest of the nodes table untouched
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func TestResourcePoolNode(t *testing.T) {
+	poolName := acctest.RandomWithPrefix("TestPoolNode")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPoolNodeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getBasicPoolNodeConfig(poolName, 2, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPoolNodeExists,
+					testAccCheckPoolNodeCount(poolName, 3),
+					resource.TestCheckResourceAttr("vtm_pool_node.node_a", "id", poolName+"/192.168.0.1:80"),
+					resource.TestCheckResourceAttr("vtm_pool_node.node_a", "weight", "2"),
+					resource.TestCheckResourceAttr("vtm_pool_node.node_b", "state", "active"),
+				),
+			},
+			{
+				Config: getBasicPoolNodeConfig(poolName, 5, "disabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPoolNodeCount(poolName, 3),
+					resource.TestCheckResourceAttr("vtm_pool_node.node_a", "weight", "5"),
+					resource.TestCheckResourceAttr("vtm_pool_node.node_b", "state", "disabled"),
+				),
+			},
+			{
+				Config: getPoolNodeNoteConfig(poolName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPoolNodeCount(poolName, 3),
+					resource.TestCheckResourceAttr("vtm_pool.test_vtm_pool", "note", "Shared pool"),
+				),
+			},
+			{
+				ResourceName:      "vtm_pool_node.node_b",
+				ImportState:       true,
+				ImportStateId:     poolName + "/192.168.0.2:80",
+				ImportStateVerify: true,
+			},
+			{
+				Config: getSinglePoolNodeConfig(poolName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPoolNodeCount(poolName, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPoolNodeExists(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_pool_node" {
+			continue
+		}
+		poolName := tfResource.Primary.Attributes["pool"]
+		node := tfResource.Primary.Attributes["node"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		pool, err := tm.GetPool(poolName)
+		if err != nil {
+			return fmt.Errorf("Pool %s does not exist: %#v", poolName, err)
+		}
+		if findPoolNode(pool, node) < 0 {
+			return fmt.Errorf("Node %s does not exist in pool %s", node, poolName)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPoolNodeDestroy(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_pool" {
+			continue
+		}
+		objectName := tfResource.Primary.Attributes["name"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.GetPool(objectName); err == nil {
+			return fmt.Errorf("Pool %s still exists", objectName)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPoolNodeCount(poolName string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		pool, err := tm.GetPool(poolName)
+		if err != nil {
+			return fmt.Errorf("Pool %s does not exist: %#v", poolName, err)
+		}
+		if len(*pool.Basic.NodesTable) != count {
+			return fmt.Errorf("Pool %s has %d nodes, expected %d", poolName, len(*pool.Basic.NodesTable), count)
+		}
+		return nil
+	}
+}
+
+func getPoolNodePoolConfig(name string) string {
+	return getPoolNodePoolNoteConfig(name, "")
+}
+
+func getPoolNodePoolNoteConfig(name, note string) string {
+	return fmt.Sprintf(`
+		resource "vtm_pool" "test_vtm_pool" {
+			name = "%s"
+			note = "%s"
+			nodes_table {
+				node = "192.168.0.100:80"
+			}
+			lifecycle {
+				ignore_changes = ["nodes_table", "nodes_table_json"]
+			}
+		}`,
+		name, note,
+	)
+}
+
+func getBasicPoolNodeConfig(name string, weight int, state string) string {
+	return getPoolNodePoolConfig(name) + fmt.Sprintf(`
+		resource "vtm_pool_node" "node_a" {
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			node = "192.168.0.1:80"
+			weight = %d
+		}
+
+		resource "vtm_pool_node" "node_b" {
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			node = "192.168.0.2:80"
+			state = "%s"
+		}`,
+		weight, state,
+	)
+}
+
+func getPoolNodeNoteConfig(name string) string {
+	return getPoolNodePoolNoteConfig(name, "Shared pool") + `
+		resource "vtm_pool_node" "node_a" {
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			node = "192.168.0.1:80"
+			weight = 5
+		}
+
+		resource "vtm_pool_node" "node_b" {
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			node = "192.168.0.2:80"
+			state = "disabled"
+		}`
+}
+
+func getSinglePoolNodeConfig(name string) string {
+	return getPoolNodePoolConfig(name) + `
+		resource "vtm_pool_node" "node_a" {
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			node = "192.168.0.1:80"
+		}`
+}
@@ -253,6 +253,8 @@ func resourceProtectionRead(d *schema.ResourceData, tm interface{}) (readError e
 		return fmt.Errorf("Failed to read vtm_protection '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -327,9 +329,12 @@ func resourceProtectionCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewProtection(objectName)
 	resourceProtectionObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceProtectionSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_protection '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_protection '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -343,9 +348,12 @@ func resourceProtectionUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_protection '%v': %v", objectName, err)
 	}
 	resourceProtectionObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceProtectionSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_protection '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_protection '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -21,7 +21,7 @@ import (
 func TestResourceProtection(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestProtection")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckProtectionDestroy,
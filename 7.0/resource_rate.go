@@ -81,6 +81,8 @@ func resourceRateRead(d *schema.ResourceData, tm interface{}) (readError error)
 		return fmt.Errorf("Failed to read vtm_rate '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -119,9 +121,12 @@ func resourceRateCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewRate(objectName)
 	resourceRateObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceRateSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_rate '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_rate '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -135,9 +140,12 @@ func resourceRateUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_rate '%v': %v", objectName, err)
 	}
 	resourceRateObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceRateSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_rate '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_rate '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -5,6 +5,8 @@ package main
 
 import (
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -24,8 +26,32 @@ func resourceRule() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		Schema: getResourceRuleSchema(),
+		CustomizeDiff: resourceRuleCustomizeDiff,
+
+		Schema: getResourceRuleResourceSchema(),
+	}
+}
+
+func getResourceRuleResourceSchema() map[string]*schema.Schema {
+	ruleSchema := getResourceRuleSchema()
+
+	// Check the TrafficScript in content for syntax errors when planning.
+	ruleSchema["check_syntax"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+
+	// Accept calls to functions which are not in the provider's
+	//  TrafficScript function catalogue, logging them as warnings. The
+	//  catalogue may be missing functions, so only set this to false to
+	//  fail the plan on such calls.
+	ruleSchema["allow_unknown_functions"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
 	}
+	return ruleSchema
 }
 
 func getResourceRuleSchema() map[string]*schema.Schema {
@@ -46,6 +72,30 @@ func getResourceRuleSchema() map[string]*schema.Schema {
 	}
 }
 
+func resourceRuleCustomizeDiff(d *schema.ResourceDiff, tm interface{}) error {
+	if d.Get("check_syntax") != true || d.HasChange("content") == false || d.NewValueKnown("content") == false {
+		return nil
+	}
+	errors, warnings := splitTrafficScriptProblems(lintTrafficScript(d.Get("content").(string)))
+	if len(warnings) != 0 && d.Get("allow_unknown_functions") != true {
+		for _, warning := range warnings {
+			warning.Warning = false
+			errors = append(errors, warning)
+		}
+		sort.SliceStable(errors, func(i, j int) bool {
+			return errors[i].Line < errors[j].Line || (errors[i].Line == errors[j].Line && errors[i].Column < errors[j].Column)
+		})
+		return fmt.Errorf("TrafficScript errors in vtm_rule '%v':\n  %s\nRemove allow_unknown_functions = false if these functions exist on the vTM.", d.Get("name"), formatTrafficScriptProblems(errors))
+	}
+	if len(warnings) != 0 {
+		log.Printf("[WARN] TrafficScript warnings in vtm_rule '%v':\n  %s", d.Get("name"), formatTrafficScriptProblems(warnings))
+	}
+	if len(errors) != 0 {
+		return fmt.Errorf("TrafficScript errors in vtm_rule '%v':\n  %s", d.Get("name"), formatTrafficScriptProblems(errors))
+	}
+	return nil
+}
+
 func resourceRuleRead(d *schema.ResourceData, tm interface{}) (readError error) {
 	objectName := d.Get("name").(string)
 	if objectName == "" {
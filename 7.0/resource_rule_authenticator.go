@@ -76,8 +76,10 @@ func getResourceRuleAuthenticatorSchema() map[string]*schema.Schema {
 
 		// The password for the bind user.
 		"ldap_bind_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The filter used to locate the LDAP record for the user being
@@ -135,6 +137,8 @@ func resourceRuleAuthenticatorRead(d *schema.ResourceData, tm interface{}) (read
 		return fmt.Errorf("Failed to read vtm_rule_authenticator '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -155,7 +159,7 @@ func resourceRuleAuthenticatorRead(d *schema.ResourceData, tm interface{}) (read
 	lastAssignedField = "ldap_bind_dn"
 	d.Set("ldap_bind_dn", string(*object.Ldap.BindDn))
 	lastAssignedField = "ldap_bind_password"
-	d.Set("ldap_bind_password", string(*object.Ldap.BindPassword))
+	d.Set("ldap_bind_password", getSecretFingerprint(d, "ldap_bind_password", string(*object.Ldap.BindPassword)))
 	lastAssignedField = "ldap_filter"
 	d.Set("ldap_filter", string(*object.Ldap.Filter))
 	lastAssignedField = "ldap_filter_base_dn"
@@ -189,9 +193,12 @@ func resourceRuleAuthenticatorCreate(d *schema.ResourceData, tm interface{}) err
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewRuleAuthenticator(objectName)
 	resourceRuleAuthenticatorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceRuleAuthenticatorSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_rule_authenticator '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_rule_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -205,9 +212,12 @@ func resourceRuleAuthenticatorUpdate(d *schema.ResourceData, tm interface{}) err
 		return fmt.Errorf("Failed to update vtm_rule_authenticator '%v': %v", objectName, err)
 	}
 	resourceRuleAuthenticatorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceRuleAuthenticatorSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_rule_authenticator '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_rule_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -226,7 +236,7 @@ func resourceRuleAuthenticatorObjectFieldAssignments(d *schema.ResourceData, obj
 		d.Set("ldap_attributes", []string(*object.Ldap.Attributes))
 	}
 	setString(&object.Ldap.BindDn, d, "ldap_bind_dn")
-	setString(&object.Ldap.BindPassword, d, "ldap_bind_password")
+	setSecret(&object.Ldap.BindPassword, d, "ldap_bind_password")
 	setString(&object.Ldap.Filter, d, "ldap_filter")
 	setString(&object.Ldap.FilterBaseDn, d, "ldap_filter_base_dn")
 	setString(&object.Ldap.SslCert, d, "ldap_ssl_cert")
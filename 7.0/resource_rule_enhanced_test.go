@@ -6,10 +6,14 @@ package main
 /*
  * This test covers the following cases:
  *   - CRUD of a non-singleton, text-only resource
+ *   - Rejecting TrafficScript with syntax errors when planning, unless check_syntax is false
+ *   - Accepting calls to functions missing from the catalogue, such as ssl.clientCertIssuerDN,
+ *     and rejecting them when allow_unknown_functions is false
  */
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -18,9 +22,12 @@ import (
 	"github.com/pulse-vadc/go-vtm/7.0"
 )
 
+// Real TrafficScript functions which are missing from the provider's function catalogue
+const testRuleMissingFunctions = "log.info(ssl.clientCertIssuerDN()); log.info(ssl.clientCertNotAfter());"
+
 func TestResourceRuleEnhanced(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRule")
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckRuleEnhancedDestroy,
@@ -38,7 +45,27 @@ func TestResourceRuleEnhanced(t *testing.T) {
 					resource.TestCheckResourceAttr("vtm_rule.my_rule", "content", "log.info('two');"),
 				),
 			},
-
+			{
+				Config:      getRuleEnhancedSyntaxConfig(objName, "log.info(('three');", ""),
+				ExpectError: regexp.MustCompile(`line 1, column 9: '\(' is never closed`),
+			},
+			{
+				Config: getRuleEnhancedSyntaxConfig(objName, "log.info(('three');", "check_syntax = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_rule.my_rule", "content", "log.info(('three');"),
+				),
+			},
+			{
+				Config: getRuleEnhancedSyntaxConfig(objName, testRuleMissingFunctions, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_rule.my_rule", "content", testRuleMissingFunctions),
+					resource.TestCheckResourceAttr("vtm_rule.my_rule", "allow_unknown_functions", "true"),
+				),
+			},
+			{
+				Config:      getRuleEnhancedSyntaxConfig(objName, "log.inf('four');", "allow_unknown_functions = false"),
+				ExpectError: regexp.MustCompile(`line 1, column 1: unknown function 'log.inf'(.|\n)*allow_unknown_functions = false`),
+			},
 		},
 	})
 }
@@ -83,3 +110,14 @@ func getRuleEnhancedConfig(name, ruleInsert string) string {
 		name, ruleInsert,
 	)
 }
+
+func getRuleEnhancedSyntaxConfig(name, content, setting string) string {
+	return fmt.Sprintf(`
+		resource "vtm_rule" "my_rule" {
+			name = "%s"
+			content = "%s"
+			%s
+		}`,
+		name, content, setting,
+	)
+}
@@ -21,7 +21,7 @@ import (
 func TestResourceRule(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestRule")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckRuleDestroy,
@@ -70,7 +70,7 @@ func getBasicRuleConfig(name string) string {
 	return fmt.Sprintf(`
         resource "vtm_rule" "test_vtm_rule" {
 			name = "%s"
-			content = "TEST_TEXT"
+			content = "log.info('TEST_TEXT');"
 
         }`,
 		name,
@@ -87,6 +87,8 @@ func resourceSamlTrustedidpRead(d *schema.ResourceData, tm interface{}) (readErr
 		return fmt.Errorf("Failed to read vtm_trustedidp '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -129,9 +131,12 @@ func resourceSamlTrustedidpCreate(d *schema.ResourceData, tm interface{}) error
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewSamlTrustedidp(objectName, d.Get("certificate").(string), d.Get("entity_id").(string), d.Get("url").(string))
 	resourceSamlTrustedidpObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceSamlTrustedidpSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_trustedidp '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_trustedidp '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -145,9 +150,12 @@ func resourceSamlTrustedidpUpdate(d *schema.ResourceData, tm interface{}) error
 		return fmt.Errorf("Failed to update vtm_trustedidp '%v': %v", objectName, err)
 	}
 	resourceSamlTrustedidpObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceSamlTrustedidpSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_trustedidp '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_trustedidp '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -101,6 +101,8 @@ func resourceSecurityRead(d *schema.ResourceData, tm interface{}) (readError err
 		return fmt.Errorf("Failed to read vtm_security: %v", err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -159,9 +161,12 @@ func resourceSecurityUpdate(d *schema.ResourceData, tm interface{}) error {
 		d.Set("ssh_intrusion_whitelist", []string(*object.SshIntrusion.Whitelist))
 	}
 
+	if err := stripUnsupportedFields(tm, object, getResourceSecuritySchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_security: %v", err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_security: %s %s", applyErr.ErrorText, info)
 	}
 	d.SetId("security")
@@ -87,6 +87,8 @@ func resourceServiceLevelMonitorRead(d *schema.ResourceData, tm interface{}) (re
 		return fmt.Errorf("Failed to read vtm_service_level_monitor '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -127,9 +129,12 @@ func resourceServiceLevelMonitorCreate(d *schema.ResourceData, tm interface{}) e
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewServiceLevelMonitor(objectName)
 	resourceServiceLevelMonitorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceServiceLevelMonitorSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_service_level_monitor '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_service_level_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -143,9 +148,12 @@ func resourceServiceLevelMonitorUpdate(d *schema.ResourceData, tm interface{}) e
 		return fmt.Errorf("Failed to update vtm_service_level_monitor '%v': %v", objectName, err)
 	}
 	resourceServiceLevelMonitorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceServiceLevelMonitorSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_service_level_monitor '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_service_level_monitor '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
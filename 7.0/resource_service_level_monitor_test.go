@@ -21,7 +21,7 @@ import (
 func TestResourceServiceLevelMonitor(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestServiceLevelMonitor")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckServiceLevelMonitorDestroy,
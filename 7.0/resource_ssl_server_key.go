@@ -4,6 +4,7 @@
 package main
 
 import (
+	"crypto/x509"
 	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -45,22 +46,32 @@ func getResourceSslServerKeySchema() map[string]*schema.Schema {
 
 		// Private key for certificate
 		"private": &schema.Schema{
-			Type:     schema.TypeString,
-			Required: true,
-			DiffSuppressFunc: suppressHashedDiffs("private"),
+			Type:      schema.TypeString,
+			Optional:  true,
+			Computed:  true,
+			Sensitive: true,
+			StateFunc: hashComputedSecretState,
 		},
 
 		// Public certificate
 		"public": &schema.Schema{
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 		},
 
 		// Certificate Signing Request for certificate
 		"request": &schema.Schema{
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 		},
+
+		// Generate the private key and certificate signing request
+		"key_spec": sslKeySpecSchema(),
+
+		// Certificate to use instead of 'public'
+		"certificate_pem": certificatePemSchema(),
 	}
 }
 
@@ -79,6 +90,8 @@ func resourceSslServerKeyRead(d *schema.ResourceData, tm interface{}) (readError
 		return fmt.Errorf("Failed to read vtm_server_key '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -91,11 +104,14 @@ func resourceSslServerKeyRead(d *schema.ResourceData, tm interface{}) (readError
 	lastAssignedField = "note"
 	d.Set("note", string(*object.Basic.Note))
 	lastAssignedField = "private"
-	d.Set("private", string(*object.Basic.Private))
+	d.Set("private", getHashedSecretFingerprint(string(*object.Basic.Private)))
 	lastAssignedField = "public"
 	d.Set("public", string(*object.Basic.Public))
 	lastAssignedField = "request"
 	d.Set("request", string(*object.Basic.Request))
+	if d.Get("certificate_pem").(string) != "" {
+		d.Set("certificate_pem", string(*object.Basic.Public))
+	}
 	d.SetId(objectName)
 	return nil
 }
@@ -117,15 +133,22 @@ func resourceSslServerKeyExists(d *schema.ResourceData, tm interface{}) (bool, e
 
 func resourceSslServerKeyCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
-	object := tm.(*vtm.VirtualTrafficManager).NewSslServerKey(objectName, d.Get("note").(string), d.Get("private").(string), d.Get("public").(string), d.Get("request").(string))
+	private, public, request, err := getSslKeyMaterial(d, x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return fmt.Errorf("Error creating vtm_server_key '%s': %v", objectName, err)
+	}
+	object := tm.(*vtm.VirtualTrafficManager).NewSslServerKey(objectName, d.Get("note").(string), private, public, request)
 	resourceSslServerKeyObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceSslServerKeySchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_server_key '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_server_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
-	return nil
+	return resourceSslServerKeyRead(d, tm)
 }
 
 func resourceSslServerKeyUpdate(d *schema.ResourceData, tm interface{}) error {
@@ -134,21 +157,35 @@ func resourceSslServerKeyUpdate(d *schema.ResourceData, tm interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Failed to update vtm_server_key '%v': %v", objectName, err)
 	}
+	if d.HasChange("certificate_pem") {
+		if err := checkCertificateMatchesRequest(d.Get("certificate_pem").(string), d.Get("request").(string)); err != nil {
+			return fmt.Errorf("Error updating vtm_server_key '%s': %v", objectName, err)
+		}
+	}
 	resourceSslServerKeyObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceSslServerKeySchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_server_key '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_server_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
-	return nil
+	return resourceSslServerKeyRead(d, tm)
 }
 
 func resourceSslServerKeyObjectFieldAssignments(d *schema.ResourceData, object *vtm.SslServerKey) {
 	setString(&object.Basic.Note, d, "note")
-	setString(&object.Basic.Private, d, "private")
-	setString(&object.Basic.Public, d, "public")
-	setString(&object.Basic.Request, d, "request")
+	if _, generated := d.GetOk("key_spec"); generated == false {
+		setSecret(&object.Basic.Private, d, "private")
+		setString(&object.Basic.Public, d, "public")
+		setString(&object.Basic.Request, d, "request")
+	} else if d.IsNewResource() == false {
+		// The generated key is only reported as a fingerprint, which must not be written back
+		object.Basic.Private = nil
+	}
+	setCertificatePem(&object.Basic.Public, d)
 }
 
 func resourceSslServerKeyDelete(d *schema.ResourceData, tm interface{}) error {
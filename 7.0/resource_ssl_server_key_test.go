@@ -6,11 +6,28 @@ package main
 /*
  * This test covers the following cases:
  *   - Creation and deletion of a vtm_ssl_server_key object with minimal configuration
+ *   - The private key is only kept in the state as a hash
+ *   - A private key replaced outside Terraform shows up in the plan
+ *   - Generation of the key, request and a self-signed certificate with key_spec
+ *   - Replacement of the certificate with certificate_pem, keeping the key
+ *   - A certificate_pem for another key is refused
  */
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
@@ -21,7 +38,7 @@ import (
 func TestResourceSslServerKey(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestSslServerKey")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSslServerKeyDestroy,
@@ -30,8 +47,59 @@ func TestResourceSslServerKey(t *testing.T) {
 				Config: getBasicSslServerKeyConfig(objName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSslServerKeyExists,
+					resource.TestCheckResourceAttr("vtm_ssl_server_key.test_vtm_ssl_server_key", "private", hashSecret("TEST_TEXT")),
 				),
 			},
+			{
+				PreConfig:          func() { setSslServerKeyPrivate(t, objName, "ROTATED_TEXT") },
+				Config:             getBasicSslServerKeyConfig(objName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceSslServerKeyGenerated(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestSslServerKey")
+	certificateFile := filepath.Join(t.TempDir(), "certificate.pem")
+	otherCertificate, _ := getTestClientCertificate(t)
+	var private string
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSslServerKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getGeneratedSslServerKeyConfig(objName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSslServerKeyExists,
+					resource.TestMatchResourceAttr("vtm_ssl_server_key.test_vtm_ssl_server_key", "request", regexp.MustCompile("^-----BEGIN CERTIFICATE REQUEST-----")),
+					resource.TestMatchResourceAttr("vtm_ssl_server_key.test_vtm_ssl_server_key", "public", regexp.MustCompile("^-----BEGIN CERTIFICATE-----")),
+					func(s *terraform.State) error {
+						private = s.RootModule().Resources["vtm_ssl_server_key.test_vtm_ssl_server_key"].Primary.Attributes["private"]
+						if private == "" || strings.Contains(private, "PRIVATE KEY") {
+							return fmt.Errorf("Expected the private key to be held as a fingerprint, got %s", private)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() { issueSslServerKeyCertificate(t, objName, certificateFile) },
+				Config:    getGeneratedSslServerKeyConfig(objName, fmt.Sprintf("file(%q)", certificateFile)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vtm_ssl_server_key.test_vtm_ssl_server_key", "public", "vtm_ssl_server_key.test_vtm_ssl_server_key", "certificate_pem"),
+					func(s *terraform.State) error {
+						return resource.TestCheckResourceAttr("vtm_ssl_server_key.test_vtm_ssl_server_key", "private", private)(s)
+					},
+				),
+			},
+			{
+				Config:      getGeneratedSslServerKeyConfig(objName, fmt.Sprintf("%q", otherCertificate)),
+				ExpectError: regexp.MustCompile("certificate_pem is not for the key of the certificate signing request"),
+			},
 		},
 	})
 }
@@ -66,6 +134,89 @@ func testAccCheckSslServerKeyDestroy(s *terraform.State) error {
 	return nil
 }
 
+func setSslServerKeyPrivate(t *testing.T, objectName, private string) {
+	tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+	object, err := tm.GetSslServerKey(objectName)
+	if err != nil {
+		t.Fatalf("SslServerKey %s does not exist: %#v", objectName, err)
+	}
+	object.Basic.Private = getStringAddr(private)
+	if _, err := object.Apply(); err != nil {
+		t.Fatalf("Failed to update SslServerKey %s: %#v", objectName, err)
+	}
+}
+
+/*
+issueSslServerKeyCertificate signs the certificate signing request of an SSL server key with a new
+test CA, and writes the certificate to a file.
+*/
+func issueSslServerKeyCertificate(t *testing.T, objectName, path string) {
+	tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+	object, err := tm.GetSslServerKey(objectName)
+	if err != nil {
+		t.Fatalf("SslServerKey %s does not exist: %#v", objectName, err)
+	}
+	block, _ := pem.Decode([]byte(*object.Basic.Request))
+	if block == nil {
+		t.Fatalf("SslServerKey %s has no PEM encoded request", objectName)
+	}
+	csr, parseErr := x509.ParseCertificateRequest(block.Bytes)
+	if parseErr != nil {
+		t.Fatalf("Failed to parse request of SslServerKey %s: %v", objectName, parseErr)
+	}
+	caKey, keyErr := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if keyErr != nil {
+		t.Fatalf("Failed to generate CA key: %v", keyErr)
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certificate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, certErr := x509.CreateCertificate(rand.Reader, certificate, ca, csr.PublicKey, caKey)
+	if certErr != nil {
+		t.Fatalf("Failed to issue certificate: %v", certErr)
+	}
+	if writeErr := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); writeErr != nil {
+		t.Fatalf("Failed to write certificate: %v", writeErr)
+	}
+}
+
+func getGeneratedSslServerKeyConfig(name, certificatePem string) string {
+	certificate := ""
+	if certificatePem != "" {
+		certificate = "certificate_pem = " + certificatePem
+	}
+	return fmt.Sprintf(`
+        resource "vtm_ssl_server_key" "test_vtm_ssl_server_key" {
+			name = "%s"
+			note = "TEST_TEXT"
+			%s
+
+			key_spec {
+				algorithm = "ecdsa"
+				subject {
+					common_name = "www.example.com"
+				}
+				dns_names = ["www.example.com"]
+				self_signed = true
+			}
+        }`,
+		name, certificate,
+	)
+}
+
 func getBasicSslServerKeyConfig(name string) string {
 	return fmt.Sprintf(`
         resource "vtm_ssl_server_key" "test_vtm_ssl_server_key" {
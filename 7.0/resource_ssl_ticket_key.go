@@ -62,8 +62,10 @@ func getResourceSslTicketKeySchema() map[string]*schema.Schema {
 		//  by the chosen key algorithm. See the documentation for the 'algorithm'
 		//  field for more details.
 		"key": &schema.Schema{
-			Type:     schema.TypeString,
-			Required: true,
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The latest time at which this key may be used to encrypt new
@@ -99,6 +101,8 @@ func resourceSslTicketKeyRead(d *schema.ResourceData, tm interface{}) (readError
 		return fmt.Errorf("Failed to read vtm_ticket_key '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -113,7 +117,7 @@ func resourceSslTicketKeyRead(d *schema.ResourceData, tm interface{}) (readError
 	lastAssignedField = "identifier"
 	d.Set("identifier", string(*object.Basic.Id))
 	lastAssignedField = "key"
-	d.Set("key", string(*object.Basic.Key))
+	d.Set("key", getSecretFingerprint(d, "key", string(*object.Basic.Key)))
 	lastAssignedField = "validity_end"
 	d.Set("validity_end", int(*object.Basic.ValidityEnd))
 	lastAssignedField = "validity_start"
@@ -141,9 +145,12 @@ func resourceSslTicketKeyCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewSslTicketKey(objectName, d.Get("identifier").(string), d.Get("key").(string), d.Get("validity_end").(int), d.Get("validity_start").(int))
 	resourceSslTicketKeyObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceSslTicketKeySchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_ticket_key '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_ticket_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -157,9 +164,12 @@ func resourceSslTicketKeyUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_ticket_key '%v': %v", objectName, err)
 	}
 	resourceSslTicketKeyObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceSslTicketKeySchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_ticket_key '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_ticket_key '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -169,7 +179,7 @@ func resourceSslTicketKeyUpdate(d *schema.ResourceData, tm interface{}) error {
 func resourceSslTicketKeyObjectFieldAssignments(d *schema.ResourceData, object *vtm.SslTicketKey) {
 	setString(&object.Basic.Algorithm, d, "algorithm")
 	setString(&object.Basic.Id, d, "identifier")
-	setString(&object.Basic.Key, d, "key")
+	setSecret(&object.Basic.Key, d, "key")
 	setInt(&object.Basic.ValidityEnd, d, "validity_end")
 	setInt(&object.Basic.ValidityStart, d, "validity_start")
 }
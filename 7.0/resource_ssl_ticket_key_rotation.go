@@ -0,0 +1,407 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_ssl_ticket_key_rotation keeps a rotating schedule of SSL ticket keys in
+ * the ssl/ticket_keys catalog. Its keys are named <name_prefix>-<validity_start>.
+ *
+ * Each key encrypts new session tickets for one rotation period, plus an
+ * overlap with the next key's period, and keep_keys keys which have not yet
+ * ended are kept, so that the keys for the coming periods are already in
+ * place. Once a key has ended it is kept for ssl_tickets_ticket_key_expiry, as
+ * auto-generated keys are, so that tickets encrypted with it can still be
+ * decrypted, and is then deleted. The rotation period defaults to
+ * ssl_tickets_ticket_key_rotation from the global settings.
+ *
+ * The schedule is checked when planning, so each apply generates keys for any
+ * periods which have come into range and deletes expired keys. Applies must
+ * therefore be run more often than every (keep_keys - 1) rotation periods.
+ * Keys are generated from crypto/rand and are never stored in the state.
+ */
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// The key length, in bytes, of each ticket key algorithm.
+var sslTicketKeyLengths = map[string]int{
+	// A 256-bit AES key followed by a 256-bit HMAC-SHA256 key.
+	"aes_256_cbc_hmac_sha256": 64,
+}
+
+// The global settings for auto-generated ticket keys, used when they cannot be read.
+const (
+	defaultSslTicketKeyRotation = 14400
+	defaultSslTicketKeyExpiry   = 86400
+)
+
+type sslTicketKeyWindow struct {
+	name  string
+	start int
+	end   int
+}
+
+type sslTicketKeyRotationSchedule struct {
+	period  int
+	overlap int
+	keep    int
+	expiry  int
+}
+
+func resourceSslTicketKeyRotation() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourceSslTicketKeyRotationRead,
+		Exists: resourceSslTicketKeyRotationExists,
+		Create: resourceSslTicketKeyRotationCreate,
+		Update: resourceSslTicketKeyRotationUpdate,
+		Delete: resourceSslTicketKeyRotationDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceSslTicketKeyRotationCustomizeDiff,
+
+		Schema: getResourceSslTicketKeyRotationSchema(),
+	}
+}
+
+func getResourceSslTicketKeyRotationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		// The prefix of the names of the SSL ticket keys, which are named
+		//  <name_prefix>-<validity_start>.
+		"name_prefix": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile("^[^/]+$"), "must not be empty or contain '/'"),
+		},
+
+		// The algorithm used to encrypt session tickets.
+		"algorithm": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"aes_256_cbc_hmac_sha256"}, false),
+			Default:      "aes_256_cbc_hmac_sha256",
+		},
+
+		// The length of time, in seconds, for which each key is used to
+		//  encrypt new session tickets. 0 uses the global setting
+		//  ssl_tickets_ticket_key_rotation.
+		"rotation_period": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.Any(validation.IntBetween(0, 0), validation.IntBetween(60, 31535940)),
+			Default:      0,
+		},
+
+		// The length of time, in seconds, for which each key's validity
+		//  overlaps the next key's, to allow for differences between the
+		//  clocks of the traffic managers. It must be less than the rotation
+		//  period.
+		"overlap": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Default:      300,
+		},
+
+		// The number of keys, for the current and following rotation periods,
+		//  to keep in place. Keys which have ended are kept in addition to
+		//  these until they expire.
+		"keep_keys": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(2, 100),
+			Default:      3,
+		},
+
+		// The names of the SSL ticket keys in the schedule, in order of
+		//  validity_start.
+		"key_names": &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+
+		// The time at which the next key in the schedule starts to be used,
+		//  in seconds since the epoch.
+		"next_rotation": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		// The time at which the last key in the schedule ends, in seconds
+		//  since the epoch. The resource must be applied again before then.
+		"schedule_end": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+/*
+getSslTicketKeyRotationSchedule combines the resource's settings with the global ticket key
+settings. get is the Get method of the resource's ResourceData or ResourceDiff.
+*/
+func getSslTicketKeyRotationSchedule(get func(string) interface{}, tm interface{}) (sslTicketKeyRotationSchedule, error) {
+	schedule := sslTicketKeyRotationSchedule{
+		period:  get("rotation_period").(int),
+		overlap: get("overlap").(int),
+		keep:    get("keep_keys").(int),
+		expiry:  defaultSslTicketKeyExpiry,
+	}
+	globalSettings, err := tm.(*vtm.VirtualTrafficManager).GetGlobalSettings()
+	if err != nil {
+		return schedule, fmt.Errorf("Failed to read the global SSL ticket key settings: %v", err.ErrorText)
+	}
+	if globalSettings.Ssl.TicketsTicketKeyExpiry != nil {
+		schedule.expiry = *globalSettings.Ssl.TicketsTicketKeyExpiry
+	}
+	if schedule.period == 0 {
+		schedule.period = defaultSslTicketKeyRotation
+		if globalSettings.Ssl.TicketsTicketKeyRotation != nil {
+			schedule.period = *globalSettings.Ssl.TicketsTicketKeyRotation
+		}
+	}
+	if schedule.overlap >= schedule.period {
+		return schedule, fmt.Errorf("overlap (%d) must be less than the rotation period (%d)", schedule.overlap, schedule.period)
+	}
+	return schedule, nil
+}
+
+func getSslTicketKeyName(prefix string, start int) string {
+	return prefix + "-" + strconv.Itoa(start)
+}
+
+// listSslTicketKeyWindows returns the SSL ticket keys named after prefix, in order of validity_start.
+func listSslTicketKeyWindows(tm interface{}, prefix string) ([]sslTicketKeyWindow, error) {
+	names, err := tm.(*vtm.VirtualTrafficManager).ListSslTicketKeys()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list SSL ticket keys: %v", err.ErrorText)
+	}
+	windows := []sslTicketKeyWindow{}
+	for _, name := range *names {
+		if strings.HasPrefix(name, prefix+"-") == false {
+			continue
+		}
+		if _, convErr := strconv.Atoi(strings.TrimPrefix(name, prefix+"-")); convErr != nil {
+			continue
+		}
+		object, err := tm.(*vtm.VirtualTrafficManager).GetSslTicketKey(name)
+		if err != nil {
+			if err.ErrorId == "resource.not_found" {
+				continue
+			}
+			return nil, fmt.Errorf("Failed to read SSL ticket key '%s': %v", name, err.ErrorText)
+		}
+		window := sslTicketKeyWindow{name: name}
+		if object.Basic.ValidityStart != nil {
+			window.start = *object.Basic.ValidityStart
+		}
+		if object.Basic.ValidityEnd != nil {
+			window.end = *object.Basic.ValidityEnd
+		}
+		windows = append(windows, window)
+	}
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].start < windows[j].start })
+	return windows, nil
+}
+
+/*
+planSslTicketKeyRotation works out which keys to create so that schedule.keep keys have not yet
+ended at now, continuing from the last existing key, and which keys have expired and are to be
+deleted.
+*/
+func planSslTicketKeyRotation(windows []sslTicketKeyWindow, prefix string, now int, schedule sslTicketKeyRotationSchedule) (create, prune []sslTicketKeyWindow) {
+	live := 0
+	for _, window := range windows {
+		if window.end > now {
+			live++
+		} else if window.end+schedule.expiry <= now {
+			prune = append(prune, window)
+		}
+	}
+	next := now
+	if len(windows) > 0 && windows[len(windows)-1].end > now {
+		next = windows[len(windows)-1].start + schedule.period
+	}
+	for ; live < schedule.keep; live++ {
+		create = append(create, sslTicketKeyWindow{
+			name:  getSslTicketKeyName(prefix, next),
+			start: next,
+			end:   next + schedule.period + schedule.overlap,
+		})
+		next += schedule.period
+	}
+	return create, prune
+}
+
+// newSslTicketKeyMaterial generates a random key identifier and key for algorithm, hex encoded.
+func newSslTicketKeyMaterial(algorithm string) (identifier, key string, err error) {
+	identifierBytes := make([]byte, 16)
+	keyBytes := make([]byte, sslTicketKeyLengths[algorithm])
+	if _, err := rand.Read(identifierBytes); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(keyBytes); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(identifierBytes), hex.EncodeToString(keyBytes), nil
+}
+
+func resourceSslTicketKeyRotationCustomizeDiff(d *schema.ResourceDiff, tm interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, field := range []string{"rotation_period", "overlap", "keep_keys"} {
+		if d.NewValueKnown(field) == false {
+			return nil
+		}
+	}
+	schedule, err := getSslTicketKeyRotationSchedule(d.Get, tm)
+	if err != nil {
+		return fmt.Errorf("vtm_ssl_ticket_key_rotation '%s': %v", d.Id(), err)
+	}
+	windows, err := listSslTicketKeyWindows(tm, d.Id())
+	if err != nil {
+		return fmt.Errorf("vtm_ssl_ticket_key_rotation '%s': %v", d.Id(), err)
+	}
+	create, prune := planSslTicketKeyRotation(windows, d.Id(), int(time.Now().Unix()), schedule)
+	if len(create) > 0 || len(prune) > 0 {
+		d.SetNewComputed("key_names")
+		d.SetNewComputed("next_rotation")
+		d.SetNewComputed("schedule_end")
+	}
+	return nil
+}
+
+func resourceSslTicketKeyRotationRead(d *schema.ResourceData, tm interface{}) error {
+	prefix := d.Get("name_prefix").(string)
+	if prefix == "" {
+		prefix = d.Id()
+		d.Set("name_prefix", prefix)
+	}
+	windows, err := listSslTicketKeyWindows(tm, prefix)
+	if err != nil {
+		return fmt.Errorf("Failed to read vtm_ssl_ticket_key_rotation '%v': %v", prefix, err)
+	}
+	now := int(time.Now().Unix())
+	keyNames := []string{}
+	nextRotation, scheduleEnd := 0, 0
+	for _, window := range windows {
+		keyNames = append(keyNames, window.name)
+		if window.start > now && nextRotation == 0 {
+			nextRotation = window.start
+		}
+		if window.end > scheduleEnd {
+			scheduleEnd = window.end
+		}
+	}
+	d.Set("key_names", keyNames)
+	d.Set("next_rotation", nextRotation)
+	d.Set("schedule_end", scheduleEnd)
+	d.SetId(prefix)
+	return nil
+}
+
+func resourceSslTicketKeyRotationExists(d *schema.ResourceData, tm interface{}) (bool, error) {
+	prefix := d.Get("name_prefix").(string)
+	if prefix == "" {
+		prefix = d.Id()
+	}
+	windows, err := listSslTicketKeyWindows(tm, prefix)
+	if err != nil {
+		return false, err
+	}
+	return len(windows) > 0, nil
+}
+
+func resourceSslTicketKeyRotationCreate(d *schema.ResourceData, tm interface{}) error {
+	prefix := d.Get("name_prefix").(string)
+	windows, err := listSslTicketKeyWindows(tm, prefix)
+	if err != nil {
+		return fmt.Errorf("Error creating vtm_ssl_ticket_key_rotation '%s': %v", prefix, err)
+	}
+	if len(windows) > 0 {
+		return fmt.Errorf("Error creating vtm_ssl_ticket_key_rotation '%s': SSL ticket key '%s' already exists, import it to manage it", prefix, windows[0].name)
+	}
+	if err := rotateSslTicketKeys(d, tm, windows); err != nil {
+		return fmt.Errorf("Error creating vtm_ssl_ticket_key_rotation '%s': %v", prefix, err)
+	}
+	d.SetId(prefix)
+	return resourceSslTicketKeyRotationRead(d, tm)
+}
+
+func resourceSslTicketKeyRotationUpdate(d *schema.ResourceData, tm interface{}) error {
+	prefix := d.Get("name_prefix").(string)
+	windows, err := listSslTicketKeyWindows(tm, prefix)
+	if err == nil {
+		err = rotateSslTicketKeys(d, tm, windows)
+	}
+	if err != nil {
+		return fmt.Errorf("Error updating vtm_ssl_ticket_key_rotation '%s': %v", prefix, err)
+	}
+	return resourceSslTicketKeyRotationRead(d, tm)
+}
+
+// rotateSslTicketKeys creates the keys which the schedule needs, then deletes the expired keys.
+func rotateSslTicketKeys(d *schema.ResourceData, tm interface{}, windows []sslTicketKeyWindow) error {
+	prefix := d.Get("name_prefix").(string)
+	algorithm := d.Get("algorithm").(string)
+	schedule, err := getSslTicketKeyRotationSchedule(d.Get, tm)
+	if err != nil {
+		return err
+	}
+	create, prune := planSslTicketKeyRotation(windows, prefix, int(time.Now().Unix()), schedule)
+	for _, window := range create {
+		identifier, key, err := newSslTicketKeyMaterial(algorithm)
+		if err != nil {
+			return fmt.Errorf("Failed to generate SSL ticket key '%s': %v", window.name, err)
+		}
+		object := tm.(*vtm.VirtualTrafficManager).NewSslTicketKey(window.name, identifier, key, window.end, window.start)
+		object.Basic.Algorithm = &algorithm
+		if err := stripUnsupportedFields(tm, object, getResourceSslTicketKeySchema()); err != nil {
+			return err
+		}
+		if _, applyErr := object.Apply(); applyErr != nil {
+			info := formatErrorInfo(applyErr.ErrorInfo)
+			return fmt.Errorf("Failed to create SSL ticket key '%s': %s %s", window.name, applyErr.ErrorText, info)
+		}
+	}
+	for _, window := range prune {
+		if err := tm.(*vtm.VirtualTrafficManager).DeleteSslTicketKey(window.name); err != nil && err.ErrorId != "resource.not_found" {
+			return fmt.Errorf("Failed to delete expired SSL ticket key '%s': %v", window.name, err.ErrorText)
+		}
+	}
+	return nil
+}
+
+func resourceSslTicketKeyRotationDelete(d *schema.ResourceData, tm interface{}) error {
+	prefix := d.Get("name_prefix").(string)
+	windows, err := listSslTicketKeyWindows(tm, prefix)
+	if err != nil {
+		return fmt.Errorf("Failed to delete vtm_ssl_ticket_key_rotation '%v': %v", prefix, err)
+	}
+	for _, window := range windows {
+		if err := tm.(*vtm.VirtualTrafficManager).DeleteSslTicketKey(window.name); err != nil && err.ErrorId != "resource.not_found" {
+			return fmt.Errorf("Failed to delete vtm_ssl_ticket_key_rotation '%v': %v", prefix, err.ErrorText)
+		}
+	}
+	d.SetId("")
+	return nil
+}
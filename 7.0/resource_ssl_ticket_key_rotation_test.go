@@ -0,0 +1,205 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * These tests cover the following cases:
+ *   - Planning the keys to create and delete for a schedule
+ *   - Creating a schedule of random keys with chained validity windows
+ *   - Deleting expired keys and replacing missing keys on the next apply
+ *   - Taking the rotation period from the global settings
+ *   - Deleting every key of the schedule
+ */
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func TestPlanSslTicketKeyRotation(t *testing.T) {
+	schedule := sslTicketKeyRotationSchedule{period: 100, overlap: 10, keep: 3, expiry: 50}
+	create, prune := planSslTicketKeyRotation(nil, "keys", 1000, schedule)
+	expected := []sslTicketKeyWindow{{"keys-1000", 1000, 1110}, {"keys-1100", 1100, 1210}, {"keys-1200", 1200, 1310}}
+	if reflect.DeepEqual(create, expected) == false || len(prune) != 0 {
+		t.Fatalf("Unexpected plan for a new schedule: create %v, prune %v", create, prune)
+	}
+
+	windows := []sslTicketKeyWindow{
+		{"keys-800", 800, 910},
+		{"keys-900", 900, 1010},
+		{"keys-1000", 1000, 1110},
+		{"keys-1100", 1100, 1210},
+	}
+	create, prune = planSslTicketKeyRotation(windows, "keys", 1050, schedule)
+	if reflect.DeepEqual(create, []sslTicketKeyWindow{{"keys-1200", 1200, 1310}}) == false {
+		t.Errorf("Unexpected keys to create: %v", create)
+	}
+	if reflect.DeepEqual(prune, []sslTicketKeyWindow{{"keys-800", 800, 910}}) == false {
+		t.Errorf("Unexpected keys to delete: %v", prune)
+	}
+
+	create, prune = planSslTicketKeyRotation(windows, "keys", 5000, schedule)
+	if len(create) != 3 || create[0].start != 5000 || len(prune) != 4 {
+		t.Errorf("Unexpected plan after the schedule ended: create %v, prune %v", create, prune)
+	}
+}
+
+func TestResourceSslTicketKeyRotation(t *testing.T) {
+	prefix := acctest.RandomWithPrefix("TestSslTicketKeyRotation")
+	expiredName := getSslTicketKeyName(prefix, 1000)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSslTicketKeyRotationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getSslTicketKeyRotationConfig(prefix, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_ssl_ticket_key_rotation.test", "id", prefix),
+					resource.TestCheckResourceAttr("vtm_ssl_ticket_key_rotation.test", "key_names.#", "3"),
+					testAccCheckSslTicketKeyRotationKeys(prefix, 3600, 60, 3),
+				),
+			},
+			{
+				// An expired key is deleted, and the last key is replaced.
+				PreConfig: func() {
+					tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+					expiredKey := tm.NewSslTicketKey(expiredName, "00000000000000000000000000000000", "00", 2000, 1000)
+					if _, err := expiredKey.Apply(); err != nil {
+						t.Fatalf("Failed to create SSL ticket key %s: %v", expiredName, err.ErrorText)
+					}
+					windows, err := listSslTicketKeyWindows(tm, prefix)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := tm.DeleteSslTicketKey(windows[len(windows)-1].name); err != nil {
+						t.Fatalf("Failed to delete SSL ticket key %s: %v", windows[len(windows)-1].name, err.ErrorText)
+					}
+				},
+				Config: getSslTicketKeyRotationConfig(prefix, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_ssl_ticket_key_rotation.test", "key_names.#", "3"),
+					testAccCheckSslTicketKeyRotationKeys(prefix, 3600, 60, 3),
+					testAccCheckSslTicketKeyMissing(expiredName),
+				),
+			},
+			{
+				ResourceName:            "vtm_ssl_ticket_key_rotation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"algorithm", "keep_keys", "overlap", "rotation_period"},
+			},
+		},
+	})
+}
+
+func TestResourceSslTicketKeyRotationGlobalSettings(t *testing.T) {
+	prefix := acctest.RandomWithPrefix("TestSslTicketKeyRotation")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSslTicketKeyRotationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getSslTicketKeyRotationConfig(prefix, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSslTicketKeyRotationKeys(prefix, defaultSslTicketKeyRotation, 60, 3),
+				),
+			},
+			{
+				Config:      getSslTicketKeyRotationConfig(prefix, 60),
+				ExpectError: regexp.MustCompile(`overlap \(60\) must be less than the rotation period \(60\)`),
+			},
+		},
+	})
+}
+
+// testAccCheckSslTicketKeyRotationKeys checks that the keys of a schedule have random keys and chained validity windows.
+func testAccCheckSslTicketKeyRotationKeys(prefix string, period, overlap, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		windows, err := listSslTicketKeyWindows(tm, prefix)
+		if err != nil {
+			return err
+		}
+		if len(windows) != count {
+			return fmt.Errorf("Expected %d SSL ticket keys named %s-*, found %v", count, prefix, windows)
+		}
+		now := int(time.Now().Unix())
+		if windows[0].start > now || windows[0].end <= now {
+			return fmt.Errorf("SSL ticket key %s is not valid now (%d): %v", windows[0].name, now, windows[0])
+		}
+		identifiers := map[string]bool{}
+		for i, window := range windows {
+			if window.name != prefix+"-"+strconv.Itoa(window.start) || window.end != window.start+period+overlap {
+				return fmt.Errorf("SSL ticket key %s has unexpected validity %v", window.name, window)
+			}
+			if i > 0 && window.start != windows[i-1].start+period {
+				return fmt.Errorf("SSL ticket key %s does not follow %s", window.name, windows[i-1].name)
+			}
+			object, vtmErr := tm.GetSslTicketKey(window.name)
+			if vtmErr != nil {
+				return fmt.Errorf("SslTicketKey %s does not exist: %#v", window.name, vtmErr)
+			}
+			if regexp.MustCompile("^[0-9a-f]{32}$").MatchString(*object.Basic.Id) == false || identifiers[*object.Basic.Id] {
+				return fmt.Errorf("SSL ticket key %s has an invalid identifier '%s'", window.name, *object.Basic.Id)
+			}
+			identifiers[*object.Basic.Id] = true
+			if regexp.MustCompile("^[0-9a-f]{128}$").MatchString(*object.Basic.Key) == false {
+				return fmt.Errorf("SSL ticket key %s has an invalid key", window.name)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckSslTicketKeyMissing(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.GetSslTicketKey(name); err == nil {
+			return fmt.Errorf("SslTicketKey %s still exists", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSslTicketKeyRotationDestroy(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_ssl_ticket_key_rotation" {
+			continue
+		}
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		windows, err := listSslTicketKeyWindows(tm, tfResource.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(windows) > 0 {
+			return fmt.Errorf("SSL ticket keys of vtm_ssl_ticket_key_rotation %s still exist: %v", tfResource.Primary.ID, windows)
+		}
+	}
+
+	return nil
+}
+
+func getSslTicketKeyRotationConfig(prefix string, period int) string {
+	return fmt.Sprintf(`
+		resource "vtm_ssl_ticket_key_rotation" "test" {
+			name_prefix = "%s"
+			rotation_period = %d
+			overlap = 60
+		}`,
+		prefix, period,
+	)
+}
@@ -4,8 +4,16 @@
 package main
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	vtm "github.com/pulse-vadc/go-vtm/7.0"
@@ -23,6 +31,11 @@ func resourceSystemBackupsFull() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 
 			"name": &schema.Schema{
@@ -36,6 +49,7 @@ func resourceSystemBackupsFull() *schema.Resource {
 			"description": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 
 			// Time the backup was created. Expressed as a UTC value.
@@ -51,10 +65,151 @@ func resourceSystemBackupsFull() *schema.Resource {
 				Optional:     true,
 				Computed: true,
 			},
+
+			// A base64 encoded backup archive to upload instead of taking a
+			//  new backup. Only its fingerprint is kept in the state.
+			"upload_archive": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Sensitive:     true,
+				StateFunc:     hashSecretState,
+				ConflictsWith: []string{"upload_path"},
+			},
+
+			// A local backup archive file, for example the download_path of
+			//  a backup on another traffic manager, to upload instead of
+			//  taking a new backup.
+			"upload_path": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"upload_archive"},
+			},
+
+			// Restore the traffic manager's configuration from the backup when
+			//  it is created, or when this is changed to true, and wait for the
+			//  restore to complete.
+			"restore": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// A local file to write the backup archive to.
+			"download_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// The hex encoded SHA-256 hash of the archive written to
+			//  download_path or uploaded, as filesha256() gives for the
+			//  file. The archive holds private keys and other secrets, so
+			//  it is not kept in the state.
+			"archive_sha256": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// downloadSystemBackupsFull writes a backup's archive to download_path.
+func downloadSystemBackupsFull(d *schema.ResourceData, tm interface{}, objectName string) error {
+	downloadPath := d.Get("download_path").(string)
+	if downloadPath == "" {
+		return nil
+	}
+	archive, err := tm.(*vtm.VirtualTrafficManager).GetSystemBackupsFullArchive(objectName)
+	if err != nil {
+		return fmt.Errorf("Failed to download vtm_backups_full '%v': %v", objectName, err.ErrorText)
+	}
+	if writeErr := ioutil.WriteFile(downloadPath, archive, 0600); writeErr != nil {
+		return fmt.Errorf("Failed to write vtm_backups_full '%v' to %s: %v", objectName, downloadPath, writeErr)
+	}
+	d.Set("archive_sha256", getArchiveSha256(archive))
+	return nil
+}
+
+func getArchiveSha256(archive []byte) string {
+	archiveHash := sha256.Sum256(archive)
+	return hex.EncodeToString(archiveHash[:])
+}
+
+// The prefix of the extra file used to tell when a restore has replaced the configuration.
+const restoreMarkerPrefix = "terraform-restore-"
+
+/*
+restoreSystemBackupsFull restores a backup and then waits, until the given timeout, for the restored
+configuration to be loaded. The traffic manager answers requests, sometimes with the old configuration,
+until the restore takes effect, and the REST API may then be unavailable for a while, so neither is a
+sign that the restore has finished. Instead an extra file, which cannot be in the backup, is written
+before the restore; a full restore replaces the whole configuration, so the restore has finished once
+the file has gone and the traffic manager reports its state again. With several endpoints, the
+restore is sent to each and waited for on each, and a timeout names the endpoints which have not
+finished. The file is removed whenever the restore fails, and the error names it if it could not be.
+*/
+func restoreSystemBackupsFull(tm interface{}, objectName string, timeout time.Duration) (restoreErr error) {
+	markerBytes := make([]byte, 16)
+	if _, err := rand.Read(markerBytes); err != nil {
+		return fmt.Errorf("Failed to restore vtm_backups_full '%v': %v", objectName, err)
+	}
+	marker := restoreMarkerPrefix + hex.EncodeToString(markerBytes)
+	if err := tm.(*vtm.VirtualTrafficManager).SetExtraFile(marker, "Removed when vtm_backups_full '"+objectName+"' has been restored\n"); err != nil {
+		return fmt.Errorf("Failed to restore vtm_backups_full '%v': %s", objectName, err.ErrorText)
+	}
+	defer func() {
+		if restoreErr == nil {
+			return
+		}
+		if err := tm.(*vtm.VirtualTrafficManager).DeleteExtraFile(marker); err != nil && err.ErrorId != "resource.not_found" {
+			restoreErr = fmt.Errorf("%v\nThe extra file '%s' could not be removed and should be deleted by hand: %s", restoreErr, marker, err.ErrorText)
+		}
+	}()
+	// The restore is sent only once, so an error may still mean that it has started
+	if err := tm.(*vtm.VirtualTrafficManager).RestoreSystemBackupsFull(objectName); err != nil {
+		return fmt.Errorf("Failed to restore vtm_backups_full '%v': %s %s", objectName, err.ErrorText, formatErrorInfo(err.ErrorInfo))
+	}
+	endpoints := tm.(*vtm.VirtualTrafficManager).GetEndpoints()
+	restored := make([]bool, len(endpoints))
+	pending := []string{}
+	waiter := &resource.StateChangeConf{
+		Pending:      []string{"restoring"},
+		Target:       []string{"restored"},
+		Timeout:      timeout,
+		Delay:        time.Second,
+		PollInterval: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			pending = []string{}
+			for i, endpoint := range endpoints {
+				if restored[i] {
+					continue
+				}
+				if _, err := endpoint.GetExtraFile(marker); err == nil || err.ErrorId != "resource.not_found" {
+					pending = append(pending, endpoint.GetEndpointLabel())
+					continue
+				}
+				if _, err := endpoint.GetSystemState(); err != nil {
+					pending = append(pending, endpoint.GetEndpointLabel())
+					continue
+				}
+				restored[i] = true
+			}
+			if len(pending) != 0 {
+				return pending, "restoring", nil
+			}
+			return restored, "restored", nil
+		},
+	}
+	if _, err := waiter.WaitForState(); err != nil {
+		if len(endpoints) > 1 && len(pending) != 0 {
+			return fmt.Errorf("Failed to restore vtm_backups_full '%v': %v; still restoring on %s", objectName, err, strings.Join(pending, ", "))
+		}
+		return fmt.Errorf("Failed to restore vtm_backups_full '%v': %v", objectName, err)
+	}
+	return nil
+}
+
 func resourceSystemBackupsFullRead(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object, err := tm.(*vtm.VirtualTrafficManager).GetSystemBackupsFull(objectName)
@@ -87,14 +242,46 @@ func resourceSystemBackupsFullExists(d *schema.ResourceData, tm interface{}) (bo
 
 func resourceSystemBackupsFullCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
-	object := tm.(*vtm.VirtualTrafficManager).NewSystemBackupsFull(objectName)
-	setString(&object.Backup.Description, d, "description")
-	setInt(&object.Backup.TimeStamp, d, "time_stamp")
-	setString(&object.Backup.Version, d, "version")
+	var archive []byte
+	if uploadArchive := d.Get("upload_archive").(string); uploadArchive != "" {
+		var decodeErr error
+		if archive, decodeErr = base64.StdEncoding.DecodeString(uploadArchive); decodeErr != nil {
+			return fmt.Errorf("Error creating vtm_backups_full '%s': upload_archive is not valid base64: %v", objectName, decodeErr)
+		}
+	} else if uploadPath := d.Get("upload_path").(string); uploadPath != "" {
+		var readErr error
+		if archive, readErr = ioutil.ReadFile(uploadPath); readErr != nil {
+			return fmt.Errorf("Error creating vtm_backups_full '%s': %v", objectName, readErr)
+		}
+	}
+	if archive != nil {
+		if err := tm.(*vtm.VirtualTrafficManager).UploadSystemBackupsFull(objectName, archive); err != nil {
+			return fmt.Errorf("Error creating vtm_backups_full '%s': %s %s", objectName, err.ErrorText, formatErrorInfo(err.ErrorInfo))
+		}
+		d.Set("archive_sha256", getArchiveSha256(archive))
+	} else {
+		object := tm.(*vtm.VirtualTrafficManager).NewSystemBackupsFull(objectName)
+		setString(&object.Backup.Description, d, "description")
+		setInt(&object.Backup.TimeStamp, d, "time_stamp")
+		setString(&object.Backup.Version, d, "version")
 
-	object.Apply()
+		_, applyErr := object.Apply()
+		if applyErr != nil {
+			info := formatErrorInfo(applyErr.ErrorInfo)
+			return fmt.Errorf("Error creating vtm_backups_full '%s': %s %s", objectName, applyErr.ErrorText, info)
+		}
+	}
 	d.SetId(objectName)
-	return nil
+
+	if err := downloadSystemBackupsFull(d, tm, objectName); err != nil {
+		return err
+	}
+	if d.Get("restore") == true {
+		if err := restoreSystemBackupsFull(tm, objectName, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+	return resourceSystemBackupsFullRead(d, tm)
 }
 
 func resourceSystemBackupsFullUpdate(d *schema.ResourceData, tm interface{}) error {
@@ -103,13 +290,30 @@ func resourceSystemBackupsFullUpdate(d *schema.ResourceData, tm interface{}) err
 	if err != nil {
 		return fmt.Errorf("Failed to update vtm_backups_full '%v': %v", objectName, err)
 	}
-	setString(&object.Backup.Description, d, "description")
-	setInt(&object.Backup.TimeStamp, d, "time_stamp")
-	setString(&object.Backup.Version, d, "version")
+	if d.HasChange("description") || d.HasChange("time_stamp") || d.HasChange("version") {
+		setString(&object.Backup.Description, d, "description")
+		setInt(&object.Backup.TimeStamp, d, "time_stamp")
+		setString(&object.Backup.Version, d, "version")
 
-	object.Apply()
+		_, applyErr := object.Apply()
+		if applyErr != nil {
+			info := formatErrorInfo(applyErr.ErrorInfo)
+			return fmt.Errorf("Error updating vtm_backups_full '%s': %s %s", objectName, applyErr.ErrorText, info)
+		}
+	}
 	d.SetId(objectName)
-	return nil
+
+	if d.HasChange("download_path") {
+		if err := downloadSystemBackupsFull(d, tm, objectName); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("restore") && d.Get("restore") == true {
+		if err := restoreSystemBackupsFull(tm, objectName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+	return resourceSystemBackupsFullRead(d, tm)
 }
 
 func resourceSystemBackupsFullDelete(d *schema.ResourceData, tm interface{}) error {
@@ -6,11 +6,22 @@ package main
 /*
  * This test covers the following cases:
  *   - Creation and deletion of a vtm_system_backup_full object with minimal configuration
+ *   - Downloading a backup's archive, uploading it as another backup and restoring that backup
+ *   - Keeping only the archive's hash in the state
+ *   - Waiting for a restore to replace configuration made after the backup
+ *   - Waiting for a restore on every endpoint
  */
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
@@ -21,7 +32,7 @@ import (
 func TestResourceSystemBackupsFull(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestBackupFull")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckSystemBackupsFullDestroy,
@@ -37,6 +48,132 @@ func TestResourceSystemBackupsFull(t *testing.T) {
 	})
 }
 
+func TestResourceSystemBackupsFullArchive(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestBackupFull")
+	downloadDir, err := ioutil.TempDir("", "TestBackupFull")
+	if err != nil {
+		t.Fatalf("Fatal error: %+v", err)
+	}
+	defer os.RemoveAll(downloadDir)
+	downloadPath := filepath.Join(downloadDir, "backup.tar")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSystemBackupsFullDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getArchiveSystemBackupsFullConfig(objName, downloadPath, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSystemBackupsFullExists,
+					resource.TestCheckResourceAttr("vtm_backups_full.test_vtm_backups_full_copy", "description", "TEST_TEXT"),
+					resource.TestCheckResourceAttr("vtm_backups_full.test_vtm_backups_full_copy", "restore", "false"),
+					testAccCheckSystemBackupsFullDownloaded(downloadPath),
+				),
+			},
+			{
+				PreConfig: func() {
+					tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+					if err := tm.SetExtraFile(objName+"_after_backup", "TEST_TEXT"); err != nil {
+						t.Fatalf("Failed to create extra file %s: %v", objName+"_after_backup", err.ErrorText)
+					}
+				},
+				Config: getArchiveSystemBackupsFullConfig(objName, downloadPath, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_backups_full.test_vtm_backups_full_copy", "restore", "true"),
+					testAccCheckSystemBackupsFullRestored(objName+"_copy"),
+					testAccCheckSystemBackupsFullConfigurationRestored(objName+"_after_backup"),
+				),
+			},
+		},
+	})
+}
+
+func TestSystemBackupsFullRestoreEndpoints(t *testing.T) {
+	fakes := []*fakeVtm{newFakeVtm(), newFakeVtm()}
+	defer fakes[0].Close()
+	provider, err := configureTestProvider(map[string]interface{}{
+		"endpoints":   getTestEndpointSettings(fakes),
+		"max_retries": 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure provider: %v", err)
+	}
+	tm := provider.Meta().(*vtm.VirtualTrafficManager)
+	if _, applyErr := tm.NewSystemBackupsFull("endpoints").Apply(); applyErr != nil {
+		t.Fatalf("Failed to create backup: %v", applyErr)
+	}
+
+	// The second endpoint stops answering once its restore has started, so it never finishes.
+	go func() {
+		for len(fakes[1].RestoredBackups()) == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		fakes[1].Close()
+	}()
+	restoreErr := restoreSystemBackupsFull(tm, "endpoints", 10*time.Second)
+	if restoreErr == nil || strings.Contains(restoreErr.Error(), "still restoring on Endpoint 'region-2'") != true {
+		t.Fatalf("Expected the restore to time out on region-2, got %v", restoreErr)
+	}
+	if strings.Contains(restoreErr.Error(), "region-1") {
+		t.Errorf("Expected the restore to finish on region-1, got %v", restoreErr)
+	}
+}
+
+func testAccCheckSystemBackupsFullDownloaded(downloadPath string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		archive, err := ioutil.ReadFile(downloadPath)
+		if err != nil {
+			return fmt.Errorf("Backup was not downloaded to %s: %v", downloadPath, err)
+		}
+		archiveHash := sha256.Sum256(archive)
+		for _, resourceName := range []string{"vtm_backups_full.test_vtm_backups_full", "vtm_backups_full.test_vtm_backups_full_copy"} {
+			attributes := s.RootModule().Resources[resourceName].Primary.Attributes
+			if attributes["archive_sha256"] != hex.EncodeToString(archiveHash[:]) {
+				return fmt.Errorf("%s archive_sha256 does not match the archive downloaded to %s", resourceName, downloadPath)
+			}
+			for attribute, value := range attributes {
+				if len(value) >= len(archive) {
+					return fmt.Errorf("%s keeps %s, of %d bytes, in the state", resourceName, attribute, len(value))
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckSystemBackupsFullConfigurationRestored checks that an extra file made after the backup has gone.
+func testAccCheckSystemBackupsFullConfigurationRestored(extraFileName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		names, err := tm.ListExtraFiles()
+		if err != nil {
+			return fmt.Errorf("Failed to list extra files: %v", err.ErrorText)
+		}
+		for _, name := range *names {
+			if name == extraFileName || strings.HasPrefix(name, restoreMarkerPrefix) {
+				return fmt.Errorf("Extra file %s still exists after the restore", name)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckSystemBackupsFullRestored(objectName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Only the fake vTM records restores; a real vTM has no record to check.
+		if testFakeVtm == nil {
+			return nil
+		}
+		for _, restored := range testFakeVtm.RestoredBackups() {
+			if restored == objectName {
+				return nil
+			}
+		}
+		return fmt.Errorf("BackupsFull %s was not restored", objectName)
+	}
+}
+
 func testAccCheckSystemBackupsFullExists(s *terraform.State) error {
 	for _, tfResource := range s.RootModule().Resources {
 		if tfResource.Type != "vtm_backups_full" {
@@ -76,3 +213,20 @@ func getBasicSystemBackupsFullConfig(name string) string {
 		name,
 	)
 }
+
+func getArchiveSystemBackupsFullConfig(name, downloadPath string, restore bool) string {
+	return fmt.Sprintf(`
+        resource "vtm_backups_full" "test_vtm_backups_full" {
+			name = "%s"
+			description = "TEST_TEXT"
+			download_path = "%s"
+        }
+
+        resource "vtm_backups_full" "test_vtm_backups_full_copy" {
+			name = "%s_copy"
+			upload_path = "${vtm_backups_full.test_vtm_backups_full.download_path}"
+			restore = %t
+        }`,
+		name, downloadPath, name, restore,
+	)
+}
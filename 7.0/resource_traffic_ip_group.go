@@ -223,6 +223,8 @@ func resourceTrafficIpGroupRead(d *schema.ResourceData, tm interface{}) (readErr
 		return fmt.Errorf("Failed to read vtm_traffic_ip_group '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -302,9 +304,12 @@ func resourceTrafficIpGroupCreate(d *schema.ResourceData, tm interface{}) error
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewTrafficIpGroup(objectName)
 	resourceTrafficIpGroupObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceTrafficIpGroupSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_traffic_ip_group '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_traffic_ip_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -318,9 +323,12 @@ func resourceTrafficIpGroupUpdate(d *schema.ResourceData, tm interface{}) error
 		return fmt.Errorf("Failed to update vtm_traffic_ip_group '%v': %v", objectName, err)
 	}
 	resourceTrafficIpGroupObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceTrafficIpGroupSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_traffic_ip_group '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_traffic_ip_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -793,8 +793,10 @@ func getResourceTrafficManagerSchema() map[string]*schema.Schema {
 		// The authentication password. Required (minimum length 8 characters)
 		//  if "security_level" includes authentication.
 		"snmp_auth_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The IP address the SNMP service should bind its listen port to.
@@ -808,9 +810,11 @@ func getResourceTrafficManagerSchema() map[string]*schema.Schema {
 		// The community string required for SNMPv1 and SNMPv2c commands.
 		//   (If empty, all SNMPv1 and SNMPv2c commands will be rejected).
 		"snmp_community": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  "public",
+			Type:      schema.TypeString,
+			Optional:  true,
+			Default:   "public",
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// Whether or not the SNMP command responder service should be enabled
@@ -841,8 +845,10 @@ func getResourceTrafficManagerSchema() map[string]*schema.Schema {
 		// The privacy password. Required (minimum length 8 characters)
 		//  if "security_level" includes privacy (message encryption).
 		"snmp_priv_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The security level for SNMPv3 communications.
@@ -877,6 +883,8 @@ func resourceTrafficManagerRead(d *schema.ResourceData, tm interface{}) (readErr
 		return fmt.Errorf("Failed to read vtm_traffic_manager '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -1146,11 +1154,11 @@ func resourceTrafficManagerRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "snmp_allow"
 	d.Set("snmp_allow", []string(*object.Snmp.Allow))
 	lastAssignedField = "snmp_auth_password"
-	d.Set("snmp_auth_password", string(*object.Snmp.AuthPassword))
+	d.Set("snmp_auth_password", getSecretFingerprint(d, "snmp_auth_password", string(*object.Snmp.AuthPassword)))
 	lastAssignedField = "snmp_bind_ip"
 	d.Set("snmp_bind_ip", string(*object.Snmp.BindIp))
 	lastAssignedField = "snmp_community"
-	d.Set("snmp_community", string(*object.Snmp.Community))
+	d.Set("snmp_community", getSecretFingerprint(d, "snmp_community", string(*object.Snmp.Community)))
 	lastAssignedField = "snmp_enabled"
 	d.Set("snmp_enabled", bool(*object.Snmp.Enabled))
 	lastAssignedField = "snmp_hash_algorithm"
@@ -1158,7 +1166,7 @@ func resourceTrafficManagerRead(d *schema.ResourceData, tm interface{}) (readErr
 	lastAssignedField = "snmp_port"
 	d.Set("snmp_port", string(*object.Snmp.Port))
 	lastAssignedField = "snmp_priv_password"
-	d.Set("snmp_priv_password", string(*object.Snmp.PrivPassword))
+	d.Set("snmp_priv_password", getSecretFingerprint(d, "snmp_priv_password", string(*object.Snmp.PrivPassword)))
 	lastAssignedField = "snmp_security_level"
 	d.Set("snmp_security_level", string(*object.Snmp.SecurityLevel))
 	lastAssignedField = "snmp_username"
@@ -1174,9 +1182,12 @@ func resourceTrafficManagerUpdate(d *schema.ResourceData, tm interface{}) error
 		return fmt.Errorf("Failed to update vtm_traffic_manager '%v': %v", objectName, err)
 	}
 	resourceTrafficManagerObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceTrafficManagerSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_traffic_manager '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_traffic_manager '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -1415,13 +1426,13 @@ func resourceTrafficManagerObjectFieldAssignments(d *schema.ResourceData, object
 		object.Snmp.Allow = &[]string{"all"}
 		d.Set("snmp_allow", []string(*object.Snmp.Allow))
 	}
-	setString(&object.Snmp.AuthPassword, d, "snmp_auth_password")
+	setSecret(&object.Snmp.AuthPassword, d, "snmp_auth_password")
 	setString(&object.Snmp.BindIp, d, "snmp_bind_ip")
-	setString(&object.Snmp.Community, d, "snmp_community")
+	setSecret(&object.Snmp.Community, d, "snmp_community")
 	setBool(&object.Snmp.Enabled, d, "snmp_enabled")
 	setString(&object.Snmp.HashAlgorithm, d, "snmp_hash_algorithm")
 	setString(&object.Snmp.Port, d, "snmp_port")
-	setString(&object.Snmp.PrivPassword, d, "snmp_priv_password")
+	setSecret(&object.Snmp.PrivPassword, d, "snmp_priv_password")
 	setString(&object.Snmp.SecurityLevel, d, "snmp_security_level")
 	setString(&object.Snmp.Username, d, "snmp_username")
 }
@@ -156,8 +156,10 @@ func getResourceUserAuthenticatorSchema() map[string]*schema.Schema {
 		// If binding to the LDAP server using "search_dn" requires a password,
 		//  enter it here.
 		"ldap_search_password": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The IP or hostname of the LDAP server.
@@ -224,8 +226,10 @@ func getResourceUserAuthenticatorSchema() map[string]*schema.Schema {
 
 		// Secret key shared with the RADIUS server.
 		"radius_secret": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The IP or hostname of the RADIUS server.
@@ -283,8 +287,10 @@ func getResourceUserAuthenticatorSchema() map[string]*schema.Schema {
 
 		// Secret key shared with the TACACS+ server.
 		"tacacs_plus_secret": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			StateFunc: hashSecretState,
 		},
 
 		// The IP or hostname of the TACACS+ server.
@@ -318,6 +324,8 @@ func resourceUserAuthenticatorRead(d *schema.ResourceData, tm interface{}) (read
 		return fmt.Errorf("Failed to read vtm_user_authenticator '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -354,7 +362,7 @@ func resourceUserAuthenticatorRead(d *schema.ResourceData, tm interface{}) (read
 	lastAssignedField = "ldap_search_dn"
 	d.Set("ldap_search_dn", string(*object.Ldap.SearchDn))
 	lastAssignedField = "ldap_search_password"
-	d.Set("ldap_search_password", string(*object.Ldap.SearchPassword))
+	d.Set("ldap_search_password", getSecretFingerprint(d, "ldap_search_password", string(*object.Ldap.SearchPassword)))
 	lastAssignedField = "ldap_server"
 	d.Set("ldap_server", string(*object.Ldap.Server))
 	lastAssignedField = "ldap_timeout"
@@ -372,7 +380,7 @@ func resourceUserAuthenticatorRead(d *schema.ResourceData, tm interface{}) (read
 	lastAssignedField = "radius_port"
 	d.Set("radius_port", int(*object.Radius.Port))
 	lastAssignedField = "radius_secret"
-	d.Set("radius_secret", string(*object.Radius.Secret))
+	d.Set("radius_secret", getSecretFingerprint(d, "radius_secret", string(*object.Radius.Secret)))
 	lastAssignedField = "radius_server"
 	d.Set("radius_server", string(*object.Radius.Server))
 	lastAssignedField = "radius_timeout"
@@ -388,7 +396,7 @@ func resourceUserAuthenticatorRead(d *schema.ResourceData, tm interface{}) (read
 	lastAssignedField = "tacacs_plus_port"
 	d.Set("tacacs_plus_port", int(*object.TacacsPlus.Port))
 	lastAssignedField = "tacacs_plus_secret"
-	d.Set("tacacs_plus_secret", string(*object.TacacsPlus.Secret))
+	d.Set("tacacs_plus_secret", getSecretFingerprint(d, "tacacs_plus_secret", string(*object.TacacsPlus.Secret)))
 	lastAssignedField = "tacacs_plus_server"
 	d.Set("tacacs_plus_server", string(*object.TacacsPlus.Server))
 	lastAssignedField = "tacacs_plus_timeout"
@@ -416,9 +424,12 @@ func resourceUserAuthenticatorCreate(d *schema.ResourceData, tm interface{}) err
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewUserAuthenticator(objectName, d.Get("type").(string))
 	resourceUserAuthenticatorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceUserAuthenticatorSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_user_authenticator '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_user_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -432,9 +443,12 @@ func resourceUserAuthenticatorUpdate(d *schema.ResourceData, tm interface{}) err
 		return fmt.Errorf("Failed to update vtm_user_authenticator '%v': %v", objectName, err)
 	}
 	resourceUserAuthenticatorObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceUserAuthenticatorSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_user_authenticator '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_user_authenticator '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -455,7 +469,7 @@ func resourceUserAuthenticatorObjectFieldAssignments(d *schema.ResourceData, obj
 	setString(&object.Ldap.GroupFilter, d, "ldap_group_filter")
 	setInt(&object.Ldap.Port, d, "ldap_port")
 	setString(&object.Ldap.SearchDn, d, "ldap_search_dn")
-	setString(&object.Ldap.SearchPassword, d, "ldap_search_password")
+	setSecret(&object.Ldap.SearchPassword, d, "ldap_search_password")
 	setString(&object.Ldap.Server, d, "ldap_server")
 	setInt(&object.Ldap.Timeout, d, "ldap_timeout")
 	setString(&object.Radius.FallbackGroup, d, "radius_fallback_group")
@@ -464,7 +478,7 @@ func resourceUserAuthenticatorObjectFieldAssignments(d *schema.ResourceData, obj
 	setString(&object.Radius.NasIdentifier, d, "radius_nas_identifier")
 	setString(&object.Radius.NasIpAddress, d, "radius_nas_ip_address")
 	setInt(&object.Radius.Port, d, "radius_port")
-	setString(&object.Radius.Secret, d, "radius_secret")
+	setSecret(&object.Radius.Secret, d, "radius_secret")
 	setString(&object.Radius.Server, d, "radius_server")
 	setInt(&object.Radius.Timeout, d, "radius_timeout")
 	setString(&object.TacacsPlus.AuthType, d, "tacacs_plus_auth_type")
@@ -472,7 +486,7 @@ func resourceUserAuthenticatorObjectFieldAssignments(d *schema.ResourceData, obj
 	setString(&object.TacacsPlus.GroupField, d, "tacacs_plus_group_field")
 	setString(&object.TacacsPlus.GroupService, d, "tacacs_plus_group_service")
 	setInt(&object.TacacsPlus.Port, d, "tacacs_plus_port")
-	setString(&object.TacacsPlus.Secret, d, "tacacs_plus_secret")
+	setSecret(&object.TacacsPlus.Secret, d, "tacacs_plus_secret")
 	setString(&object.TacacsPlus.Server, d, "tacacs_plus_server")
 	setInt(&object.TacacsPlus.Timeout, d, "tacacs_plus_timeout")
 }
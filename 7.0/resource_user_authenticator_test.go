@@ -6,6 +6,9 @@ package main
 /*
  * This test covers the following cases:
  *   - Creation and deletion of a vtm_user_authenticator object with minimal configuration
+ *   - Secrets are sent to the vTM but only their hashes are kept in the state
+ *   - A secret masked by the vTM does not show up in the plan
+ *   - A secret changed outside Terraform shows up in the plan and is put back on apply
  */
 
 import (
@@ -21,7 +24,7 @@ import (
 func TestResourceUserAuthenticator(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestUserAuthenticator")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckUserAuthenticatorDestroy,
@@ -32,6 +35,30 @@ func TestResourceUserAuthenticator(t *testing.T) {
 					testAccCheckUserAuthenticatorExists,
 				),
 			},
+			{
+				Config: getSecretUserAuthenticatorConfig(objName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vtm_user_authenticator.test_vtm_user_authenticator", "radius_secret", hashSecret("TEST_SECRET")),
+					testAccCheckUserAuthenticatorRadiusSecret(objName, "TEST_SECRET"),
+				),
+			},
+			{
+				PreConfig: func() { setUserAuthenticatorRadiusSecret(t, objName, "********") },
+				Config:    getSecretUserAuthenticatorConfig(objName),
+				PlanOnly:  true,
+			},
+			{
+				PreConfig:          func() { setUserAuthenticatorRadiusSecret(t, objName, "ROTATED_SECRET") },
+				Config:             getSecretUserAuthenticatorConfig(objName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: getSecretUserAuthenticatorConfig(objName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserAuthenticatorRadiusSecret(objName, "TEST_SECRET"),
+				),
+			},
 		},
 	})
 }
@@ -66,6 +93,32 @@ func testAccCheckUserAuthenticatorDestroy(s *terraform.State) error {
 	return nil
 }
 
+func testAccCheckUserAuthenticatorRadiusSecret(objectName, secret string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		object, err := tm.GetUserAuthenticator(objectName)
+		if err != nil {
+			return fmt.Errorf("UserAuthenticator %s does not exist: %#v", objectName, err)
+		}
+		if *object.Radius.Secret != secret {
+			return fmt.Errorf("UserAuthenticator %s has the wrong RADIUS secret", objectName)
+		}
+		return nil
+	}
+}
+
+func setUserAuthenticatorRadiusSecret(t *testing.T, objectName, secret string) {
+	tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+	object, err := tm.GetUserAuthenticator(objectName)
+	if err != nil {
+		t.Fatalf("UserAuthenticator %s does not exist: %#v", objectName, err)
+	}
+	object.Radius.Secret = getStringAddr(secret)
+	if _, err := object.Apply(); err != nil {
+		t.Fatalf("Failed to update UserAuthenticator %s: %#v", objectName, err)
+	}
+}
+
 func getBasicUserAuthenticatorConfig(name string) string {
 	return fmt.Sprintf(`
         resource "vtm_user_authenticator" "test_vtm_user_authenticator" {
@@ -76,3 +129,15 @@ func getBasicUserAuthenticatorConfig(name string) string {
 		name,
 	)
 }
+
+func getSecretUserAuthenticatorConfig(name string) string {
+	return fmt.Sprintf(`
+        resource "vtm_user_authenticator" "test_vtm_user_authenticator" {
+			name = "%s"
+			type = "radius"
+			radius_secret = "TEST_SECRET"
+
+        }`,
+		name,
+	)
+}
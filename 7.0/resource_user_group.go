@@ -111,6 +111,8 @@ func resourceUserGroupRead(d *schema.ResourceData, tm interface{}) (readError er
 		return fmt.Errorf("Failed to read vtm_user_group '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -164,9 +166,12 @@ func resourceUserGroupCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewUserGroup(objectName)
 	resourceUserGroupObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceUserGroupSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_user_group '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_user_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -180,9 +185,12 @@ func resourceUserGroupUpdate(d *schema.ResourceData, tm interface{}) error {
 		return fmt.Errorf("Failed to update vtm_user_group '%v': %v", objectName, err)
 	}
 	resourceUserGroupObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceUserGroupSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_user_group '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_user_group '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
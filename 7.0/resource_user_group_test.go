@@ -21,7 +21,7 @@ import (
 func TestResourceUserGroup(t *testing.T) {
 	objName := acctest.RandomWithPrefix("TestUserGroup")
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckUserGroupDestroy,
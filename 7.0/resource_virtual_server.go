@@ -1694,6 +1694,8 @@ func resourceVirtualServerRead(d *schema.ResourceData, tm interface{}) (readErro
 		return fmt.Errorf("Failed to read vtm_virtual_server '%v': %v", objectName, err.ErrorText)
 	}
 
+	fillUnsupportedFields(d, tm, object)
+
 	var lastAssignedField string
 
 	defer func() {
@@ -2123,9 +2125,12 @@ func resourceVirtualServerCreate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
 	object := tm.(*vtm.VirtualTrafficManager).NewVirtualServer(objectName, d.Get("pool").(string), d.Get("port").(int))
 	resourceVirtualServerObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceVirtualServerSchema()); err != nil {
+		return fmt.Errorf("Error creating vtm_virtual_server '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error creating vtm_virtual_server '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -2134,14 +2139,18 @@ func resourceVirtualServerCreate(d *schema.ResourceData, tm interface{}) error {
 
 func resourceVirtualServerUpdate(d *schema.ResourceData, tm interface{}) error {
 	objectName := d.Get("name").(string)
+	defer lockVirtualServer(objectName).Unlock()
 	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(objectName)
 	if err != nil {
 		return fmt.Errorf("Failed to update vtm_virtual_server '%v': %v", objectName, err)
 	}
 	resourceVirtualServerObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResourceVirtualServerSchema()); err != nil {
+		return fmt.Errorf("Error updating vtm_virtual_server '%s': %v", objectName, err)
+	}
 	_, applyErr := object.Apply()
 	if applyErr != nil {
-		info := formatErrorInfo(applyErr.ErrorInfo.(map[string]interface{}))
+		info := formatErrorInfo(applyErr.ErrorInfo)
 		return fmt.Errorf("Error updating vtm_virtual_server '%s': %s %s", objectName, applyErr.ErrorText, info)
 	}
 	d.SetId(objectName)
@@ -0,0 +1,260 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtm_virtual_server_ssl_host_mapping manages the SNI certificates of a single
+ * host name in the ssl_server_cert_host_mapping table of a virtual server,
+ * leaving the rest of the table, and its order, alone. This allows the owner
+ * of each host name to manage its certificates without editing the shared
+ * virtual server; the vtm_virtual_server resource should then ignore changes
+ * to its ssl_server_cert_host_mapping attribute.
+ */
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// Each change to a host mapping, or other entry in a virtual server's tables and lists, rewrites the
+// whole virtual server, so changes made by read-modify-write to one virtual server, including updates
+// by vtm_virtual_server itself, are serialised.
+var virtualServerLocks = struct {
+	sync.Mutex
+	virtualServers map[string]*sync.Mutex
+}{virtualServers: make(map[string]*sync.Mutex)}
+
+func lockVirtualServer(virtualServerName string) *sync.Mutex {
+	virtualServerLocks.Lock()
+	virtualServerLock, ok := virtualServerLocks.virtualServers[virtualServerName]
+	if !ok {
+		virtualServerLock = new(sync.Mutex)
+		virtualServerLocks.virtualServers[virtualServerName] = virtualServerLock
+	}
+	virtualServerLocks.Unlock()
+	virtualServerLock.Lock()
+	return virtualServerLock
+}
+
+func resourceVirtualServerSslHostMapping() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourceVirtualServerSslHostMappingRead,
+		Exists: resourceVirtualServerSslHostMappingExists,
+		Create: resourceVirtualServerSslHostMappingCreate,
+		Update: resourceVirtualServerSslHostMappingUpdate,
+		Delete: resourceVirtualServerSslHostMappingDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceVirtualServerSslHostMappingImport,
+		},
+
+		Schema: getResourceVirtualServerSslHostMappingSchema(),
+	}
+}
+
+func getResourceVirtualServerSslHostMappingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+
+		// The virtual server the host mapping belongs to.
+		"virtual_server": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// Host which this entry refers to.
+		"host": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// The SSL server certificate for the host.
+		"certificate": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+
+		// Further SSL server certificates for the host, such as for other
+		//  key types, in order of preference.
+		"alt_certificates": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func getVirtualServerSslHostMappingId(virtualServerName, host string) string {
+	return virtualServerName + "/" + host
+}
+
+// Host names never contain a slash, so the ID is split at the last one.
+func parseVirtualServerSslHostMappingId(id string) (virtualServerName, host string, err error) {
+	separator := strings.LastIndex(id, "/")
+	if separator <= 0 || separator == len(id)-1 {
+		return "", "", fmt.Errorf("Invalid vtm_virtual_server_ssl_host_mapping ID '%s': expected <virtual_server>/<host>", id)
+	}
+	return id[:separator], id[separator+1:], nil
+}
+
+func findVirtualServerSslHostMapping(object *vtm.VirtualServer, host string) int {
+	if object.Ssl.ServerCertHostMapping == nil {
+		return -1
+	}
+	for i, item := range *object.Ssl.ServerCertHostMapping {
+		if item.Host != nil && *item.Host == host {
+			return i
+		}
+	}
+	return -1
+}
+
+func resourceVirtualServerSslHostMappingImport(d *schema.ResourceData, tm interface{}) ([]*schema.ResourceData, error) {
+	virtualServerName, host, err := parseVirtualServerSslHostMappingId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("virtual_server", virtualServerName)
+	d.Set("host", host)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVirtualServerSslHostMappingRead(d *schema.ResourceData, tm interface{}) error {
+	virtualServerName := d.Get("virtual_server").(string)
+	host := d.Get("host").(string)
+	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_virtual_server_ssl_host_mapping '%v': %v", d.Id(), err.ErrorText)
+	}
+	index := findVirtualServerSslHostMapping(object, host)
+	if index < 0 {
+		d.SetId("")
+		return nil
+	}
+	item := (*object.Ssl.ServerCertHostMapping)[index]
+	certificate, altCertificates := "", []string{}
+	if item.Certificate != nil {
+		certificate = *item.Certificate
+	}
+	if item.AltCertificates != nil {
+		altCertificates = *item.AltCertificates
+	}
+	d.Set("certificate", certificate)
+	d.Set("alt_certificates", altCertificates)
+	return nil
+}
+
+func resourceVirtualServerSslHostMappingExists(d *schema.ResourceData, tm interface{}) (bool, error) {
+	virtualServerName, host, idErr := parseVirtualServerSslHostMappingId(d.Id())
+	if idErr != nil {
+		return false, idErr
+	}
+	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			return false, nil
+		}
+		return false, fmt.Errorf("%v", err.ErrorText)
+	}
+	return findVirtualServerSslHostMapping(object, host) >= 0, nil
+}
+
+func resourceVirtualServerSslHostMappingCreate(d *schema.ResourceData, tm interface{}) error {
+	virtualServerName := d.Get("virtual_server").(string)
+	host := d.Get("host").(string)
+	id := getVirtualServerSslHostMappingId(virtualServerName, host)
+	defer lockVirtualServer(virtualServerName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+	if err != nil {
+		return fmt.Errorf("Error creating vtm_virtual_server_ssl_host_mapping '%s': %v", id, err)
+	}
+	if findVirtualServerSslHostMapping(object, host) >= 0 {
+		return fmt.Errorf("Error creating vtm_virtual_server_ssl_host_mapping '%s': host already has a mapping in virtual server '%s', import it to manage it", id, virtualServerName)
+	}
+	if object.Ssl.ServerCertHostMapping == nil {
+		object.Ssl.ServerCertHostMapping = &vtm.VirtualServerServerCertHostMappingTable{}
+	}
+	item := vtm.VirtualServerServerCertHostMapping{Host: getStringAddr(host)}
+	resourceVirtualServerSslHostMappingObjectFieldAssignments(d, &item)
+	*object.Ssl.ServerCertHostMapping = append(*object.Ssl.ServerCertHostMapping, item)
+	if err := applyVirtualServer(tm, object); err != nil {
+		return fmt.Errorf("Error creating vtm_virtual_server_ssl_host_mapping '%s': %v", id, err)
+	}
+	d.SetId(id)
+	return nil
+}
+
+func resourceVirtualServerSslHostMappingUpdate(d *schema.ResourceData, tm interface{}) error {
+	virtualServerName := d.Get("virtual_server").(string)
+	host := d.Get("host").(string)
+	defer lockVirtualServer(virtualServerName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+	if err != nil {
+		return fmt.Errorf("Failed to update vtm_virtual_server_ssl_host_mapping '%v': %v", d.Id(), err)
+	}
+	index := findVirtualServerSslHostMapping(object, host)
+	if index < 0 {
+		return fmt.Errorf("Failed to update vtm_virtual_server_ssl_host_mapping '%v': host no longer has a mapping in virtual server '%s'", d.Id(), virtualServerName)
+	}
+	resourceVirtualServerSslHostMappingObjectFieldAssignments(d, &(*object.Ssl.ServerCertHostMapping)[index])
+	if err := applyVirtualServer(tm, object); err != nil {
+		return fmt.Errorf("Error updating vtm_virtual_server_ssl_host_mapping '%s': %v", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceVirtualServerSslHostMappingDelete(d *schema.ResourceData, tm interface{}) error {
+	virtualServerName := d.Get("virtual_server").(string)
+	host := d.Get("host").(string)
+	defer lockVirtualServer(virtualServerName).Unlock()
+	object, err := tm.(*vtm.VirtualTrafficManager).GetVirtualServer(virtualServerName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to delete vtm_virtual_server_ssl_host_mapping '%v': %v", d.Id(), err.ErrorText)
+	}
+	if index := findVirtualServerSslHostMapping(object, host); index >= 0 {
+		mappingTable := *object.Ssl.ServerCertHostMapping
+		*object.Ssl.ServerCertHostMapping = append(mappingTable[:index], mappingTable[index+1:]...)
+		if err := applyVirtualServer(tm, object); err != nil {
+			return fmt.Errorf("Failed to delete vtm_virtual_server_ssl_host_mapping '%v': %v", d.Id(), err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceVirtualServerSslHostMappingObjectFieldAssignments(d *schema.ResourceData, item *vtm.VirtualServerServerCertHostMapping) {
+	setString(&item.Certificate, d, "certificate")
+	setStringList(&item.AltCertificates, d, "alt_certificates")
+}
+
+// applyVirtualServer writes a virtual server back to the vTM after a read-modify-write of one of
+// its tables or lists, made while holding lockVirtualServer.
+func applyVirtualServer(tm interface{}, object *vtm.VirtualServer) error {
+	if err := stripUnsupportedFields(tm, object, getResourceVirtualServerSchema()); err != nil {
+		return err
+	}
+	_, applyErr := object.Apply()
+	if applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("%s %s", applyErr.ErrorText, info)
+	}
+	return nil
+}
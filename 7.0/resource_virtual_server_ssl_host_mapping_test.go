@@ -0,0 +1,207 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Adding host mappings to a virtual server, alongside a mapping added to it elsewhere
+ *   - Updating the certificates of a host in place, keeping the order of the table
+ *   - Importing a host mapping by virtual_server/host
+ *   - Removing a host mapping leaves the rest of the table untouched
+ *   - A certificate which does not exist is refused with strict_references
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func TestResourceVirtualServerSslHostMapping(t *testing.T) {
+	vsName := acctest.RandomWithPrefix("TestSslHostMapping")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVirtualServerSslHostMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getBasicVirtualServerSslHostMappingConfig(vsName, "b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVirtualServerSslHostMappingExists,
+					testAccCheckVirtualServerSslHostMappingHosts(vsName, "shared.example.com", "a.example.com", "b.example.com"),
+					resource.TestCheckResourceAttr("vtm_virtual_server_ssl_host_mapping.host_a", "id", vsName+"/a.example.com"),
+					resource.TestCheckResourceAttr("vtm_virtual_server_ssl_host_mapping.host_a", "certificate", vsName+"-a"),
+					resource.TestCheckResourceAttr("vtm_virtual_server_ssl_host_mapping.host_b", "alt_certificates.#", "1"),
+				),
+			},
+			{
+				Config: getBasicVirtualServerSslHostMappingConfig(vsName, "a"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVirtualServerSslHostMappingHosts(vsName, "shared.example.com", "a.example.com", "b.example.com"),
+					resource.TestCheckResourceAttr("vtm_virtual_server_ssl_host_mapping.host_b", "alt_certificates.0", vsName+"-a"),
+				),
+			},
+			{
+				ResourceName:      "vtm_virtual_server_ssl_host_mapping.host_b",
+				ImportState:       true,
+				ImportStateId:     vsName + "/b.example.com",
+				ImportStateVerify: true,
+			},
+			{
+				Config: getSingleVirtualServerSslHostMappingConfig(vsName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVirtualServerSslHostMappingHosts(vsName, "shared.example.com", "b.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceVirtualServerSslHostMappingStrictReferences(t *testing.T) {
+	vsName := acctest.RandomWithPrefix("TestSslHostMapping")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVirtualServerSslHostMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "vtm" {
+						strict_references = true
+					}
+					` + getVirtualServerSslHostMappingServerConfig(vsName) + fmt.Sprintf(`
+					resource "vtm_virtual_server_ssl_host_mapping" "host_a" {
+						virtual_server = "${vtm_virtual_server.test_vtm_virtual_server.name}"
+						host = "a.example.com"
+						certificate = "%s-missing"
+					}`, vsName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("vtm_virtual_server_ssl_host_mapping '%s/a.example.com' refers to objects which do not exist.*\n.*certificate: vtm_ssl_server_key '%s-missing'", vsName, vsName)),
+			},
+		},
+	})
+}
+
+func testAccCheckVirtualServerSslHostMappingExists(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_virtual_server_ssl_host_mapping" {
+			continue
+		}
+		vsName := tfResource.Primary.Attributes["virtual_server"]
+		host := tfResource.Primary.Attributes["host"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		virtualServer, err := tm.GetVirtualServer(vsName)
+		if err != nil {
+			return fmt.Errorf("VirtualServer %s does not exist: %#v", vsName, err)
+		}
+		if findVirtualServerSslHostMapping(virtualServer, host) < 0 {
+			return fmt.Errorf("Host %s has no mapping in virtual server %s", host, vsName)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVirtualServerSslHostMappingDestroy(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_virtual_server" {
+			continue
+		}
+		objectName := tfResource.Primary.Attributes["name"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.GetVirtualServer(objectName); err == nil {
+			return fmt.Errorf("VirtualServer %s still exists", objectName)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVirtualServerSslHostMappingHosts(vsName string, hosts ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		virtualServer, err := tm.GetVirtualServer(vsName)
+		if err != nil {
+			return fmt.Errorf("VirtualServer %s does not exist: %#v", vsName, err)
+		}
+		found := []string{}
+		for _, item := range *virtualServer.Ssl.ServerCertHostMapping {
+			found = append(found, *item.Host)
+		}
+		if strings.Join(found, ",") != strings.Join(hosts, ",") {
+			return fmt.Errorf("VirtualServer %s has host mappings for %v, expected %v", vsName, found, hosts)
+		}
+		return nil
+	}
+}
+
+func getVirtualServerSslHostMappingServerConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "vtm_virtual_server" "test_vtm_virtual_server" {
+			name = "%s"
+			pool = "discard"
+			port = 443
+			ssl_server_cert_host_mapping {
+				host = "shared.example.com"
+				certificate = "${vtm_ssl_server_key.a.name}"
+			}
+			lifecycle {
+				ignore_changes = ["ssl_server_cert_host_mapping"]
+			}
+		}
+
+		resource "vtm_ssl_server_key" "a" {
+			name = "%s-a"
+			note = "TEST_TEXT"
+			private = "TEST_TEXT"
+			public = "TEST_TEXT"
+			request = "TEST_TEXT"
+		}
+
+		resource "vtm_ssl_server_key" "b" {
+			name = "%s-b"
+			note = "TEST_TEXT"
+			private = "TEST_TEXT"
+			public = "TEST_TEXT"
+			request = "TEST_TEXT"
+		}`,
+		name, name, name,
+	)
+}
+
+func getBasicVirtualServerSslHostMappingConfig(name, altCertificate string) string {
+	return getVirtualServerSslHostMappingServerConfig(name) + fmt.Sprintf(`
+		resource "vtm_virtual_server_ssl_host_mapping" "host_a" {
+			virtual_server = "${vtm_virtual_server.test_vtm_virtual_server.name}"
+			host = "a.example.com"
+			certificate = "${vtm_ssl_server_key.a.name}"
+		}
+
+		resource "vtm_virtual_server_ssl_host_mapping" "host_b" {
+			virtual_server = "${vtm_virtual_server.test_vtm_virtual_server.name}"
+			host = "b.example.com"
+			certificate = "${vtm_ssl_server_key.b.name}"
+			alt_certificates = ["${vtm_ssl_server_key.%s.name}"]
+			depends_on = ["vtm_virtual_server_ssl_host_mapping.host_a"]
+		}`,
+		altCertificate,
+	)
+}
+
+func getSingleVirtualServerSslHostMappingConfig(name string) string {
+	return getVirtualServerSslHostMappingServerConfig(name) + `
+		resource "vtm_virtual_server_ssl_host_mapping" "host_b" {
+			virtual_server = "${vtm_virtual_server.test_vtm_virtual_server.name}"
+			host = "b.example.com"
+			certificate = "${vtm_ssl_server_key.b.name}"
+			alt_certificates = ["${vtm_ssl_server_key.a.name}"]
+		}`
+}
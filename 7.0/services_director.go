@@ -0,0 +1,123 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * The services_director provider block connects to vTM instances through the
+ * Services Director REST API proxy, instead of to a vTM at base_url. The
+ * instance is named by its ID, or found by its tag or host name, and the
+ * provider's username and password are those of a Services Director user.
+ *
+ * With fan_out, every instance matching the tag or host name is configured,
+ * in order of ID, in the same way as the endpoints block: see endpoints.go.
+ */
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+func servicesDirectorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Connect to vTM instances through the Services Director REST API proxy instead of base_url",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Base URL of the Services Director REST API: 'https://sd:8100/api'",
+				},
+				"api_version": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Services Director REST API version, such as '2.10'",
+				},
+				"instance_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "ID of the instance to configure",
+				},
+				"instance_tag": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Tag of the instance to configure, or of the instances with fan_out",
+				},
+				"instance_hostname": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Management address or REST host name of the instance to configure",
+				},
+				"fan_out": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Configure every instance matching instance_tag and instance_hostname, rather than requiring exactly one",
+				},
+			},
+		},
+	}
+}
+
+/*
+connectServicesDirector resolves the instances selected by a services_director block and connects
+to them through Services Director. It returns the VirtualTrafficManager and the URL of the first
+instance, for error messages.
+*/
+func connectServicesDirector(settings map[string]interface{}, username, password string, verifySslCert bool, options vtm.ConnectionOptions) (*vtm.VirtualTrafficManager, string, error) {
+	url := settings["url"].(string)
+	instanceId := settings["instance_id"].(string)
+	instanceTag := settings["instance_tag"].(string)
+	instanceHostname := settings["instance_hostname"].(string)
+	fanOut := settings["fan_out"].(bool)
+
+	if instanceId != "" && (instanceTag != "" || instanceHostname != "") {
+		return nil, "", fmt.Errorf("services_director: instance_id cannot be combined with instance_tag or instance_hostname")
+	}
+	if instanceId == "" && instanceTag == "" && instanceHostname == "" {
+		return nil, "", fmt.Errorf("services_director: one of instance_id, instance_tag or instance_hostname must be set")
+	}
+
+	sd, sdErr := vtm.NewServicesDirector(url, settings["api_version"].(string), username, password, verifySslCert, true, options)
+	if sdErr != nil {
+		return nil, "", fmt.Errorf("Failed to connect to Services Director at '%v': %v", url, sdErr.ErrorText)
+	}
+	instanceIds := []string{instanceId}
+	if instanceId == "" {
+		ids, findErr := sd.FindInstances(instanceTag, instanceHostname)
+		if findErr != nil {
+			return nil, "", fmt.Errorf("Failed to list instances of Services Director at '%v': %v", url, findErr.ErrorText)
+		}
+		switch {
+		case len(ids) == 0:
+			return nil, "", fmt.Errorf("Services Director at '%v' has no instance %s", url, describeInstanceSelection(instanceTag, instanceHostname))
+		case len(ids) > 1 && fanOut == false:
+			return nil, "", fmt.Errorf("Services Director at '%v' has %d instances %s (%s); set fan_out to configure them all", url, len(ids), describeInstanceSelection(instanceTag, instanceHostname), strings.Join(ids, ", "))
+		}
+		instanceIds = ids
+	}
+
+	instanceUrl := fmt.Sprintf("%s/tmcm/%s/instance/%s", strings.TrimSuffix(url, "/"), settings["api_version"].(string), instanceIds[0])
+	tm, contactable, contactErr := sd.NewVirtualTrafficManager(instanceIds)
+	if contactable != true {
+		return nil, "", fmt.Errorf("Failed to connect to Virtual Traffic Manager through Services Director at '%v': %v", url, contactErr.ErrorText)
+	}
+	return tm, instanceUrl, nil
+}
+
+func describeInstanceSelection(tag, hostname string) string {
+	conditions := []string{}
+	if tag != "" {
+		conditions = append(conditions, fmt.Sprintf("tagged '%s'", tag))
+	}
+	if hostname != "" {
+		conditions = append(conditions, fmt.Sprintf("with host name '%s'", hostname))
+	}
+	return strings.Join(conditions, " and ")
+}
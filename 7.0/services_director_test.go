@@ -0,0 +1,187 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+	"github.com/pulse-vadc/terraform-provider-vtm/internal/fakevtm"
+)
+
+const (
+	fakeSdUsername   = "sdadmin"
+	fakeSdPassword   = "sdpassword"
+	fakeSdApiVersion = "2.10"
+)
+
+type fakeSdInstance struct {
+	vtm               *fakeVtm
+	tag               string
+	managementAddress string
+}
+
+/*
+newFakeServicesDirector starts a fake Services Director which proxies requests for each instance to
+its own fake vTM. An instance without a fake vTM is unreachable.
+*/
+func newFakeServicesDirector(t *testing.T, instances map[string]*fakeSdInstance) *httptest.Server {
+	prefix := "/api/tmcm/" + fakeSdApiVersion + "/instance"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); ok != true || username != fakeSdUsername || password != fakeSdPassword {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == prefix {
+			ids := []string{}
+			for id := range instances {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			fakevtm.WriteChildren(w, prefix+"/", ids)
+			return
+		}
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, prefix+"/"), "/", 2)
+		instance, ok := instances[parts[0]]
+		if strings.HasPrefix(r.URL.Path, prefix+"/") == false || ok != true {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		if len(parts) == 1 {
+			json.NewEncoder(w).Encode(map[string]string{
+				"tag":                instance.tag,
+				"management_address": instance.managementAddress,
+				"rest_address":       instance.managementAddress + ":9070",
+				"status":             "Active",
+			})
+			return
+		}
+		if instance.vtm == nil {
+			http.Error(w, "<html>Bad Gateway</html>", http.StatusBadGateway)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		request, _ := http.NewRequest(r.Method, instance.vtm.URL()+"/api/"+parts[1], bytes.NewReader(body))
+		request.Header = r.Header.Clone()
+		request.SetBasicAuth(fakeVtmUsername, fakeVtmPassword)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+		for key, values := range response.Header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(response.StatusCode)
+		io.Copy(w, response.Body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func configureTestServicesDirector(sdUrl string, settings map[string]interface{}) (*vtm.VirtualTrafficManager, error) {
+	sdSettings := map[string]interface{}{
+		"url":         sdUrl + "/api",
+		"api_version": fakeSdApiVersion,
+	}
+	for key, value := range settings {
+		sdSettings[key] = value
+	}
+	provider, err := configureTestProvider(map[string]interface{}{
+		"username":          fakeSdUsername,
+		"password":          fakeSdPassword,
+		"max_retries":       0,
+		"services_director": []interface{}{sdSettings},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return provider.Meta().(*vtm.VirtualTrafficManager), nil
+}
+
+func TestServicesDirectorInstanceSelection(t *testing.T) {
+	sd := newFakeServicesDirector(t, map[string]*fakeSdInstance{
+		"vtm-a": {vtm: newFakeVtm(), tag: "web", managementAddress: "vtm-a.example.com"},
+		"vtm-b": {vtm: newFakeVtm(), tag: "web", managementAddress: "vtm-b.example.com"},
+		"vtm-c": {vtm: newFakeVtm(), tag: "mail", managementAddress: "vtm-c.example.com"},
+		"vtm-d": {tag: "down", managementAddress: "vtm-d.example.com"},
+	})
+
+	tables := []struct {
+		settings map[string]interface{}
+		err      string
+	}{
+		{map[string]interface{}{"instance_id": "vtm-a"}, ""},
+		{map[string]interface{}{"instance_tag": "mail"}, ""},
+		{map[string]interface{}{"instance_hostname": "VTM-B.example.com"}, ""},
+		{map[string]interface{}{"instance_tag": "web", "instance_hostname": "vtm-a.example.com"}, ""},
+		{map[string]interface{}{"instance_tag": "web", "fan_out": true}, ""},
+		{map[string]interface{}{"instance_tag": "web"}, "has 2 instances tagged 'web' (vtm-a, vtm-b); set fan_out"},
+		{map[string]interface{}{"instance_tag": "ftp"}, "has no instance tagged 'ftp'"},
+		{map[string]interface{}{}, "one of instance_id, instance_tag or instance_hostname must be set"},
+		{map[string]interface{}{"instance_id": "vtm-a", "instance_tag": "web"}, "instance_id cannot be combined"},
+		{map[string]interface{}{"instance_id": "vtm-x"}, "Services Director has no such instance"},
+		{map[string]interface{}{"instance_id": "vtm-d"}, "Services Director could not reach the instance (HTTP 502)"},
+		{map[string]interface{}{"instance_tag": "web", "api_version": "1.0"}, "Services Director has no such instance or API version"},
+	}
+	for _, table := range tables {
+		_, err := configureTestServicesDirector(sd.URL, table.settings)
+		if table.err == "" {
+			if err != nil {
+				t.Errorf("Failed to configure provider with %v: %v", table.settings, err)
+			}
+		} else if err == nil || strings.Contains(err.Error(), table.err) == false {
+			t.Errorf("Configuring provider with %v: expected an error containing '%s', got %v", table.settings, table.err, err)
+		}
+	}
+
+	if _, err := configureTestProvider(map[string]interface{}{
+		"username":          fakeSdUsername,
+		"password":          "NotThePassword",
+		"services_director": []interface{}{map[string]interface{}{"url": sd.URL + "/api", "api_version": fakeSdApiVersion, "instance_tag": "web"}},
+	}); err == nil || strings.Contains(err.Error(), "Services Director rejected the username or password") == false {
+		t.Errorf("Expected the Services Director credentials to be rejected, got %v", err)
+	}
+}
+
+func TestServicesDirectorFanOut(t *testing.T) {
+	instances := map[string]*fakeSdInstance{
+		"vtm-a": {vtm: newFakeVtm(), tag: "web"},
+		"vtm-b": {vtm: newFakeVtm(), tag: "web"},
+		"vtm-c": {vtm: newFakeVtm(), tag: "mail"},
+	}
+	sd := newFakeServicesDirector(t, instances)
+	tm, err := configureTestServicesDirector(sd.URL, map[string]interface{}{"instance_tag": "web", "fan_out": true})
+	if err != nil {
+		t.Fatalf("Failed to configure provider: %v", err)
+	}
+
+	hasPool := func(id string) bool {
+		return instances[id].vtm.HasObject("config/active/pools/sd_fan_out")
+	}
+	if _, applyErr := tm.NewPool("sd_fan_out").Apply(); applyErr != nil {
+		t.Fatalf("Failed to create pool: %v", applyErr)
+	}
+	if hasPool("vtm-a") != true || hasPool("vtm-b") != true || hasPool("vtm-c") {
+		t.Errorf("Expected the pool on vtm-a and vtm-b only")
+	}
+	if _, getErr := tm.GetPool("sd_fan_out"); getErr != nil {
+		t.Errorf("Failed to read pool: %v", getErr)
+	}
+	if deleteErr := tm.DeletePool("sd_fan_out"); deleteErr != nil {
+		t.Fatalf("Failed to delete pool: %v", deleteErr)
+	}
+	if hasPool("vtm-a") || hasPool("vtm-b") {
+		t.Errorf("Expected the pool to be deleted from vtm-a and vtm-b")
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * The key_spec block of vtm_ssl_server_key and vtm_ssl_client_key has the
+ * provider generate the private key and certificate signing request, and
+ * optionally a self-signed certificate, instead of taking them as PEM text.
+ * The private key is only ever sent to the vTM, and the state holds its
+ * fingerprint as for a key given in 'private'.
+ *
+ * certificate_pem replaces the certificate in 'public', such as with one
+ * issued for the request, without generating a new key.
+ */
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var sslKeyCurves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+func sslKeySpecSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"private", "public", "request"},
+		Description:   "Generate the private key and certificate signing request instead of setting private, public and request",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"algorithm": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringInSlice([]string{"rsa", "ecdsa"}, false),
+					Description:  "Type of key to generate: 'rsa' or 'ecdsa'",
+				},
+				"bits": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      2048,
+					ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+					Description:  "Size of an RSA key",
+				},
+				"curve": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      "P-256",
+					ValidateFunc: validation.StringInSlice([]string{"P-256", "P-384", "P-521"}, false),
+					Description:  "Curve of an ECDSA key",
+				},
+				"subject": &schema.Schema{
+					Type:     schema.TypeList,
+					Required: true,
+					ForceNew: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"common_name": &schema.Schema{
+								Type:         schema.TypeString,
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.NoZeroValues,
+							},
+							"organization": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								ForceNew: true,
+							},
+							"organizational_unit": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								ForceNew: true,
+							},
+							"locality": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								ForceNew: true,
+							},
+							"province": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								ForceNew: true,
+							},
+							"country": &schema.Schema{
+								Type:         schema.TypeString,
+								Optional:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.StringLenBetween(2, 2),
+							},
+						},
+					},
+				},
+				"dns_names": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					ForceNew: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"ip_addresses": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					ForceNew: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.SingleIP(),
+					},
+				},
+				"self_signed": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					ForceNew:    true,
+					Default:     false,
+					Description: "Also generate a self-signed certificate for 'public'",
+				},
+				"validity_days": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      365,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "How long the self-signed certificate is valid for",
+				},
+			},
+		},
+	}
+}
+
+func certificatePemSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"public"},
+		ValidateFunc:  validateCertificatePem,
+		Description:   "PEM encoded certificate to set as 'public', such as one issued for the generated request",
+	}
+}
+
+func validateCertificatePem(v interface{}, k string) (ws []string, es []error) {
+	if _, err := parseCertificatePem(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %v", k, err))
+	}
+	return
+}
+
+func parseCertificatePem(text string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("not a PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+/*
+getSslKeyMaterial returns the private key, certificate and certificate signing request for a new
+SSL key resource, generating them if it has a key_spec block. A certificate in certificate_pem
+takes the place of 'public'.
+*/
+func getSslKeyMaterial(d *schema.ResourceData, extKeyUsage x509.ExtKeyUsage) (private, public, request string, err error) {
+	certificate := d.Get("certificate_pem").(string)
+	if specs := d.Get("key_spec").([]interface{}); len(specs) != 0 {
+		private, public, request, err = generateSslKey(specs[0].(map[string]interface{}), extKeyUsage)
+		if err != nil {
+			return "", "", "", err
+		}
+	} else {
+		private, public, request = d.Get("private").(string), d.Get("public").(string), d.Get("request").(string)
+		if private == "" || request == "" || (public == "" && certificate == "") {
+			return "", "", "", fmt.Errorf("private, public and request must be set unless key_spec is given")
+		}
+	}
+	if certificate != "" {
+		if err = checkCertificateMatchesRequest(certificate, request); err != nil {
+			return "", "", "", err
+		}
+		public = certificate
+	}
+	return private, public, request, nil
+}
+
+// setCertificatePem replaces 'public' with certificate_pem, if it is set.
+func setCertificatePem(target **string, d *schema.ResourceData) {
+	if certificate := d.Get("certificate_pem").(string); certificate != "" {
+		*target = &certificate
+	}
+}
+
+/*
+checkCertificateMatchesRequest checks that a certificate is for the key in a certificate signing
+request. Requests which are not PEM encoded, which the vTM does not require, are not checked.
+*/
+func checkCertificateMatchesRequest(certificate, request string) error {
+	block, _ := pem.Decode([]byte(request))
+	if block == nil {
+		return nil
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	cert, err := parseCertificatePem(certificate)
+	if err != nil {
+		return fmt.Errorf("certificate_pem: %v", err)
+	}
+	if key, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); ok == false || key.Equal(csr.PublicKey) == false {
+		return fmt.Errorf("certificate_pem is not for the key of the certificate signing request")
+	}
+	return nil
+}
+
+/*
+generateSslKey creates a private key and a certificate signing request, and a self-signed
+certificate if self_signed is set, as described by a key_spec block. They are PEM encoded, and
+public is empty when no certificate is generated. extKeyUsage is the use that the self-signed
+certificate is issued for.
+*/
+func generateSslKey(spec map[string]interface{}, extKeyUsage x509.ExtKeyUsage) (private, public, request string, err error) {
+	var key crypto.Signer
+	var keyBlock *pem.Block
+	switch spec["algorithm"].(string) {
+	case "rsa":
+		rsaKey, rsaErr := rsa.GenerateKey(rand.Reader, spec["bits"].(int))
+		if rsaErr != nil {
+			return "", "", "", fmt.Errorf("Failed to generate RSA key: %v", rsaErr)
+		}
+		key = rsaKey
+		keyBlock = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}
+	case "ecdsa":
+		curve, ok := sslKeyCurves[spec["curve"].(string)]
+		if ok != true {
+			return "", "", "", fmt.Errorf("Unknown curve '%s'", spec["curve"])
+		}
+		ecdsaKey, ecdsaErr := ecdsa.GenerateKey(curve, rand.Reader)
+		if ecdsaErr != nil {
+			return "", "", "", fmt.Errorf("Failed to generate ECDSA key: %v", ecdsaErr)
+		}
+		der, marshalErr := x509.MarshalECPrivateKey(ecdsaKey)
+		if marshalErr != nil {
+			return "", "", "", fmt.Errorf("Failed to encode ECDSA key: %v", marshalErr)
+		}
+		key = ecdsaKey
+		keyBlock = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	default:
+		return "", "", "", fmt.Errorf("Unknown key algorithm '%s'", spec["algorithm"])
+	}
+
+	subject := getSslKeySubject(spec["subject"].([]interface{}))
+	dnsNames := expandStringList(spec["dns_names"].([]interface{}))
+	ipAddresses := []net.IP{}
+	for _, address := range expandStringList(spec["ip_addresses"].([]interface{})) {
+		ipAddresses = append(ipAddresses, net.ParseIP(address))
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     subject,
+		DNSNames:    dnsNames,
+		IPAddresses: ipAddresses,
+	}, key)
+	if err != nil {
+		return "", "", "", fmt.Errorf("Failed to create certificate signing request: %v", err)
+	}
+	private = string(pem.EncodeToMemory(keyBlock))
+	request = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}))
+
+	if spec["self_signed"].(bool) {
+		serial, serialErr := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if serialErr != nil {
+			return "", "", "", fmt.Errorf("Failed to generate certificate serial number: %v", serialErr)
+		}
+		keyUsage := x509.KeyUsageDigitalSignature
+		if _, isRsa := key.(*rsa.PrivateKey); isRsa {
+			keyUsage |= x509.KeyUsageKeyEncipherment
+		}
+		now := time.Now()
+		template := &x509.Certificate{
+			SerialNumber:          serial,
+			Subject:               subject,
+			DNSNames:              dnsNames,
+			IPAddresses:           ipAddresses,
+			NotBefore:             now.Add(-time.Minute),
+			NotAfter:              now.AddDate(0, 0, spec["validity_days"].(int)),
+			KeyUsage:              keyUsage,
+			ExtKeyUsage:           []x509.ExtKeyUsage{extKeyUsage},
+			BasicConstraintsValid: true,
+		}
+		der, certErr := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		if certErr != nil {
+			return "", "", "", fmt.Errorf("Failed to create self-signed certificate: %v", certErr)
+		}
+		public = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+	return private, public, request, nil
+}
+
+func getSslKeySubject(subjects []interface{}) pkix.Name {
+	subject := subjects[0].(map[string]interface{})
+	name := pkix.Name{CommonName: subject["common_name"].(string)}
+	for field, target := range map[string]*[]string{
+		"organization":        &name.Organization,
+		"organizational_unit": &name.OrganizationalUnit,
+		"locality":            &name.Locality,
+		"province":            &name.Province,
+		"country":             &name.Country,
+	} {
+		if value := subject[field].(string); value != "" {
+			*target = []string{value}
+		}
+	}
+	return name
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * These tests cover the following cases:
+ *   - Generation of RSA and ECDSA keys with their certificate signing requests
+ *   - Generation of a self-signed certificate for the key
+ *   - Checking that a certificate is for the key of a request
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+func getTestSslKeySpec(algorithm string, selfSigned bool) map[string]interface{} {
+	return map[string]interface{}{
+		"algorithm": algorithm,
+		"bits":      2048,
+		"curve":     "P-384",
+		"subject": []interface{}{map[string]interface{}{
+			"common_name":         "www.example.com",
+			"organization":        "Example",
+			"organizational_unit": "",
+			"locality":            "",
+			"province":            "",
+			"country":             "GB",
+		}},
+		"dns_names":     []interface{}{"www.example.com", "example.com"},
+		"ip_addresses":  []interface{}{"192.0.2.1"},
+		"self_signed":   selfSigned,
+		"validity_days": 30,
+	}
+}
+
+func TestGenerateSslKey(t *testing.T) {
+	for _, algorithm := range []string{"rsa", "ecdsa"} {
+		private, public, request, err := generateSslKey(getTestSslKeySpec(algorithm, false), x509.ExtKeyUsageServerAuth)
+		if err != nil {
+			t.Fatalf("Failed to generate %s key: %v", algorithm, err)
+		}
+		if public != "" {
+			t.Errorf("Expected no certificate for the %s key, got %s", algorithm, public)
+		}
+
+		keyBlock, _ := pem.Decode([]byte(private))
+		if keyBlock == nil {
+			t.Fatalf("Expected a PEM encoded %s key, got %s", algorithm, private)
+		}
+		requestBlock, _ := pem.Decode([]byte(request))
+		if requestBlock == nil || requestBlock.Type != "CERTIFICATE REQUEST" {
+			t.Fatalf("Expected a PEM encoded request for the %s key, got %s", algorithm, request)
+		}
+		csr, err := x509.ParseCertificateRequest(requestBlock.Bytes)
+		if err != nil {
+			t.Fatalf("Failed to parse request for the %s key: %v", algorithm, err)
+		}
+		if csr.CheckSignature() != nil || csr.Subject.CommonName != "www.example.com" || csr.Subject.Country[0] != "GB" ||
+			len(csr.DNSNames) != 2 || len(csr.IPAddresses) != 1 || csr.IPAddresses[0].String() != "192.0.2.1" {
+			t.Errorf("Unexpected request for the %s key: %v %v %v", algorithm, csr.Subject, csr.DNSNames, csr.IPAddresses)
+		}
+
+		switch algorithm {
+		case "rsa":
+			key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+			if err != nil || key.N.BitLen() != 2048 || key.PublicKey.Equal(csr.PublicKey.(*rsa.PublicKey)) == false {
+				t.Errorf("Unexpected RSA key: %v", err)
+			}
+		case "ecdsa":
+			key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+			if err != nil || key.Curve.Params().Name != "P-384" || key.PublicKey.Equal(csr.PublicKey.(*ecdsa.PublicKey)) == false {
+				t.Errorf("Unexpected ECDSA key: %v", err)
+			}
+		}
+	}
+}
+
+func TestGenerateSslKeySelfSigned(t *testing.T) {
+	_, public, request, err := generateSslKey(getTestSslKeySpec("ecdsa", true), x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	cert, err := parseCertificatePem(public)
+	if err != nil {
+		t.Fatalf("Expected a self-signed certificate, got %v", err)
+	}
+	if cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) != nil || cert.Subject.CommonName != "www.example.com" || len(cert.DNSNames) != 2 ||
+		cert.ExtKeyUsage[0] != x509.ExtKeyUsageClientAuth || cert.NotAfter.Sub(cert.NotBefore).Hours() < 30*24 {
+		t.Errorf("Unexpected self-signed certificate for %v, valid until %v", cert.Subject, cert.NotAfter)
+	}
+	if err := checkCertificateMatchesRequest(public, request); err != nil {
+		t.Errorf("Expected the certificate to match the request, got %v", err)
+	}
+
+	_, other, _, err := generateSslKey(getTestSslKeySpec("ecdsa", true), x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if err := checkCertificateMatchesRequest(other, request); err == nil || strings.Contains(err.Error(), "is not for the key") == false {
+		t.Errorf("Expected a certificate for another key to be refused, got %v", err)
+	}
+	if err := checkCertificateMatchesRequest(other, "TEST_TEXT"); err != nil {
+		t.Errorf("Expected a request which is not PEM encoded not to be checked, got %v", err)
+	}
+}
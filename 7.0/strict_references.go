@@ -0,0 +1,256 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * Virtual servers and pools refer to other objects, such as pools, rules and
+ * classes, by name. The vTM only rejects a name that does not exist when the
+ * referring object is applied, part way through an apply. With
+ * strict_references set on the provider, every resource type in
+ * strictReferenceFields checks when planning that the objects named by its
+ * changed references exist, and fails with a single error listing all of the
+ * missing ones.
+ *
+ * An object that is created in the same plan is not yet listed by the vTM, so
+ * each referenced resource type records the names of the objects of that
+ * type that are planned for creation, as do resource types which create such
+ * objects, such as vtm_acme_certificate for vtm_ssl_server_key. Terraform
+ * plans a resource after the resources it depends on, so this only works when
+ * the reference interpolates the referenced resource's name, for example
+ * pool = "${vtm_pool.web.name}", rather than repeating the name as a literal.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// The attributes of each resource type that name another object, and the
+// resource type of the object that they name.
+var strictReferenceFields = map[string]map[string]string{
+	"vtm_pool": {
+		"auto_scaling_cloud_credentials": "vtm_cloud_api_credential",
+		"bandwidth_class":                "vtm_bandwidth",
+		"failure_pool":                   "vtm_pool",
+		"monitors":                       "vtm_monitor",
+		"persistence_class":              "vtm_persistence",
+	},
+	"vtm_virtual_server_ssl_host_mapping": {
+		"alt_certificates": "vtm_ssl_server_key",
+		"certificate":      "vtm_ssl_server_key",
+	},
+	"vtm_virtual_server": {
+		"bandwidth_class":                  "vtm_bandwidth",
+		"completion_rules":                 "vtm_rule",
+		"glb_services":                     "vtm_glb_service",
+		"pool":                             "vtm_pool",
+		"protection_class":                 "vtm_protection",
+		"request_rules":                    "vtm_rule",
+		"response_rules":                   "vtm_rule",
+		"slm_class":                        "vtm_service_level_monitor",
+		"ssl_client_cert_cas":              "vtm_ssl_ca",
+		"ssl_server_cert_alt_certificates": "vtm_ssl_server_key",
+		"ssl_server_cert_default":          "vtm_ssl_server_key",
+	},
+}
+
+// How to list the objects of each referenced resource type.
+var strictReferenceLists = map[string]func(*vtm.VirtualTrafficManager) (*[]string, string){
+	"vtm_bandwidth": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListBandwidths())
+	},
+	"vtm_cloud_api_credential": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListCloudApiCredentials())
+	},
+	"vtm_glb_service": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListGlbServices())
+	},
+	"vtm_monitor": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListMonitors())
+	},
+	"vtm_persistence": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListPersistences())
+	},
+	"vtm_pool": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListPools())
+	},
+	"vtm_protection": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListProtections())
+	},
+	"vtm_rule": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListRules())
+	},
+	"vtm_service_level_monitor": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListServiceLevelMonitors())
+	},
+	"vtm_ssl_ca": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListSslCas())
+	},
+	"vtm_ssl_server_key": func(tm *vtm.VirtualTrafficManager) (*[]string, string) {
+		return getStrictReferenceList(tm.ListSslServerKeys())
+	},
+}
+
+// How to name the objects of resource types which have no name attribute, in errors.
+var strictReferenceObjectNames = map[string]func(*schema.ResourceDiff) string{
+	"vtm_virtual_server_ssl_host_mapping": func(d *schema.ResourceDiff) string {
+		return getVirtualServerSslHostMappingId(d.Get("virtual_server").(string), d.Get("host").(string))
+	},
+}
+
+// Resource types which create objects of another resource type, under their own name.
+var strictReferencePlannedTypes = map[string]string{
+	"vtm_acme_certificate": "vtm_ssl_server_key",
+}
+
+// Names which refer to objects built into the vTM rather than to configuration.
+var strictReferenceBuiltIns = map[string]map[string]bool{
+	"vtm_pool": {"discard": true},
+}
+
+/*
+strictReferenceState holds, for a provider configured with strict_references, the names of the
+objects of each resource type that are planned for creation.
+*/
+type strictReferenceState struct {
+	mutex   sync.Mutex
+	planned map[string]map[string]bool
+}
+
+var strictReferenceStatesMutex sync.Mutex
+var strictReferenceStates = make(map[*vtm.VirtualTrafficManager]*strictReferenceState)
+
+func enableStrictReferences(tm *vtm.VirtualTrafficManager) {
+	strictReferenceStatesMutex.Lock()
+	defer strictReferenceStatesMutex.Unlock()
+	strictReferenceStates[tm] = &strictReferenceState{planned: make(map[string]map[string]bool)}
+}
+
+func getStrictReferenceState(tm interface{}) *strictReferenceState {
+	strictReferenceStatesMutex.Lock()
+	defer strictReferenceStatesMutex.Unlock()
+	return strictReferenceStates[tm.(*vtm.VirtualTrafficManager)]
+}
+
+func getStrictReferenceList(list *[]string, err error) (*[]string, string) {
+	if list == nil {
+		return nil, err.Error()
+	}
+	return list, ""
+}
+
+/*
+strictReferences adds the strict_references checks to a resource: it records the objects planned
+for creation if other resources can refer to them, and checks its own references if it has any.
+*/
+func strictReferences(resourceType string, resource *schema.Resource) *schema.Resource {
+	plannedType := resourceType
+	if createdType, ok := strictReferencePlannedTypes[resourceType]; ok {
+		plannedType = createdType
+	}
+	_, isReferenced := strictReferenceLists[plannedType]
+	fields := strictReferenceFields[resourceType]
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(d *schema.ResourceDiff, tm interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(d, tm); err != nil {
+				return err
+			}
+		}
+		state := getStrictReferenceState(tm)
+		if state == nil {
+			return nil
+		}
+		if isReferenced && d.Id() == "" && d.NewValueKnown("name") {
+			state.addPlanned(plannedType, d.Get("name").(string))
+		}
+		if len(fields) != 0 {
+			objectName := fmt.Sprintf("%v", d.Get("name"))
+			if getObjectName, ok := strictReferenceObjectNames[resourceType]; ok {
+				objectName = getObjectName(d)
+			}
+			return state.check(resourceType, objectName, fields, d, tm.(*vtm.VirtualTrafficManager))
+		}
+		return nil
+	}
+	return resource
+}
+
+func (state *strictReferenceState) addPlanned(resourceType, name string) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	if state.planned[resourceType] == nil {
+		state.planned[resourceType] = make(map[string]bool)
+	}
+	state.planned[resourceType][name] = true
+}
+
+func (state *strictReferenceState) isPlanned(resourceType, name string) bool {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	return state.planned[resourceType][name]
+}
+
+/*
+check returns an error listing every object named by the changed references of a resource that
+neither exists on the vTM nor is planned for creation.
+*/
+func (state *strictReferenceState) check(resourceType, objectName string, fields map[string]string, d *schema.ResourceDiff, tm *vtm.VirtualTrafficManager) error {
+	existing := make(map[string]map[string]bool)
+	missing := []string{}
+	for attribute, referencedType := range fields {
+		if d.HasChange(attribute) == false || d.NewValueKnown(attribute) == false {
+			continue
+		}
+		for _, name := range getStrictReferenceNames(d.Get(attribute)) {
+			// Rules are disabled by prefixing their name with a slash
+			if referencedType == "vtm_rule" {
+				name = strings.TrimPrefix(name, "/")
+			}
+			if name == "" || strictReferenceBuiltIns[referencedType][name] || state.isPlanned(referencedType, name) {
+				continue
+			}
+			if existing[referencedType] == nil {
+				list, listErr := strictReferenceLists[referencedType](tm)
+				if list == nil {
+					return fmt.Errorf("Failed to list %s objects to check the references of %s '%s': %s", referencedType, resourceType, objectName, listErr)
+				}
+				existing[referencedType] = make(map[string]bool)
+				for _, existingName := range *list {
+					existing[referencedType][existingName] = true
+				}
+			}
+			if existing[referencedType][name] == false {
+				missing = append(missing, fmt.Sprintf("%s: %s '%s'", attribute, referencedType, name))
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("%s '%s' refers to objects which do not exist and are not planned for creation:\n  %s",
+		resourceType, objectName, strings.Join(missing, "\n  "))
+}
+
+func getStrictReferenceNames(value interface{}) []string {
+	switch typedValue := value.(type) {
+	case string:
+		return []string{typedValue}
+	case []interface{}:
+		names := make([]string, 0, len(typedValue))
+		for _, name := range typedValue {
+			names = append(names, name.(string))
+		}
+		return names
+	case *schema.Set:
+		return getStrictReferenceNames(typedValue.List())
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * This test covers the following cases:
+ *   - Listing every missing object referred to by a virtual server and a pool in one error
+ *   - Accepting references to objects which are created in the same plan
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestStrictReferences(t *testing.T) {
+	objName := acctest.RandomWithPrefix("TestStrictReferences")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVirtualServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getMissingStrictReferencesConfig(objName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(
+					"(?s)vtm_virtual_server '%s' refers to objects which do not exist.*"+
+						"pool: vtm_pool '%s_missing'.*request_rules: vtm_rule '%s_missing'",
+					objName, objName, objName)),
+			},
+			// The same plan also reports the pool's missing failure pool
+			{
+				Config:      getMissingStrictReferencesConfig(objName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("vtm_pool '%s' refers to objects which do not exist.*\n.*failure_pool: vtm_pool '%s_missing'", objName, objName)),
+			},
+			{
+				Config: getStrictReferencesConfig(objName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVirtualServerExists,
+					resource.TestCheckResourceAttr("vtm_virtual_server.test_vtm_virtual_server", "pool", objName),
+				),
+			},
+		},
+	})
+}
+
+func getMissingStrictReferencesConfig(name string) string {
+	return fmt.Sprintf(`
+		provider "vtm" {
+			strict_references = true
+		}
+
+		resource "vtm_pool" "test_vtm_pool" {
+			name = "%s"
+			failure_pool = "%s_missing"
+		}
+
+		resource "vtm_virtual_server" "test_vtm_virtual_server" {
+			name = "%s"
+			pool = "%s_missing"
+			port = 10
+			request_rules = ["/%s_missing"]
+		}`,
+		name, name, name, name, name,
+	)
+}
+
+func getStrictReferencesConfig(name string) string {
+	return fmt.Sprintf(`
+		provider "vtm" {
+			strict_references = true
+		}
+
+		resource "vtm_rule" "test_vtm_rule" {
+			name = "%s"
+			content = "log.info('%s');"
+		}
+
+		resource "vtm_pool" "test_vtm_pool" {
+			name = "%s"
+			failure_pool = "discard"
+		}
+
+		resource "vtm_virtual_server" "test_vtm_virtual_server" {
+			name = "%s"
+			pool = "${vtm_pool.test_vtm_pool.name}"
+			port = 10
+			request_rules = ["${vtm_rule.test_vtm_rule.name}"]
+		}`,
+		name, name, name, name,
+	)
+}
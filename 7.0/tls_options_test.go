@@ -0,0 +1,161 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	vtm "github.com/pulse-vadc/go-vtm/7.0"
+)
+
+// newTLSTestServer starts a TLS server answering the API version list, optionally requiring a client certificate.
+func newTLSTestServer(t *testing.T, requireClientCert bool) (*httptest.Server, string) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"children":[{"name":"7.0","href":"/api/tm/7.0/"}]}`))
+	}))
+	if requireClientCert {
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	caCertPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	return server, caCertPem
+}
+
+func getTestClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate client key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create client certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to encode client key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestTLSOptions(t *testing.T) {
+	server, caCertPem := newTLSTestServer(t, false)
+	fingerprint := vtm.GetCertificateFingerprint(server.Certificate().Raw)
+	colonFingerprint := strings.ToUpper(fingerprint[:2]) + ":" + strings.ToUpper(fingerprint[2:4]) + ":" + fingerprint[4:]
+
+	tables := []struct {
+		description   string
+		verifySslCert bool
+		options       vtm.ConnectionOptions
+		err           string
+	}{
+		{"untrusted certificate", true, vtm.ConnectionOptions{}, "certificate"},
+		{"CA bundle", true, vtm.ConnectionOptions{CACertPEM: caCertPem}, ""},
+		{"CA bundle and server name", true, vtm.ConnectionOptions{CACertPEM: caCertPem, TLSServerName: "example.com"}, ""},
+		{"wrong server name", true, vtm.ConnectionOptions{CACertPEM: caCertPem, TLSServerName: "vtm.example.org"}, "vtm.example.org"},
+		{"invalid CA bundle", true, vtm.ConnectionOptions{CACertPEM: "not a certificate"}, "holds no PEM encoded certificates"},
+		{"pinned fingerprint", true, vtm.ConnectionOptions{PinnedFingerprints: []string{fingerprint}}, ""},
+		{"pinned fingerprint with colons", true, vtm.ConnectionOptions{PinnedFingerprints: []string{colonFingerprint}}, ""},
+		{"pinned fingerprint without verification", false, vtm.ConnectionOptions{PinnedFingerprints: []string{fingerprint}}, ""},
+		{"wrong pinned fingerprint", false, vtm.ConnectionOptions{PinnedFingerprints: []string{strings.Repeat("ab", 32)}}, "is not pinned"},
+		{"invalid pinned fingerprint", true, vtm.ConnectionOptions{PinnedFingerprints: []string{"abcd"}}, "is not a SHA-256 fingerprint"},
+		{"client certificate without key", true, vtm.ConnectionOptions{CACertPEM: caCertPem, ClientCertPEM: caCertPem}, "must be given together"},
+	}
+	for _, table := range tables {
+		options := vtm.DefaultConnectionOptions()
+		options.MaxRetries = 0
+		options.CACertPEM = table.options.CACertPEM
+		options.ClientCertPEM = table.options.ClientCertPEM
+		options.TLSServerName = table.options.TLSServerName
+		options.PinnedFingerprints = table.options.PinnedFingerprints
+		_, contactable, contactErr := vtm.NewVirtualTrafficManagerWithOptions(server.URL+"/api", "admin", "password", table.verifySslCert, false, options)
+		if table.err == "" {
+			if contactable != true {
+				t.Errorf("%s: failed to contact test server: %v", table.description, contactErr)
+			}
+		} else if contactable || contactErr == nil || strings.Contains(contactErr.ErrorText, table.err) == false {
+			t.Errorf("%s: expected an error containing '%s', got %v", table.description, table.err, contactErr)
+		}
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	server, caCertPem := newTLSTestServer(t, true)
+	clientCertPem, clientKeyPem := getTestClientCertificate(t)
+
+	options := vtm.DefaultConnectionOptions()
+	options.MaxRetries = 0
+	options.CACertPEM = caCertPem
+	if _, contactable, _ := vtm.NewVirtualTrafficManagerWithOptions(server.URL+"/api", "admin", "password", true, false, options); contactable {
+		t.Errorf("Contacted a server requiring a client certificate without one")
+	}
+	options.ClientCertPEM = clientCertPem
+	options.ClientKeyPEM = clientKeyPem
+	if _, contactable, contactErr := vtm.NewVirtualTrafficManagerWithOptions(server.URL+"/api", "admin", "password", true, false, options); contactable != true {
+		t.Errorf("Failed to contact test server with a client certificate: %v", contactErr)
+	}
+}
+
+func TestProviderTLSSettings(t *testing.T) {
+	server, caCertPem := newTLSTestServer(t, true)
+	clientCertPem, clientKeyPem := getTestClientCertificate(t)
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caCertFile, []byte(caCertPem), 0600); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	tables := []struct {
+		settings map[string]interface{}
+		err      string
+	}{
+		{map[string]interface{}{"ca_cert_file": caCertFile, "client_cert_pem": clientCertPem, "client_key_pem": clientKeyPem}, ""},
+		{map[string]interface{}{"ca_cert_pem": caCertPem, "client_cert_pem": clientCertPem, "client_key_pem": clientKeyPem, "tls_server_name": "example.com"}, ""},
+		{map[string]interface{}{"ca_cert_pem": caCertPem, "client_cert_pem": clientCertPem}, "client_cert_pem and client_key_pem must be set together"},
+		{map[string]interface{}{"ca_cert_file": filepath.Join(os.TempDir(), "no-such-ca.pem")}, "Failed to read ca_cert_file"},
+		{map[string]interface{}{"ca_cert_pem": caCertPem, "ca_cert_file": caCertFile}, "conflicts with"},
+		{map[string]interface{}{"pinned_sha256_fingerprints": []interface{}{"abcd"}}, "must be a SHA-256 fingerprint"},
+	}
+	for _, table := range tables {
+		settings := map[string]interface{}{
+			"base_url":        server.URL + "/api",
+			"password":        "password",
+			"verify_ssl_cert": true,
+			"max_retries":     0,
+		}
+		for key, value := range table.settings {
+			settings[key] = value
+		}
+		_, err := configureTestProvider(settings)
+		if table.err == "" {
+			if err != nil {
+				t.Errorf("Failed to configure provider with %v: %v", table.settings, err)
+			}
+		} else if err == nil || strings.Contains(err.Error(), table.err) == false {
+			t.Errorf("Configuring provider with %v: expected an error containing '%s', got %v", table.settings, table.err, err)
+		}
+	}
+}
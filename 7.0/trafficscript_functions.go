@@ -0,0 +1,161 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * The TrafficScript functions known to lintTrafficScript. Function names are
+ * not case-sensitive, so they are looked up in lower case. The catalogue may
+ * lack functions, particularly those added in later vTM releases, so calls to
+ * functions which are not in it are only reported as warnings.
+ */
+
+import (
+	"strings"
+)
+
+var trafficScriptFunctions = getTrafficScriptFunctions(`
+	array.append array.contains array.copy array.filter array.join array.length
+	array.pop array.push array.reverse array.shift array.sort array.sortNumerical
+	array.splice array.unshift
+
+	auth.query
+
+	connection.checkForComplete connection.close connection.data.get
+	connection.data.reset connection.data.set connection.discard
+	connection.getBandwidthClass connection.getCompletionReasonCode
+	connection.getCompletionReasonInfo connection.getDestIP connection.getDestPort
+	connection.getLocalIP connection.getLocalPort connection.getMemoryUsage
+	connection.getNode connection.getPersistence connection.getPool
+	connection.getPriority connection.getRemoteIP connection.getRemotePort
+	connection.getServiceLevelClass connection.getVirtualServer
+	connection.setBandwidthClass connection.setIdempotent connection.setPersistence
+	connection.setPersistenceKey connection.setPersistenceNode connection.setPriority
+	connection.setServiceLevelClass connection.sleep
+
+	counter.increment
+
+	counter64.increment
+
+	data.get data.getMemoryUsage data.local.get data.local.remove data.local.reset
+	data.local.set data.remove data.reset data.set
+
+	dns.addResponseRR dns.getPacket dns.getQuestion dns.getResponse dns.removeRR
+	dns.rrToString dns.setQuestion dns.setResponse dns.stringToRR
+
+	event.emit event.getName
+
+	geo.getCity geo.getCountry geo.getCountryCode geo.getDistance geo.getLatitude
+	geo.getLongitude geo.getRegion
+
+	glb.service.getLocations glb.service.getName glb.service.setLocation
+
+	http.addHeader http.addResponseHeader http.aptimizer.bypass http.aptimizer.use
+	http.cache.disable http.cache.enable http.cache.exists http.cache.getKey
+	http.cache.setKey http.changeSite http.compress.disable http.compress.enable
+	http.escape http.getBody http.getCookie http.getCookies http.getFormParam
+	http.getFormParams http.getHeader http.getHeaderNames http.getHeaders
+	http.getHostHeader http.getMethod http.getPath http.getQueryString
+	http.getRawURL http.getRequest http.getResponse http.getResponseBody
+	http.getResponseCode http.getResponseCookie http.getResponseCookies
+	http.getResponseHeader http.getResponseHeaderNames http.getResponseHeaders
+	http.getVersion http.headerExists http.mirrorRequest http.redirect
+	http.removeHeader http.removeResponseHeader http.request.delete
+	http.request.get http.request.post http.request.put http.sendResponse
+	http.setBody http.setCookie http.setHeader http.setHostHeader http.setMethod
+	http.setPath http.setQueryString http.setResponseBody http.setResponseCode
+	http.setResponseCookie http.setResponseHeader http.stream.continueFromBackend
+	http.stream.readRequest http.stream.readResponse http.stream.startResponse
+	http.stream.writeResponse http.unescape
+
+	java.run
+
+	json.deserialize json.serialize
+
+	lang.chr lang.dump lang.isArray lang.isDouble lang.isHash lang.isInt
+	lang.isString lang.ord lang.toArray lang.toDouble lang.toHash lang.toInt
+	lang.toString
+
+	log.debug log.emergency log.error log.info log.warn
+
+	math.abs math.ceil math.floor math.max math.min math.random math.round
+	math.sqrt
+
+	hash.contains hash.count hash.delete hash.empty hash.keys hash.values
+
+	net.dns.resolveAll net.dns.resolveHost net.dns.resolveIP
+
+	pool.activeNodes pool.getActiveNodes pool.getFailedNodes pool.getName
+	pool.getNodes pool.select pool.use
+
+	rate.getBacklog rate.use rate.use.noQueue
+
+	request.avoidConnectionReuse request.endsWith request.get request.getDestIP
+	request.getDestPort request.getLength request.getLine request.getLocalIP
+	request.getLocalPort request.getRemoteIP request.getRemotePort
+	request.getRetries request.retry request.sendResponse request.set
+	request.setMaxReplyTime request.skip
+
+	resource.exists resource.get resource.getMD5 resource.getMTime
+
+	response.append response.close response.endsWith response.get
+	response.getLength response.getLine response.set
+
+	rtsp.addHeader rtsp.getBody rtsp.getHeader rtsp.getHeaderNames rtsp.getHeaders
+	rtsp.getMethod rtsp.getPath rtsp.getRequestURI rtsp.getStatusCode
+	rtsp.getVersion rtsp.removeHeader rtsp.sendResponse rtsp.setBody
+	rtsp.setHeader rtsp.setMethod rtsp.setRequestURI rtsp.setStatusCode
+
+	rule.getName
+
+	sip.addHeader sip.getBody sip.getCallID sip.getHeader sip.getHeaderNames
+	sip.getHeaders sip.getMethod sip.getReasonPhrase sip.getRequestURI
+	sip.getStatusCode sip.removeHeader sip.sendResponse sip.setBody
+	sip.setHeader sip.setMethod sip.setReasonPhrase sip.setRequestURI
+	sip.setStatusCode
+
+	slm.conforming slm.isOK
+
+	ssl.clientCert ssl.clientCertDN ssl.clientCertHash ssl.getCipher
+	ssl.getCipherBits ssl.getClientCert ssl.getServerName ssl.getVersion
+	ssl.isSSL ssl.sessionID
+
+	string.base64decode string.base64encode string.bytesToDotted
+	string.bytesToInt string.chr string.cmp string.contains string.convertCharset
+	string.count string.decrypt string.dottedToBytes string.drop string.encrypt
+	string.endsWith string.escape string.find string.findr string.hash
+	string.hashMD5 string.hashSHA1 string.hashSHA256 string.hashSHA384
+	string.hashSHA512 string.hexDecode string.hexEncode string.htmlDecode
+	string.htmlEncode string.icmp string.insert string.intToBytes
+	string.ipMaskMatch string.isIPAddress string.left string.len string.lowercase
+	string.normalizeIPAddress string.ord string.randomBytes string.regexEscape
+	string.regexMatch string.regexSub string.replace string.replaceAll
+	string.replaceAllI string.replaceI string.right string.skip string.split
+	string.sprintf string.startsWith string.substring string.toInt string.trim
+	string.unescape string.uppercase string.urlDecode string.urlEncode
+	string.validIPAddress string.wildMatch
+
+	sys.domainname sys.getenv sys.getPid sys.gmtime sys.gmtime.format
+	sys.hostname sys.localtime sys.localtime.format sys.time sys.time.highres
+	sys.time.hour sys.time.minutes sys.time.month sys.time.monthday
+	sys.time.seconds sys.time.weekday sys.time.year sys.time.yearday
+
+	xml.escape xml.unescape xml.validate.xsd xml.xpath.matchNodeCount
+	xml.xpath.matchNodeSet xml.xslt.transform
+`)
+
+// The position of the regular expression among the arguments of functions
+// that take one, counting from zero.
+var trafficScriptRegexArguments = map[string]int{
+	"array.filter":      1,
+	"string.regexmatch": 1,
+	"string.regexsub":   1,
+}
+
+func getTrafficScriptFunctions(catalogue string) map[string]bool {
+	functions := make(map[string]bool)
+	for _, name := range strings.Fields(catalogue) {
+		functions[strings.ToLower(name)] = true
+	}
+	return functions
+}
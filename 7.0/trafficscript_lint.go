@@ -0,0 +1,501 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * lintTrafficScript checks a TrafficScript rule without a traffic manager, so
+ * that mistakes are reported when planning rather than part way through an
+ * apply. It reports, with their line and column:
+ *
+ *   - brackets, braces and parentheses which are not balanced
+ *   - statements which start with a word that is neither a keyword nor a
+ *     function call
+ *   - unterminated strings and comments, and malformed escape sequences
+ *   - regular expressions given as string literals which cannot be compiled
+ *   - calls to functions which are not in trafficScriptFunctions, are not
+ *     subroutines defined in the rule and are not in an imported library.
+ *     These are warnings rather than errors, as the catalogue may be
+ *     incomplete.
+ *
+ * It is not a full parser, so a rule which it accepts can still be rejected
+ * by the traffic manager.
+ */
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var trafficScriptKeywords = map[string]bool{
+	"as":       true,
+	"break":    true,
+	"case":     true,
+	"continue": true,
+	"default":  true,
+	"do":       true,
+	"else":     true,
+	"for":      true,
+	"foreach":  true,
+	"if":       true,
+	"import":   true,
+	"in":       true,
+	"return":   true,
+	"sub":      true,
+	"switch":   true,
+	"while":    true,
+}
+
+var trafficScriptClosingBrackets = map[string]string{
+	"(": ")",
+	"[": "]",
+	"{": "}",
+}
+
+// Regular expression errors which PCRE, used by the traffic manager, reports
+// too. Other errors from regexp/syntax may be PCRE features that it lacks.
+var trafficScriptRegexErrors = map[syntax.ErrorCode]bool{
+	syntax.ErrInvalidCharRange:      true,
+	syntax.ErrMissingBracket:        true,
+	syntax.ErrMissingParen:          true,
+	syntax.ErrMissingRepeatArgument: true,
+	syntax.ErrTrailingBackslash:     true,
+	syntax.ErrUnexpectedParen:       true,
+}
+
+type trafficScriptProblem struct {
+	Line    int
+	Column  int
+	Message string
+	// Whether the problem may not be a mistake, such as a call to a function missing from the catalogue
+	Warning bool
+}
+
+func (problem trafficScriptProblem) String() string {
+	if problem.Warning {
+		return fmt.Sprintf("line %d, column %d: warning: %s", problem.Line, problem.Column, problem.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", problem.Line, problem.Column, problem.Message)
+}
+
+type trafficScriptTokenType int
+
+const (
+	trafficScriptWord trafficScriptTokenType = iota
+	trafficScriptVariable
+	trafficScriptNumber
+	trafficScriptString
+	trafficScriptPunctuation
+)
+
+type trafficScriptToken struct {
+	tokenType trafficScriptTokenType
+	// The decoded value of a string, and the source text of anything else
+	text   string
+	line   int
+	column int
+}
+
+func (token trafficScriptToken) is(tokenType trafficScriptTokenType, text string) bool {
+	return token.tokenType == tokenType && strings.ToLower(token.text) == text
+}
+
+type trafficScriptLexer struct {
+	source    []rune
+	offset    int
+	line      int
+	column    int
+	tokens    []trafficScriptToken
+	problems  []trafficScriptProblem
+	truncated bool
+}
+
+func (lexer *trafficScriptLexer) atEnd() bool {
+	return lexer.offset >= len(lexer.source)
+}
+
+func (lexer *trafficScriptLexer) peek(ahead int) rune {
+	if lexer.offset+ahead >= len(lexer.source) {
+		return 0
+	}
+	return lexer.source[lexer.offset+ahead]
+}
+
+func (lexer *trafficScriptLexer) next() rune {
+	r := lexer.source[lexer.offset]
+	lexer.offset++
+	if r == '\n' {
+		lexer.line++
+		lexer.column = 1
+	} else {
+		lexer.column++
+	}
+	return r
+}
+
+func (lexer *trafficScriptLexer) nextWhile(accept func(rune) bool) string {
+	start := lexer.offset
+	for lexer.atEnd() == false && accept(lexer.peek(0)) {
+		lexer.next()
+	}
+	return string(lexer.source[start:lexer.offset])
+}
+
+func (lexer *trafficScriptLexer) addToken(tokenType trafficScriptTokenType, text string, line, column int) {
+	lexer.tokens = append(lexer.tokens, trafficScriptToken{tokenType, text, line, column})
+}
+
+func (lexer *trafficScriptLexer) addProblem(line, column int, format string, args ...interface{}) {
+	lexer.problems = append(lexer.problems, trafficScriptProblem{line, column, fmt.Sprintf(format, args...), false})
+}
+
+func isTrafficScriptWordStart(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r))
+}
+
+func isTrafficScriptWordRune(r rune) bool {
+	return isTrafficScriptWordStart(r) || (r >= '0' && r <= '9')
+}
+
+func isHexDigit(r rune) bool {
+	return strings.ContainsRune("0123456789abcdefABCDEF", r)
+}
+
+func (lexer *trafficScriptLexer) lex() {
+	for lexer.atEnd() == false {
+		line, column := lexer.line, lexer.column
+		r := lexer.peek(0)
+		switch {
+		case unicode.IsSpace(r):
+			lexer.next()
+
+		case r == '#' || (r == '/' && lexer.peek(1) == '/'):
+			lexer.nextWhile(func(r rune) bool { return r != '\n' })
+
+		case r == '/' && lexer.peek(1) == '*':
+			lexer.next()
+			lexer.next()
+			for !(lexer.peek(0) == '*' && lexer.peek(1) == '/') {
+				if lexer.atEnd() {
+					lexer.addProblem(line, column, "unterminated comment")
+					lexer.truncated = true
+					return
+				}
+				lexer.next()
+			}
+			lexer.next()
+			lexer.next()
+
+		case r == '"' || r == '\'':
+			lexer.lexString(line, column)
+
+		case r == '$':
+			lexer.next()
+			name := lexer.nextWhile(isTrafficScriptWordRune)
+			if name == "" {
+				lexer.addProblem(line, column, "expected a variable name after '$'")
+			}
+			lexer.addToken(trafficScriptVariable, name, line, column)
+
+		case isTrafficScriptWordStart(r):
+			word := lexer.nextWhile(isTrafficScriptWordRune)
+			for lexer.peek(0) == '.' && isTrafficScriptWordStart(lexer.peek(1)) {
+				lexer.next()
+				word += "." + lexer.nextWhile(isTrafficScriptWordRune)
+			}
+			lexer.addToken(trafficScriptWord, word, line, column)
+
+		case r >= '0' && r <= '9':
+			number := lexer.nextWhile(isTrafficScriptWordRune)
+			if lexer.peek(0) == '.' && lexer.peek(1) >= '0' && lexer.peek(1) <= '9' {
+				lexer.next()
+				number += "." + lexer.nextWhile(isTrafficScriptWordRune)
+			}
+			lexer.addToken(trafficScriptNumber, number, line, column)
+
+		case strings.ContainsRune("+-*/%=!<>&|^~?:;,.(){}[]", r):
+			lexer.next()
+			lexer.addToken(trafficScriptPunctuation, string(r), line, column)
+
+		default:
+			lexer.next()
+			lexer.addProblem(line, column, "unexpected character %q", r)
+		}
+	}
+}
+
+func (lexer *trafficScriptLexer) lexString(line, column int) {
+	quote := lexer.next()
+	var value strings.Builder
+	for {
+		if lexer.atEnd() {
+			lexer.addProblem(line, column, "unterminated string")
+			lexer.truncated = true
+			return
+		}
+		escapeLine, escapeColumn := lexer.line, lexer.column
+		r := lexer.next()
+		if r == quote {
+			break
+		}
+		if r != '\\' || lexer.atEnd() {
+			value.WriteRune(r)
+			continue
+		}
+		escaped := lexer.next()
+		switch {
+		case escaped == quote || escaped == '\\':
+			value.WriteRune(escaped)
+		case quote == '\'':
+			value.WriteRune('\\')
+			value.WriteRune(escaped)
+		case escaped == 'n':
+			value.WriteRune('\n')
+		case escaped == 'r':
+			value.WriteRune('\r')
+		case escaped == 't':
+			value.WriteRune('\t')
+		case escaped == '$':
+			value.WriteRune('$')
+		case escaped == 'x':
+			hex := ""
+			for len(hex) < 2 && lexer.atEnd() == false && isHexDigit(lexer.peek(0)) {
+				hex += string(lexer.next())
+			}
+			if len(hex) != 2 {
+				lexer.addProblem(escapeLine, escapeColumn, "malformed escape sequence '\\x%s', expected two hexadecimal digits", hex)
+				continue
+			}
+			b, _ := strconv.ParseUint(hex, 16, 8)
+			value.WriteByte(byte(b))
+		default:
+			// Left for the regular expression, if the string is one
+			value.WriteRune('\\')
+			value.WriteRune(escaped)
+		}
+	}
+	lexer.addToken(trafficScriptString, value.String(), line, column)
+}
+
+/*
+lintTrafficScript returns the problems found in a TrafficScript rule, in the order in which they
+appear in it.
+*/
+func lintTrafficScript(source string) []trafficScriptProblem {
+	lexer := &trafficScriptLexer{source: []rune(source), line: 1, column: 1}
+	lexer.lex()
+	tokens := lexer.tokens
+	problems := lexer.problems
+	addProblem := func(token trafficScriptToken, format string, args ...interface{}) {
+		problems = append(problems, trafficScriptProblem{token.line, token.column, fmt.Sprintf(format, args...), false})
+	}
+
+	// Subroutines and libraries can be used before they are defined
+	subroutines := make(map[string]bool)
+	libraries := make(map[string]bool)
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i+1].tokenType != trafficScriptWord {
+			continue
+		}
+		name := strings.ToLower(tokens[i+1].text)
+		if tokens[i].is(trafficScriptWord, "sub") {
+			subroutines[name] = true
+		} else if tokens[i].is(trafficScriptWord, "import") {
+			if i+3 < len(tokens) && tokens[i+2].is(trafficScriptWord, "as") && tokens[i+3].tokenType == trafficScriptWord {
+				name = strings.ToLower(tokens[i+3].text)
+			}
+			libraries[name] = true
+		}
+	}
+
+	open := []trafficScriptToken{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch token.tokenType {
+		case trafficScriptPunctuation:
+			switch token.text {
+			case "(", "[", "{":
+				open = append(open, token)
+			case ")", "]", "}":
+				if len(open) == 0 {
+					addProblem(token, "unexpected '%s' with no matching opening bracket", token.text)
+					continue
+				}
+				opening := open[len(open)-1]
+				open = open[:len(open)-1]
+				if trafficScriptClosingBrackets[opening.text] != token.text {
+					addProblem(token, "'%s' does not match '%s' at line %d, column %d", token.text, opening.text, opening.line, opening.column)
+				}
+			}
+
+		case trafficScriptWord:
+			name := strings.ToLower(token.text)
+			if trafficScriptKeywords[name] {
+				if name == "sub" || name == "import" {
+					for i+1 < len(tokens) && tokens[i+1].tokenType == trafficScriptWord {
+						i++
+					}
+				}
+				continue
+			}
+			if i+1 < len(tokens) && tokens[i+1].is(trafficScriptPunctuation, "(") {
+				// A misspelt keyword, such as "fi ($x) { ... }", looks like a call
+				if strings.Contains(name, ".") == false && subroutines[name] == false &&
+					isTrafficScriptStatementStart(tokens, i) && isTrafficScriptBlockCondition(tokens, i+1) {
+					addProblem(token, "unknown keyword '%s'", token.text)
+					continue
+				}
+				problems = append(problems, lintTrafficScriptCall(tokens, i, subroutines, libraries)...)
+				continue
+			}
+			if isTrafficScriptStatementStart(tokens, i) {
+				addProblem(token, "unknown keyword '%s'", token.text)
+			} else {
+				addProblem(token, "unexpected word '%s'", token.text)
+			}
+		}
+	}
+
+	// An unterminated string or comment hides the brackets that close these
+	if lexer.truncated == false {
+		for _, opening := range open {
+			addProblem(opening, "'%s' is never closed", opening.text)
+		}
+	}
+
+	sort.SliceStable(problems, func(a, b int) bool {
+		if problems[a].Line != problems[b].Line {
+			return problems[a].Line < problems[b].Line
+		}
+		return problems[a].Column < problems[b].Column
+	})
+	return problems
+}
+
+func isTrafficScriptStatementStart(tokens []trafficScriptToken, i int) bool {
+	if i == 0 {
+		return true
+	}
+	previous := tokens[i-1]
+	return previous.is(trafficScriptPunctuation, ";") || previous.is(trafficScriptPunctuation, "{") ||
+		previous.is(trafficScriptPunctuation, "}") || previous.is(trafficScriptWord, "else") ||
+		previous.is(trafficScriptWord, "do")
+}
+
+/*
+isTrafficScriptBlockCondition reports whether the parentheses opened at tokens[open] are followed by
+a block, as the condition of an if or while statement is.
+*/
+func isTrafficScriptBlockCondition(tokens []trafficScriptToken, open int) bool {
+	depth := 0
+	for j := open; j < len(tokens); j++ {
+		if tokens[j].is(trafficScriptPunctuation, "(") {
+			depth++
+		} else if tokens[j].is(trafficScriptPunctuation, ")") {
+			depth--
+			if depth == 0 {
+				return j+1 < len(tokens) && tokens[j+1].is(trafficScriptPunctuation, "{")
+			}
+		}
+	}
+	return false
+}
+
+/*
+lintTrafficScriptCall checks the call to a function whose name is tokens[i], including any regular
+expression passed to it as a string literal.
+*/
+func lintTrafficScriptCall(tokens []trafficScriptToken, i int, subroutines, libraries map[string]bool) []trafficScriptProblem {
+	problems := []trafficScriptProblem{}
+	token := tokens[i]
+	name := strings.ToLower(token.text)
+	known := subroutines[name]
+	if dot := strings.LastIndex(name, "."); dot != -1 {
+		known = trafficScriptFunctions[name] || libraries[name[:dot]]
+	}
+	if known == false {
+		problems = append(problems, trafficScriptProblem{token.line, token.column, fmt.Sprintf("unknown function '%s'", token.text), true})
+	}
+
+	argument, hasRegex := trafficScriptRegexArguments[name]
+	if hasRegex == false {
+		return problems
+	}
+	regex := getTrafficScriptStringArgument(tokens, i+1, argument)
+	if regex == nil {
+		return problems
+	}
+	if _, err := syntax.Parse(regex.text, syntax.Perl); err != nil {
+		if regexErr, ok := err.(*syntax.Error); ok && trafficScriptRegexErrors[regexErr.Code] {
+			problems = append(problems, trafficScriptProblem{regex.line, regex.column,
+				fmt.Sprintf("malformed regular expression %q: %s", regex.text, regexErr.Code), false})
+		}
+	}
+	return problems
+}
+
+/*
+getTrafficScriptStringArgument returns an argument of the call whose opening parenthesis is
+tokens[open], if that argument is a single string literal.
+*/
+func getTrafficScriptStringArgument(tokens []trafficScriptToken, open, argument int) *trafficScriptToken {
+	current, depth := 0, 0
+	for j := open + 1; j < len(tokens); j++ {
+		token := tokens[j]
+		if token.tokenType == trafficScriptPunctuation {
+			switch token.text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				if depth == 0 {
+					return nil
+				}
+				depth--
+			case ",":
+				if depth == 0 {
+					current++
+				}
+			}
+			continue
+		}
+		if depth != 0 || current != argument || token.tokenType != trafficScriptString {
+			continue
+		}
+		before, after := tokens[j-1], trafficScriptToken{}
+		if j+1 < len(tokens) {
+			after = tokens[j+1]
+		}
+		if (before.is(trafficScriptPunctuation, "(") || before.is(trafficScriptPunctuation, ",")) &&
+			(after.is(trafficScriptPunctuation, ")") || after.is(trafficScriptPunctuation, ",")) {
+			return &tokens[j]
+		}
+	}
+	return nil
+}
+
+// splitTrafficScriptProblems separates the errors in a rule from the warnings.
+func splitTrafficScriptProblems(problems []trafficScriptProblem) (errors, warnings []trafficScriptProblem) {
+	for _, problem := range problems {
+		if problem.Warning {
+			warnings = append(warnings, problem)
+		} else {
+			errors = append(errors, problem)
+		}
+	}
+	return errors, warnings
+}
+
+/*
+formatTrafficScriptProblems describes the problems in a rule, one per line.
+*/
+func formatTrafficScriptProblems(problems []trafficScriptProblem) string {
+	lines := make([]string, len(problems))
+	for i, problem := range problems {
+		lines[i] = problem.String()
+	}
+	return strings.Join(lines, "\n  ")
+}
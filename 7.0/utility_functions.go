@@ -24,11 +24,21 @@ func setAllNotRequired(fields map[string]*schema.Schema) map[string]*schema.Sche
 	return fields
 }
 
-func formatErrorInfo(errorInfo map[string]interface{}) string {
+func formatErrorInfo(errorInfo interface{}) string {
 	errorMsg := ""
-	for section, info := range errorInfo {
-		for field, errors := range info.(map[string]interface{}) {
-			errorMsg += fmt.Sprintf("; %s_%s %s", section, field, errors.(map[string]interface{})["error_text"])
+	sections, ok := errorInfo.(map[string]interface{})
+	if !ok {
+		return errorMsg
+	}
+	for section, info := range sections {
+		fields, ok := info.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field, errors := range fields {
+			if fieldErrors, ok := errors.(map[string]interface{}); ok {
+				errorMsg += fmt.Sprintf("; %s_%s %s", section, field, fieldErrors["error_text"])
+			}
 		}
 	}
 	return errorMsg
@@ -54,16 +64,68 @@ func suppressTableDiffs(tableName string) schema.SchemaDiffSuppressFunc {
 	}
 }
 
-func suppressHashedDiffs(fieldName string) schema.SchemaDiffSuppressFunc {
-	return func (k, old, new string, d *schema.ResourceData) bool {
-		fieldValue := d.Get(fieldName)
-		fieldValueHash := sha256.New()
-		fieldValueHash.Write([]byte(fieldValue.(string)))
-		if base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s", fieldValueHash.Sum(nil)))) == old {
-			return true
-		}
-		return false
+/*
+Write-only secrets, such as SSL private keys and RADIUS secrets, are stored in the state as a
+fingerprint: the base64 encoded SHA-256 hash of the secret, which is how the vTM reports SSL private
+keys. The configured value is fingerprinted by the attribute's StateFunc and the value reported by
+the vTM is fingerprinted when the resource is read, so a plan shows a change whenever the secret on
+the vTM no longer matches the configuration, without the plaintext ever being persisted. An empty
+secret has an empty fingerprint.
+
+This relies on the vTM returning secrets other than private keys as they were written. Where it
+masks a secret instead, the fingerprint already in the state is kept, so the secret is only sent
+again when its configuration changes.
+*/
+func hashSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	secretHash := sha256.Sum256([]byte(secret))
+	return base64.StdEncoding.EncodeToString(secretHash[:])
+}
+
+/*
+getSecretFingerprint returns the fingerprint of a secret reported by the vTM. If the reported
+secret has been masked, it returns the fingerprint of the configured secret when it is being
+applied, and the fingerprint already in the state otherwise.
+*/
+func getSecretFingerprint(d *schema.ResourceData, key, reported string) string {
+	if isMaskedSecret(reported) == false {
+		return hashSecret(reported)
+	}
+	if d.HasChange(key) {
+		return hashSecret(d.Get(key).(string))
 	}
+	return d.Get(key).(string)
+}
+
+// isMaskedSecret reports whether a secret has been returned as a row of asterisks.
+func isMaskedSecret(reported string) bool {
+	return reported != "" && strings.Trim(reported, "*") == ""
+}
+
+func hashSecretState(v interface{}) string {
+	return hashSecret(v.(string))
+}
+
+/*
+hashComputedSecretState is the StateFunc of secrets which are computed when they are not configured,
+such as generated private keys. Terraform passes the fingerprint in the state back as the planned
+value of such an attribute, so a value which is already a fingerprint is kept. It must not be used
+for secrets which could themselves look like a fingerprint.
+*/
+func hashComputedSecretState(v interface{}) string {
+	return getHashedSecretFingerprint(v.(string))
+}
+
+// getHashedSecretFingerprint returns the fingerprint of a secret which the vTM normally reports as a
+// hash already, hashing it only if the vTM has returned the secret itself.
+func getHashedSecretFingerprint(reported string) string {
+	decoded, err := base64.StdEncoding.DecodeString(reported)
+	if err == nil && len(decoded) == sha256.Size {
+		return reported
+	}
+	return hashSecret(reported)
 }
 
 func getStringAddr(target string) *string {
@@ -101,6 +163,15 @@ func setString(target **string, d *schema.ResourceData, key string) {
 	*target = &value
 }
 
+// Write-only secrets are only sent to the vTM when they change, as the state only holds their hash.
+func setSecret(target **string, d *schema.ResourceData, key string) {
+	if d.HasChange(key) {
+		setString(target, d, key)
+	} else {
+		*target = nil
+	}
+}
+
 func setFloat(target **float64, d *schema.ResourceData, key string) {
 	value := d.Get(key).(float64)
 	*target = &value
@@ -0,0 +1,104 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * vtmgen generates the go-vtm object types and the Terraform resources, data
+ * sources, table data sources and tests of one provider version from the vTM
+ * REST schema documents of that version.
+ *
+ * The schema directory mirrors the REST API below /api/tm/<version>:
+ *
+ *   - config/active/<path>.json holds the schema of the configuration
+ *     collection at config/active/<path>, as served by the vTM.
+ *   - status/statistics/<path>.json holds the schema of the statistics at
+ *     status/<tm>/statistics/<path>.
+ *   - objects.json lists the objects to generate, naming each one and giving
+ *     its kind: see manifest.go.
+ *
+ * Regenerating the 7.0 tree:
+ *
+ *   go run ./cmd/vtmgen -version 7.0 -schemas <dir> \
+ *       -provider 7.0 -library vendor/github.com/pulse-vadc/go-vtm/7.0
+ *
+ * Registering new resources and data sources in provider.go is left to hand,
+ * as are the files of objects marked hand_edited in the manifest.
+ */
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	version := flag.String("version", "", "REST API version of the schemas, such as 7.0")
+	schemaDir := flag.String("schemas", "", "Directory holding objects.json and the schema documents")
+	providerDir := flag.String("provider", "", "Directory to write the provider files to")
+	libraryDir := flag.String("library", "", "Directory to write the go-vtm files to")
+	flag.Parse()
+
+	if *version == "" || *schemaDir == "" || *providerDir == "" || *libraryDir == "" {
+		fmt.Fprintf(os.Stderr, "Usage: vtmgen -version <ver> -schemas <dir> -provider <dir> -library <dir>\n")
+		os.Exit(2)
+	}
+	files, err := generate(*version, *schemaDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vtmgen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := writeFiles(files, *providerDir, *libraryDir); err != nil {
+		fmt.Fprintf(os.Stderr, "vtmgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generatedFile is the content of one output file.
+type generatedFile struct {
+	library bool
+	name    string
+	content []byte
+}
+
+// generate loads the manifest and schemas in schemaDir and renders every file for them.
+func generate(version, schemaDir string) ([]generatedFile, error) {
+	objects, err := loadManifest(schemaDir)
+	if err != nil {
+		return nil, err
+	}
+	var files []generatedFile
+	for _, object := range objects {
+		if err := loadObjectSchema(schemaDir, object); err != nil {
+			return nil, err
+		}
+		objectFiles, err := renderObject(version, object)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", object.Name, err)
+		}
+		files = append(files, objectFiles...)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].library != files[j].library {
+			return files[i].library
+		}
+		return files[i].name < files[j].name
+	})
+	return files, nil
+}
+
+func writeFiles(files []generatedFile, providerDir, libraryDir string) error {
+	for _, file := range files {
+		dir := providerDir
+		if file.library {
+			dir = libraryDir
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, file.name), file.content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
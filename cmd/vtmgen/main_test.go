@@ -0,0 +1,97 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateMatchesTree checks that the schemas in testdata regenerate the committed 7.0 files unchanged.
+func TestGenerateMatchesTree(t *testing.T) {
+	files, err := generate("7.0", "testdata")
+	if err != nil {
+		t.Fatalf("Failed to generate files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No files were generated")
+	}
+	for _, file := range files {
+		committedPath := filepath.Join("..", "..", "7.0", file.name)
+		if file.library {
+			committedPath = filepath.Join("..", "..", "vendor", "github.com", "pulse-vadc", "go-vtm", "7.0", file.name)
+		}
+		committed, err := ioutil.ReadFile(committedPath)
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", committedPath, err)
+			continue
+		}
+		if bytes.Equal(file.content, committed) == false {
+			t.Errorf("Generated %s differs from %s", file.name, committedPath)
+		}
+	}
+}
+
+func TestGetGoName(t *testing.T) {
+	tables := []struct {
+		name   string
+		goName string
+	}{
+		{"basic", "Basic"},
+		{"cache_j2ee", "CacheJ2Ee"},
+		{"ssl_server_cert_host_mapping", "SslServerCertHostMapping"},
+		{"adminMasterXMLIP", "Adminmasterxmlip"},
+		{"log2mainlog", "Log2Mainlog"},
+	}
+	for _, table := range tables {
+		if goName := getGoName(table.name); goName != table.goName {
+			t.Errorf("getGoName(%q) = %q, expected %q", table.name, goName, table.goName)
+		}
+	}
+}
+
+func TestGetCommentLines(t *testing.T) {
+	lines := getCommentLines("The percentage of SSL server cache lookups that succeeded over the last interval.\nShort.")
+	expected := []string{
+		"// The percentage of SSL server cache lookups that succeeded over",
+		"//  the last interval.",
+		"// Short.",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("getCommentLines returned %d lines, expected %d: %q", len(lines), len(expected), lines)
+	}
+	for i := range lines {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d is %q, expected %q", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	tables := []struct {
+		manifest  string
+		errorText string
+	}{
+		{`{"objects": [{"name": "pool"}]}`, "objects.json: every object needs a name and a path"},
+		{`{"objects": [{"name": "pool", "path": "pools", "kind": "binary"}]}`, "objects.json: object 'pool' has unknown kind 'binary'"},
+		{`{"objects": [{"name": "rule", "path": "rules", "kind": "text", "singleton": true}]}`, "objects.json: text object 'rule' cannot be a singleton"},
+	}
+	for _, table := range tables {
+		dir, err := ioutil.TempDir("", "vtmgen")
+		if err != nil {
+			t.Fatalf("Failed to create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		if err := ioutil.WriteFile(filepath.Join(dir, "objects.json"), []byte(table.manifest), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+		_, err = loadManifest(dir)
+		if err == nil || err.Error() != table.errorText {
+			t.Errorf("loadManifest(%s) returned error %v, expected %q", table.manifest, err, table.errorText)
+		}
+	}
+}
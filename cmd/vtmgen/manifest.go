@@ -0,0 +1,108 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+/*
+ * objects.json lists the objects to generate, in a "objects" array. Each
+ * object has:
+ *
+ *   - name: the name of the object, which gives the vtm_<name> resource and
+ *     the Go type names, such as "extra_file".
+ *   - path: the REST path of the collection or object, relative to
+ *     config/active or status/<tm>/statistics, such as "extra_files".
+ *   - kind: "standard" (the default) for objects with properties, "text" for
+ *     objects whose body is plain text, or "statistics".
+ *   - singleton: true for objects which are not in a collection, such as
+ *     "security" or the "cache/ssl_cache" statistics.
+ *   - expert_keys: keys, as <section>/<field>, which the vTM only returns
+ *     when they are asked for by name.
+ *   - hand_edited: true for objects whose provider files have been changed
+ *     by hand since they were generated, so only go-vtm is regenerated.
+ *   - test: overrides for the generated test. "values" maps attributes to
+ *     the HCL values to give them instead of the defaults for their type, and
+ *     "setup" holds Go statements creating the objects they refer to.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+const (
+	kindStandard   = "standard"
+	kindText       = "text"
+	kindStatistics = "statistics"
+)
+
+type object struct {
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	Kind       string   `json:"kind"`
+	Singleton  bool     `json:"singleton"`
+	ExpertKeys []string `json:"expert_keys"`
+	HandEdited bool     `json:"hand_edited"`
+	Test       struct {
+		Values map[string]string `json:"values"`
+		Setup  []string          `json:"setup"`
+	} `json:"test"`
+
+	goName   string
+	sections []*section
+}
+
+type section struct {
+	name   string
+	goName string
+	fields []*field
+}
+
+type field struct {
+	name        string
+	goName      string
+	attribute   string
+	description string
+	fieldType   string
+	unique      bool
+	required    bool
+	defaultVal  interface{}
+	enum        []string
+	minimum     *json.Number
+	maximum     *json.Number
+	table       *table
+}
+
+type table struct {
+	goName string
+	fields []*field
+}
+
+func loadManifest(schemaDir string) ([]*object, error) {
+	content, err := ioutil.ReadFile(filepath.Join(schemaDir, "objects.json"))
+	if err != nil {
+		return nil, err
+	}
+	manifest := struct {
+		Objects []*object `json:"objects"`
+	}{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("objects.json: %v", err)
+	}
+	for _, object := range manifest.Objects {
+		if object.Kind == "" {
+			object.Kind = kindStandard
+		}
+		switch {
+		case object.Name == "" || object.Path == "":
+			return nil, fmt.Errorf("objects.json: every object needs a name and a path")
+		case object.Kind != kindStandard && object.Kind != kindText && object.Kind != kindStatistics:
+			return nil, fmt.Errorf("objects.json: object '%s' has unknown kind '%s'", object.Name, object.Kind)
+		case object.Kind == kindText && object.Singleton:
+			return nil, fmt.Errorf("objects.json: text object '%s' cannot be a singleton", object.Name)
+		}
+		object.goName = getGoName(object.Name)
+	}
+	return manifest.Objects, nil
+}
@@ -0,0 +1,631 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const copyrightHeader = `// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+`
+
+// renderObject renders the go-vtm and provider files of an object.
+func renderObject(version string, object *object) ([]generatedFile, error) {
+	data := &templateData{Version: version, object: object}
+	var files []generatedFile
+	add := func(library bool, name string, tmpl *template.Template) error {
+		var content bytes.Buffer
+		if err := tmpl.Execute(&content, data); err != nil {
+			return err
+		}
+		formatted, err := format.Source(content.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		files = append(files, generatedFile{library: library, name: name, content: formatted})
+		return nil
+	}
+
+	var err error
+	switch {
+	case object.Kind == kindStatistics:
+		err = add(true, "statistics_"+object.Name+".go", libraryStatisticsTemplate)
+		if err == nil && object.HandEdited == false {
+			err = add(false, "data_source_stats_"+object.Name+".go", dataSourceStatisticsTemplate)
+		}
+		return files, err
+	case object.Kind == kindText:
+		err = add(true, "config_"+object.Name+".go", libraryTextTemplate)
+	case object.Singleton:
+		err = add(true, "config_"+object.Name+".go", librarySingletonTemplate)
+	default:
+		err = add(true, "config_"+object.Name+".go", libraryStandardTemplate)
+	}
+	if err != nil || object.HandEdited {
+		return files, err
+	}
+
+	resourceTemplate, testTemplate := resourceStandardTemplate, resourceTestTemplate
+	switch {
+	case object.Kind == kindText:
+		resourceTemplate = resourceTextTemplate
+	case object.Singleton:
+		resourceTemplate, testTemplate = resourceSingletonTemplate, resourceSingletonTestTemplate
+	}
+	for _, step := range []struct {
+		name string
+		tmpl *template.Template
+	}{
+		{"resource_" + object.Name + ".go", resourceTemplate},
+		{"resource_" + object.Name + "_test.go", testTemplate},
+		{"data_source_config_" + object.Name + ".go", dataSourceConfigTemplate},
+	} {
+		if err := add(false, step.name, step.tmpl); err != nil {
+			return files, err
+		}
+	}
+	if object.Singleton == false {
+		if err := add(false, "data_source_config_"+object.Name+"_list.go", dataSourceListTemplate); err != nil {
+			return files, err
+		}
+	}
+	for _, f := range data.Tables() {
+		data.table = f
+		if err := add(false, "data_source_table_"+object.Name+"_"+f.name+".go", dataSourceTableTemplate); err != nil {
+			return files, err
+		}
+	}
+	return files, nil
+}
+
+// templateData is passed to the templates, which call its methods for anything beyond the names.
+type templateData struct {
+	Version string
+	object  *object
+	table   *field
+}
+
+func (data *templateData) Name() string   { return data.object.Name }
+func (data *templateData) GoName() string { return data.object.goName }
+func (data *templateData) Path() string   { return data.object.Path }
+
+/*
+Label is the name of the object in error messages and, for singletons, its ID. It comes from the last
+part of the path, so it is "ca" rather than "ssl_ca" for the objects at ssl/cas.
+*/
+func (data *templateData) Label() string {
+	label := path.Base(data.object.Path)
+	if data.object.Kind == kindStatistics {
+		return label
+	}
+	return strings.TrimSuffix(label, "s")
+}
+
+// StatisticsLabel is the name of named statistics in error messages.
+func (data *templateData) StatisticsLabel() string {
+	return path.Base(data.object.Path)
+}
+
+// ExpertKeysSuffix is the query which asks for the expert keys of an object, if it has any.
+func (data *templateData) ExpertKeysSuffix() string {
+	if len(data.object.ExpertKeys) == 0 {
+		return ""
+	}
+	return "?expert_keys=" + strings.Join(data.object.ExpertKeys, ",")
+}
+
+// ExpertKeysQuery appends ExpertKeysSuffix to a path expression.
+func (data *templateData) ExpertKeysQuery() string {
+	if len(data.object.ExpertKeys) == 0 {
+		return ""
+	}
+	return " + \"" + data.ExpertKeysSuffix() + "\""
+}
+
+func (data *templateData) Singleton() bool { return data.object.Singleton }
+
+func (data *templateData) Fields() []*field {
+	var fields []*field
+	for _, section := range data.object.sections {
+		fields = append(fields, section.fields...)
+	}
+	return fields
+}
+
+func (data *templateData) Tables() []*field {
+	var tables []*field
+	for _, f := range data.Fields() {
+		if f.table != nil {
+			tables = append(tables, f)
+		}
+	}
+	return tables
+}
+
+func (data *templateData) HasTables() bool { return len(data.Tables()) > 0 }
+
+// RequiredFields are the fields which are passed to the New function of a standard object.
+func (data *templateData) RequiredFields() []*field {
+	var required []*field
+	for _, f := range data.Fields() {
+		if f.required && f.table == nil {
+			required = append(required, f)
+		}
+	}
+	return required
+}
+
+// UsesValidation reports whether the resource schema calls the validation package.
+func (data *templateData) UsesValidation() bool {
+	if data.object.Singleton == false || data.HasTables() {
+		return true
+	}
+	for _, f := range data.Fields() {
+		if getValidateFunc(f) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (data *templateData) fieldOwner(f *field) string {
+	for _, section := range data.object.sections {
+		for _, sectionField := range section.fields {
+			if sectionField == f {
+				return "object." + section.goName + "." + f.goName
+			}
+		}
+	}
+	return ""
+}
+
+/*
+LibrarySections renders the sections of the Properties struct, or of the Statistics struct, in the
+order of their Go names.
+*/
+func (data *templateData) LibrarySections() string {
+	var out bytes.Buffer
+	sections := append([]*section{}, data.object.sections...)
+	sortLibrarySections(sections)
+	for i, section := range sections {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "\t%s struct {\n", section.goName)
+		for j, f := range section.fields {
+			if data.object.Kind == kindStatistics {
+				fmt.Fprintf(&out, "\t\t%s %s `json:\"%s\"`\n", f.goName, getLibraryType(data.object, f), f.name)
+				continue
+			}
+			if j > 0 {
+				out.WriteString("\n")
+			}
+			writeComment(&out, "\t\t", f.description)
+			fmt.Fprintf(&out, "\t\t%s %s `json:\"%s,omitempty\"`\n", f.goName, getLibraryType(data.object, f), f.name)
+		}
+		fmt.Fprintf(&out, "\t} `json:\"%s\"`\n", section.name)
+	}
+	return out.String()
+}
+
+// LibraryTables renders the row and table types of the tables of an object.
+func (data *templateData) LibraryTables() string {
+	var out bytes.Buffer
+	sections := append([]*section{}, data.object.sections...)
+	sortLibrarySections(sections)
+	for _, section := range sections {
+		for _, f := range section.fields {
+			if f.table == nil {
+				continue
+			}
+			fmt.Fprintf(&out, "\ntype %s struct {\n", f.table.goName)
+			for j, sub := range f.table.fields {
+				if j > 0 {
+					out.WriteString("\n")
+				}
+				writeComment(&out, "\t", sub.description)
+				fmt.Fprintf(&out, "\t%s %s `json:\"%s,omitempty\"`\n", sub.goName, getLibraryType(data.object, sub), sub.name)
+			}
+			fmt.Fprintf(&out, "}\n\ntype %sTable []%s\n", f.table.goName, f.table.goName)
+		}
+	}
+	return out.String()
+}
+
+// NewParameters renders the parameters of the New function of a standard object.
+func (data *templateData) NewParameters() string {
+	parameters := []string{"name string"}
+	for _, f := range data.RequiredFields() {
+		parameters = append(parameters, getParameterName(f)+" "+strings.TrimPrefix(getLibraryType(data.object, f), "*"))
+	}
+	return strings.Join(parameters, ", ")
+}
+
+// NewAssignments renders the assignments of the required fields in the New function.
+func (data *templateData) NewAssignments() string {
+	var out bytes.Buffer
+	for _, f := range data.RequiredFields() {
+		fmt.Fprintf(&out, "\t%s = &%s\n", data.fieldOwner(f), getParameterName(f))
+	}
+	if out.Len() == 0 {
+		return "\n"
+	}
+	return out.String()
+}
+
+// NewArguments renders the arguments which the provider passes to the New function.
+func (data *templateData) NewArguments() (string, error) {
+	arguments := []string{"objectName"}
+	for _, f := range data.RequiredFields() {
+		if f.fieldType == "array" {
+			return "", fmt.Errorf("required list attribute '%s' is not supported", f.attribute)
+		}
+		arguments = append(arguments, fmt.Sprintf("d.Get(\"%s\").(%s)", f.attribute, getTerraformGoType(f)))
+	}
+	return strings.Join(arguments, ", "), nil
+}
+
+// ResourceSchema renders the entries of the resource schema which follow "name".
+func (data *templateData) ResourceSchema() string {
+	var out bytes.Buffer
+	if data.object.Kind == kindText {
+		out.WriteString("\n\t\t// Object text\n\t\t\"content\": &schema.Schema{\n\t\t\tType: schema.TypeString,\n\t\t\tRequired: true,\n\t\t},\n")
+		return out.String()
+	}
+	for _, f := range data.Fields() {
+		out.WriteString("\n")
+		writeComment(&out, "\t\t", f.description)
+		writeSchemaEntry(&out, "\t\t", f, false, false)
+		if f.table != nil {
+			fmt.Fprintf(&out, "\n\t\t// JSON representation of %s\n", f.attribute)
+			fmt.Fprintf(&out, "\t\t\"%s_json\": &schema.Schema{\n", f.attribute)
+			out.WriteString("\t\t\tType: schema.TypeString,\n\t\t\tOptional: true,\n\t\t\tValidateFunc: validation.ValidateJsonString,\n\t\t},\n")
+		}
+	}
+	return out.String()
+}
+
+// ReadAssignments renders the statements which copy an object's fields into the resource data.
+func (data *templateData) ReadAssignments() string {
+	var out bytes.Buffer
+	for _, f := range data.Fields() {
+		owner := data.fieldOwner(f)
+		if data.object.Kind == kindStatistics {
+			if isSplitCounter(f) {
+				continue
+			}
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "\tlastAssignedField = \"%s\"\n", f.attribute)
+		if f.table == nil {
+			fmt.Fprintf(&out, "\td.Set(\"%s\", %s(*%s))\n", f.attribute, getConversion(f), owner)
+			continue
+		}
+		variable := getVariableName(f.attribute)
+		fmt.Fprintf(&out, "\t%s := make([]map[string]interface{}, 0, len(*%s))\n", variable, owner)
+		fmt.Fprintf(&out, "\tfor _, item := range *%s {\n", owner)
+		out.WriteString("\t\titemTerraform := make(map[string]interface{})\n")
+		for _, sub := range f.table.fields {
+			fmt.Fprintf(&out, "\t\tif item.%s != nil {\n", sub.goName)
+			fmt.Fprintf(&out, "\t\t\titemTerraform[\"%s\"] = %s(*item.%s)\n", sub.name, getConversion(sub), sub.goName)
+			out.WriteString("\t\t}\n")
+		}
+		fmt.Fprintf(&out, "\t\t%s = append(%s, itemTerraform)\n", variable, variable)
+		out.WriteString("\t}\n")
+		fmt.Fprintf(&out, "\td.Set(\"%s\", %s)\n", f.attribute, variable)
+		fmt.Fprintf(&out, "\t%sJson, _ := json.Marshal(%s)\n", variable, variable)
+		fmt.Fprintf(&out, "\td.Set(\"%s_json\", %sJson)\n", f.attribute, variable)
+	}
+	return out.String()
+}
+
+// FieldAssignments renders the statements which copy the resource data into an object's fields.
+func (data *templateData) FieldAssignments() string {
+	var out bytes.Buffer
+	for _, f := range data.Fields() {
+		owner := data.fieldOwner(f)
+		switch {
+		case f.table != nil:
+			variable := getVariableName(f.attribute)
+			fmt.Fprintf(&out, "\n\t%s = &vtm.%sTable{}\n", owner, f.table.goName)
+			fmt.Fprintf(&out, "\tif %sJson, ok := d.GetOk(\"%s_json\"); ok {\n", variable, f.attribute)
+			fmt.Fprintf(&out, "\t\t_ = json.Unmarshal([]byte(%sJson.(string)), %s)\n", variable, owner)
+			fmt.Fprintf(&out, "\t} else if %s, ok := d.GetOk(\"%s\"); ok {\n", variable, f.attribute)
+			fmt.Fprintf(&out, "\t\tfor _, row := range %s.(*schema.Set).List() {\n", variable)
+			out.WriteString("\t\t\titemTerraform := row.(map[string]interface{})\n")
+			fmt.Fprintf(&out, "\t\t\tVtmObject := vtm.%s{}\n", f.table.goName)
+			for _, sub := range f.table.fields {
+				value := fmt.Sprintf("itemTerraform[\"%s\"]", sub.name)
+				fmt.Fprintf(&out, "\t\t\tVtmObject.%s = %s\n", sub.goName, getAddrExpression(sub, value, false))
+			}
+			fmt.Fprintf(&out, "\t\t\t*%s = append(*%s, VtmObject)\n", owner, owner)
+			out.WriteString("\t\t}\n")
+			fmt.Fprintf(&out, "\t\td.Set(\"%s\", %s)\n", f.attribute, variable)
+			out.WriteString("\t} else {\n")
+			fmt.Fprintf(&out, "\t\td.Set(\"%s\", make([]map[string]interface{}, 0, len(*%s)))\n", f.attribute, owner)
+			out.WriteString("\t}\n")
+		case f.fieldType == "array":
+			setter := "setStringList"
+			if f.unique {
+				setter = "setStringSet"
+			}
+			fmt.Fprintf(&out, "\n\tif _, ok := d.GetOk(\"%s\"); ok {\n", f.attribute)
+			fmt.Fprintf(&out, "\t\t%s(&%s, d, \"%s\")\n", setter, owner, f.attribute)
+			out.WriteString("\t} else {\n")
+			fmt.Fprintf(&out, "\t\t%s = &[]string{}\n", owner)
+			fmt.Fprintf(&out, "\t\td.Set(\"%s\", []string(*%s))\n", f.attribute, owner)
+			out.WriteString("\t}\n")
+		default:
+			fmt.Fprintf(&out, "\tset%s(&%s, d, \"%s\")\n", getSetterSuffix(f), owner, f.attribute)
+		}
+	}
+	return out.String()
+}
+
+// TestAttributes renders the required attributes of the test configuration.
+func (data *templateData) TestAttributes() string {
+	var out bytes.Buffer
+	if data.object.Kind == kindText {
+		out.WriteString("\t\t\tcontent = \"TEST_TEXT\"\n")
+	}
+	for _, f := range data.RequiredFields() {
+		value, ok := data.object.Test.Values[f.attribute]
+		if ok != true {
+			value = getTestValue(f)
+		}
+		fmt.Fprintf(&out, "\t\t\t%s = %s\n", f.attribute, value)
+	}
+	return out.String()
+}
+
+func (data *templateData) TestSetup() []string { return data.object.Test.Setup }
+
+// StatisticsSchema renders the entries of a statistics data source schema.
+func (data *templateData) StatisticsSchema() string {
+	var out bytes.Buffer
+	for _, f := range data.Fields() {
+		if isSplitCounter(f) {
+			continue
+		}
+		out.WriteString("\n")
+		writeComment(&out, "\t\t\t", f.description)
+		fmt.Fprintf(&out, "\t\t\t\"%s\": &schema.Schema{\n", f.attribute)
+		fmt.Fprintf(&out, "\t\t\t\tType: %s,\n\t\t\t\tOptional: true,\n\t\t\t},\n", getSchemaType(f, false))
+	}
+	return out.String()
+}
+
+func (data *templateData) TableGoName() string   { return data.table.table.goName }
+func (data *templateData) TableFields() []*field { return data.table.table.fields }
+func (data *templateData) TableUsesValidation() bool {
+	for _, sub := range data.table.table.fields {
+		if getValidateFunc(sub) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// TableSchema renders the entries of a table data source schema which follow "json".
+func (data *templateData) TableSchema() string {
+	var out bytes.Buffer
+	for _, sub := range data.table.table.fields {
+		fmt.Fprintf(&out, "\n\t\t\t// %s\n", sub.name)
+		writeSchemaEntry(&out, "\t\t\t", sub, true, true)
+	}
+	return out.String()
+}
+
+// TableRow renders the fields of the row built by a table data source.
+func (data *templateData) TableRow() string {
+	var out bytes.Buffer
+	for _, sub := range data.table.table.fields {
+		value := fmt.Sprintf("d.Get(\"%s\")", sub.name)
+		fmt.Fprintf(&out, "\t\t%s: %s,\n", sub.goName, getAddrExpression(sub, value, true))
+	}
+	return out.String()
+}
+
+func sortLibrarySections(sections []*section) {
+	for i := 1; i < len(sections); i++ {
+		for j := i; j > 0 && sections[j].goName < sections[j-1].goName; j-- {
+			sections[j], sections[j-1] = sections[j-1], sections[j]
+		}
+	}
+}
+
+func writeComment(out *bytes.Buffer, indent, description string) {
+	if description == "" {
+		return
+	}
+	for _, line := range getCommentLines(description) {
+		out.WriteString(indent + line + "\n")
+	}
+}
+
+/*
+writeSchemaEntry renders the schema of an attribute or of a table column. Table data sources take
+lists rather than sets, as their rows are built from a single set of values.
+*/
+func writeSchemaEntry(out *bytes.Buffer, indent string, f *field, inTable, dataSource bool) {
+	fmt.Fprintf(out, "%s\"%s\": &schema.Schema{\n", indent, f.attribute)
+	fmt.Fprintf(out, "%s\tType: %s,\n", indent, getSchemaType(f, dataSource))
+	if f.required {
+		fmt.Fprintf(out, "%s\tRequired: true,\n", indent)
+	} else {
+		fmt.Fprintf(out, "%s\tOptional: true,\n", indent)
+	}
+	if f.table != nil {
+		fmt.Fprintf(out, "%s\tElem: &schema.Resource{\n", indent)
+		fmt.Fprintf(out, "%s\t\tSchema: map[string]*schema.Schema{\n", indent)
+		for _, sub := range f.table.fields {
+			fmt.Fprintf(out, "\n%s\t\t\t// %s\n", indent, sub.name)
+			writeSchemaEntry(out, indent+"\t\t\t", sub, true, false)
+		}
+		fmt.Fprintf(out, "%s\t\t},\n", indent)
+		fmt.Fprintf(out, "%s\t},\n", indent)
+		fmt.Fprintf(out, "%s},\n", indent)
+		return
+	}
+	if f.fieldType == "array" {
+		fmt.Fprintf(out, "%s\tElem: &schema.Schema{Type: schema.TypeString},\n", indent)
+		if inTable && f.required == false {
+			fmt.Fprintf(out, "%s\tDefault: nil,\n", indent)
+		}
+	}
+	if validateFunc := getValidateFunc(f); validateFunc != "" {
+		fmt.Fprintf(out, "%s\tValidateFunc: %s,\n", indent, validateFunc)
+	}
+	if defaultValue := getDefault(f); defaultValue != "" && f.required == false {
+		fmt.Fprintf(out, "%s\tDefault: %s,\n", indent, defaultValue)
+	}
+	fmt.Fprintf(out, "%s},\n", indent)
+}
+
+func getSchemaType(f *field, dataSource bool) string {
+	switch f.fieldType {
+	case "string":
+		return "schema.TypeString"
+	case "integer":
+		return "schema.TypeInt"
+	case "number":
+		return "schema.TypeFloat"
+	case "boolean":
+		return "schema.TypeBool"
+	}
+	if f.table != nil || (f.unique && dataSource == false) {
+		return "schema.TypeSet"
+	}
+	return "schema.TypeList"
+}
+
+func getValidateFunc(f *field) string {
+	switch {
+	case len(f.enum) > 0:
+		return fmt.Sprintf("validation.StringInSlice([]string{\"%s\"}, false)", strings.Join(f.enum, "\", \""))
+	case f.fieldType != "integer":
+		return ""
+	case f.minimum != nil && f.maximum != nil:
+		return fmt.Sprintf("validation.IntBetween(%s, %s)", f.minimum, f.maximum)
+	case f.minimum != nil:
+		return fmt.Sprintf("validation.IntAtLeast(%s)", f.minimum)
+	case f.maximum != nil:
+		return fmt.Sprintf("validation.IntAtMost(%s)", f.maximum)
+	}
+	return ""
+}
+
+// getDefault renders the default of a scalar field, or "" if the schema has none worth stating.
+func getDefault(f *field) string {
+	if f.defaultVal == nil || f.fieldType == "array" {
+		return ""
+	}
+	switch value := f.defaultVal.(type) {
+	case string:
+		if value == "" {
+			return ""
+		}
+		return strconv.Quote(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case json.Number:
+		if f.fieldType == "number" {
+			number, _ := value.Float64()
+			formatted := strconv.FormatFloat(number, 'f', -1, 64)
+			if strings.Contains(formatted, ".") == false {
+				formatted += ".0"
+			}
+			return formatted
+		}
+		return value.String()
+	}
+	return ""
+}
+
+func getLibraryType(object *object, f *field) string {
+	switch {
+	case f.table != nil:
+		return "*" + f.table.goName + "Table"
+	case f.fieldType == "array":
+		return "*[]string"
+	}
+	return "*" + getTerraformGoType(f)
+}
+
+func getTerraformGoType(f *field) string {
+	switch f.fieldType {
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "string"
+}
+
+func getConversion(f *field) string {
+	if f.fieldType == "array" {
+		return "[]string"
+	}
+	return getTerraformGoType(f)
+}
+
+func getSetterSuffix(f *field) string {
+	switch f.fieldType {
+	case "integer":
+		return "Int"
+	case "number":
+		return "Float"
+	case "boolean":
+		return "Bool"
+	}
+	return "String"
+}
+
+/*
+getAddrExpression renders the pointer to a table column read from value, which is an item of a set
+in a resource or an attribute of a table data source, where every list is a plain list.
+*/
+func getAddrExpression(f *field, value string, dataSource bool) string {
+	switch {
+	case f.fieldType == "array" && f.unique && dataSource == false:
+		return fmt.Sprintf("getStringSetAddr(expandStringSet(%s.(*schema.Set)))", value)
+	case f.fieldType == "array":
+		return fmt.Sprintf("getStringListAddr(expandStringList(%s.([]interface{})))", value)
+	}
+	return fmt.Sprintf("get%sAddr(%s.(%s))", getSetterSuffix(f), value, getTerraformGoType(f))
+}
+
+func getParameterName(f *field) string {
+	if f.name == "type" {
+		return "typeParam"
+	}
+	return f.name
+}
+
+func getTestValue(f *field) string {
+	switch {
+	case len(f.enum) > 0:
+		return strconv.Quote(f.enum[0])
+	case f.fieldType == "integer" || f.fieldType == "number":
+		return "10"
+	case f.fieldType == "boolean":
+		return "true"
+	}
+	return "\"TEST_TEXT\""
+}
+
+// isSplitCounter reports whether a statistic is one half of a 64-bit counter, which the provider leaves out.
+func isSplitCounter(f *field) bool {
+	return strings.HasSuffix(f.name, "_hi") || strings.HasSuffix(f.name, "_lo")
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// jsonSchema is the part of a draft-03 JSON schema document which is used by the vTM.
+type jsonSchema struct {
+	Description string                 `json:"description"`
+	Type        string                 `json:"type"`
+	Default     interface{}            `json:"default"`
+	Enum        []string               `json:"enum"`
+	Minimum     *json.Number           `json:"minimum"`
+	Maximum     *json.Number           `json:"maximum"`
+	Required    bool                   `json:"required"`
+	UniqueItems bool                   `json:"uniqueItems"`
+	Items       *jsonSchema            `json:"items"`
+	Properties  map[string]*jsonSchema `json:"properties"`
+}
+
+func getSchemaPath(schemaDir string, object *object) string {
+	if object.Kind == kindStatistics {
+		return filepath.Join(schemaDir, "status", "statistics", filepath.FromSlash(object.Path)+".json")
+	}
+	return filepath.Join(schemaDir, "config", "active", filepath.FromSlash(object.Path)+".json")
+}
+
+// loadObjectSchema reads the schema document of an object and fills in its sections.
+func loadObjectSchema(schemaDir string, object *object) error {
+	schemaPath := getSchemaPath(schemaDir, object)
+	content, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	document := new(jsonSchema)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(document); err != nil {
+		return fmt.Errorf("%s: %v", schemaPath, err)
+	}
+
+	if object.Kind == kindText {
+		return nil
+	}
+	member := "properties"
+	if object.Kind == kindStatistics {
+		member = "statistics"
+	}
+	properties, ok := document.Properties[member]
+	if ok != true || properties.Type != "object" {
+		return fmt.Errorf("%s: no object schema for \"%s\"", schemaPath, member)
+	}
+	if object.Kind == kindStatistics {
+		statistics, err := getFields(object, "statistics", properties.Properties)
+		if err != nil {
+			return fmt.Errorf("%s: %v", schemaPath, err)
+		}
+		object.sections = []*section{{name: "statistics", goName: "Statistics", fields: statistics}}
+		return nil
+	}
+	for sectionName, sectionSchema := range properties.Properties {
+		fields, err := getFields(object, sectionName, sectionSchema.Properties)
+		if err != nil {
+			return fmt.Errorf("%s: %v", schemaPath, err)
+		}
+		object.sections = append(object.sections, &section{
+			name:   sectionName,
+			goName: getGoName(sectionName),
+			fields: fields,
+		})
+	}
+	sortSections(object.sections)
+	return nil
+}
+
+func getFields(object *object, sectionName string, properties map[string]*jsonSchema) ([]*field, error) {
+	var fields []*field
+	for fieldName, fieldSchema := range properties {
+		f, err := getField(fieldName, fieldSchema)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", sectionName, fieldName, err)
+		}
+		f.attribute = getAttributeName(object, sectionName, fieldName)
+		if f.table != nil {
+			f.table.goName = object.goName + f.goName
+			if f.description == "" {
+				f.description = fmt.Sprintf("This is table '%s'", fieldName)
+			}
+		}
+		fields = append(fields, f)
+	}
+	sortFields(fields)
+	return fields, nil
+}
+
+func getField(name string, fieldSchema *jsonSchema) (*field, error) {
+	f := &field{
+		name:        name,
+		goName:      getGoName(name),
+		attribute:   name,
+		description: fieldSchema.Description,
+		fieldType:   fieldSchema.Type,
+		unique:      fieldSchema.UniqueItems,
+		required:    fieldSchema.Required,
+		defaultVal:  fieldSchema.Default,
+		enum:        fieldSchema.Enum,
+		minimum:     fieldSchema.Minimum,
+		maximum:     fieldSchema.Maximum,
+	}
+	switch fieldSchema.Type {
+	case "string", "integer", "number", "boolean":
+	case "array":
+		if fieldSchema.Items == nil {
+			return nil, fmt.Errorf("array without items")
+		}
+		switch fieldSchema.Items.Type {
+		case "string":
+		case "object":
+			f.table = &table{}
+			for subName, subSchema := range fieldSchema.Items.Properties {
+				sub, err := getField(subName, subSchema)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", subName, err)
+				}
+				if sub.table != nil {
+					return nil, fmt.Errorf("%s: tables cannot be nested", subName)
+				}
+				f.table.fields = append(f.table.fields, sub)
+			}
+			sortFields(f.table.fields)
+		default:
+			return nil, fmt.Errorf("unsupported array item type '%s'", fieldSchema.Items.Type)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type '%s'", fieldSchema.Type)
+	}
+	return f, nil
+}
+
+// sortSections orders sections as the provider lists them: basic first and the rest by name.
+func sortSections(sections []*section) {
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].name == "basic" || sections[j].name == "basic" {
+			return sections[i].name == "basic" && sections[j].name != "basic"
+		}
+		return sections[i].name < sections[j].name
+	})
+}
+
+func sortFields(fields []*field) {
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+}
+
+/*
+getAttributeName returns the Terraform attribute for a field: the field name in the basic section,
+or the section and field names joined with an underscore in any other. Attributes named "id" or
+"count" clash with Terraform's own, so they are renamed to "identifier" and "counter".
+*/
+func getAttributeName(object *object, sectionName, fieldName string) string {
+	attribute := fieldName
+	if sectionName != "basic" && object.Kind != kindStatistics {
+		attribute = sectionName + "_" + fieldName
+	}
+	switch attribute {
+	case "id":
+		return "identifier"
+	case "count":
+		return "counter"
+	}
+	return attribute
+}
+
+/*
+getGoName returns the exported Go name for a name from the schema: each part between underscores is
+capitalised, as is any letter following a digit, so "cache_j2ee" becomes "CacheJ2Ee". The rest of
+each part is lower case, even where the name is in camel case.
+*/
+func getGoName(name string) string {
+	var goName strings.Builder
+	for _, part := range strings.Split(strings.ToLower(name), "_") {
+		capitalise := true
+		for _, r := range part {
+			if capitalise && unicode.IsLetter(r) {
+				r = unicode.ToUpper(r)
+			}
+			capitalise = unicode.IsDigit(r)
+			goName.WriteRune(r)
+		}
+	}
+	return goName.String()
+}
+
+// getVariableName returns the unexported Go name for an attribute, such as "sslOcspIssuers".
+func getVariableName(attribute string) string {
+	goName := getGoName(attribute)
+	return strings.ToLower(goName[:1]) + goName[1:]
+}
+
+/*
+getCommentLines wraps a description as the generated code has always done: a line is broken at the
+first space at least 60 characters into it, and the lines which are carried on are indented by an
+extra space. Line breaks in the description are kept.
+*/
+func getCommentLines(description string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(description, "\n") {
+		prefix := "// "
+		start := 0
+		for i := 0; i < len(paragraph); i++ {
+			if paragraph[i] == ' ' && i-start >= 60 {
+				lines = append(lines, prefix+paragraph[start:i])
+				prefix = "//  "
+				start = i + 1
+			}
+		}
+		lines = append(lines, prefix+paragraph[start:])
+	}
+	return lines
+}
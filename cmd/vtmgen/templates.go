@@ -0,0 +1,761 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+package main
+
+import "text/template"
+
+func newTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).Parse(text))
+}
+
+const libraryHeader = copyrightHeader + `
+// Go library for Pulse Virtual Traffic Manager REST version {{.Version}}.
+package vtm
+`
+
+const libraryListFunction = `
+func (vtm VirtualTrafficManager) List{{.GoName}}s() (*[]string, *vtmErrorResponse) {
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}")
+	data, err := conn.get()
+	if err != nil {
+		return nil, err
+	}
+	objectList := new(vtmObjectChildren)
+	if decodeErr := json.NewDecoder(data).Decode(objectList); decodeErr != nil {
+		return nil, newDecodeError(decodeErr)
+	}
+	var stringList []string
+	for _, obj := range objectList.Children {
+		stringList = append(stringList, obj.Name)
+	}
+	return &stringList, nil
+}
+`
+
+const libraryApplyFunction = `
+func (object {{.GoName}}) Apply() (*{{.GoName}}, *vtmErrorResponse) {
+	marshalled, encodeErr := json.Marshal(object)
+	if encodeErr != nil {
+		return nil, newEncodeError(encodeErr)
+	}
+	data, err := object.connector.put(string(marshalled), STANDARD_OBJ)
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr := json.NewDecoder(data).Decode(&object); decodeErr != nil {
+		return nil, newDecodeError(decodeErr)
+	}
+	return &object, nil
+}
+`
+
+const libraryProperties = `
+type {{.GoName}}Properties struct {
+{{.LibrarySections}}}
+{{.LibraryTables}}`
+
+var libraryStandardTemplate = newTemplate("library_standard", libraryHeader+`
+import (
+	"encoding/json"
+)
+
+type {{.GoName}} struct {
+	connector *vtmConnector
+	{{.GoName}}Properties `+"`json:\"properties\"`"+`
+}
+
+func (vtm VirtualTrafficManager) Get{{.GoName}}(name string) (*{{.GoName}}, *vtmErrorResponse) {
+	if name == "" {
+		return nil, newParameterError("Provided an empty \"name\" parameter to VirtualTrafficManager.Get{{.GoName}}(name)")
+	}
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}/" + name{{.ExpertKeysQuery}})
+	data, err := conn.get()
+	if err != nil {
+		return nil, err
+	}
+	object := new({{.GoName}})
+	object.connector = conn
+	if decodeErr := json.NewDecoder(data).Decode(object); decodeErr != nil {
+		return nil, newDecodeError(decodeErr)
+	}
+	return object, nil
+}
+`+libraryApplyFunction+`
+func (vtm VirtualTrafficManager) New{{.GoName}}({{.NewParameters}}) *{{.GoName}} {
+	object := new({{.GoName}})
+{{.NewAssignments}}	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}/" + name)
+	object.connector = conn
+	return object
+}
+
+func (vtm VirtualTrafficManager) Delete{{.GoName}}(name string) *vtmErrorResponse {
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}/" + name)
+	_, err := conn.delete()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+`+libraryListFunction+libraryProperties)
+
+var librarySingletonTemplate = newTemplate("library_singleton", libraryHeader+`
+import (
+	"encoding/json"
+)
+
+type {{.GoName}} struct {
+	connector *vtmConnector
+	{{.GoName}}Properties `+"`json:\"properties\"`"+`
+}
+
+func (vtm VirtualTrafficManager) Get{{.GoName}}() (*{{.GoName}}, *vtmErrorResponse) {
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}{{.ExpertKeysSuffix}}")
+	data, err := conn.get()
+	if err != nil {
+		return nil, err
+	}
+	object := new({{.GoName}})
+	object.connector = conn
+	if decodeErr := json.NewDecoder(data).Decode(object); decodeErr != nil {
+		return nil, newDecodeError(decodeErr)
+	}
+	return object, nil
+}
+`+libraryApplyFunction+libraryProperties)
+
+var libraryTextTemplate = newTemplate("library_text", libraryHeader+`
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+`+libraryListFunction+`
+func (vtm VirtualTrafficManager) Get{{.GoName}}(name string) (string, *vtmErrorResponse) {
+	if name == "" {
+		return "", newParameterError("Provided an empty \"name\" parameter to VirtualTrafficManager.Get{{.GoName}}(name)")
+	}
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}/" + name)
+	data, err := conn.get()
+	if err != nil {
+		return "", err
+	}
+	bodyText, readErr := ioutil.ReadAll(data)
+	if readErr != nil {
+		return "", newDecodeError(readErr)
+	}
+	return string(bodyText), nil
+}
+
+func (vtm VirtualTrafficManager) Set{{.GoName}}(name, content string) *vtmErrorResponse {
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}/" + name)
+	_, err := conn.put(content, TEXT_ONLY_OBJ)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (vtm VirtualTrafficManager) Delete{{.GoName}}(name string) *vtmErrorResponse {
+	conn := vtm.connector.getChildConnector("/tm/" + vtm.apiVersion + "/config/active/{{.Path}}/" + name)
+	_, err := conn.delete()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+`)
+
+var libraryStatisticsTemplate = newTemplate("library_statistics", libraryHeader+`
+import (
+	"encoding/json"
+)
+
+type {{.GoName}}Statistics struct {
+{{.LibrarySections}}}
+{{if .Singleton}}
+func (vtm VirtualTrafficManager) Get{{.GoName}}Statistics() (*{{.GoName}}Statistics, *vtmErrorResponse) {
+	conn := vtm.connector.getChildConnector(vtm.statusPath() + "/statistics/{{.Path}}")
+{{- else}}
+func (vtm VirtualTrafficManager) Get{{.GoName}}Statistics(name string) (*{{.GoName}}Statistics, *vtmErrorResponse) {
+	conn := vtm.connector.getChildConnector(vtm.statusPath() + "/statistics/{{.Path}}/" + name)
+{{- end}}
+	data, err := conn.get()
+	if err != nil {
+		return nil, err
+	}
+	object := new({{.GoName}}Statistics)
+	if decodeErr := json.NewDecoder(data).Decode(object); decodeErr != nil {
+		return nil, newDecodeError(decodeErr)
+	}
+	return object, nil
+}
+`)
+
+const providerHeader = copyrightHeader + `
+package main
+`
+
+const resourceReadRecover = `
+	var lastAssignedField string
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			readError = fmt.Errorf("Field '%s' missing from vTM configuration", lastAssignedField)
+		}
+	}()
+`
+
+const resourceNameSchema = `
+		"name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+`
+
+const resourceNamedRead = `
+func resource{{.GoName}}Read(d *schema.ResourceData, tm interface{}) (readError error) {
+	objectName := d.Get("name").(string)
+	if objectName == "" {
+		objectName = d.Id()
+		d.Set("name", objectName)
+	}
+	object, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}(objectName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_{{.Label}} '%v': %v", objectName, err.ErrorText)
+	}
+`
+
+const resourceNamedExists = `
+func resource{{.GoName}}Exists(d *schema.ResourceData, tm interface{}) (bool, error) {
+	objectName := d.Get("name").(string)
+	if objectName == "" {
+		objectName = d.Id()
+	}
+	_, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}(objectName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			return false, nil
+		}
+		return false, fmt.Errorf("%v", err.ErrorText)
+	}
+	return true, nil
+}
+`
+
+const resourceNamedDelete = `
+func resource{{.GoName}}Delete(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	err := tm.(*vtm.VirtualTrafficManager).Delete{{.GoName}}(objectName)
+	if err != nil {
+		return fmt.Errorf("Failed to delete vtm_{{.Label}} '%v': %v", objectName, err.ErrorText)
+	}
+	d.SetId("")
+	return nil
+}
+`
+
+var resourceStandardTemplate = newTemplate("resource_standard", providerHeader+`
+import (
+{{- if .HasTables}}
+	"encoding/json"
+{{- end}}
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func resource{{.GoName}}() *schema.Resource {
+	return &schema.Resource{
+		Read:   resource{{.GoName}}Read,
+		Exists: resource{{.GoName}}Exists,
+		Create: resource{{.GoName}}Create,
+		Update: resource{{.GoName}}Update,
+		Delete: resource{{.GoName}}Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: getResource{{.GoName}}Schema(),
+	}
+}
+
+func getResource{{.GoName}}Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+`+resourceNameSchema+`{{.ResourceSchema}}	}
+}
+`+resourceNamedRead+`
+	fillUnsupportedFields(d, tm, object)
+`+resourceReadRecover+`
+{{.ReadAssignments}}	d.SetId(objectName)
+	return nil
+}
+`+resourceNamedExists+`
+func resource{{.GoName}}Create(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	object := tm.(*vtm.VirtualTrafficManager).New{{.GoName}}({{.NewArguments}})
+	resource{{.GoName}}ObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResource{{.GoName}}Schema()); err != nil {
+		return fmt.Errorf("Error creating vtm_{{.Label}} '%s': %v", objectName, err)
+	}
+	_, applyErr := object.Apply()
+	if applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("Error creating vtm_{{.Label}} '%s': %s %s", objectName, applyErr.ErrorText, info)
+	}
+	d.SetId(objectName)
+	return nil
+}
+
+func resource{{.GoName}}Update(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	object, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}(objectName)
+	if err != nil {
+		return fmt.Errorf("Failed to update vtm_{{.Label}} '%v': %v", objectName, err)
+	}
+	resource{{.GoName}}ObjectFieldAssignments(d, object)
+	if err := stripUnsupportedFields(tm, object, getResource{{.GoName}}Schema()); err != nil {
+		return fmt.Errorf("Error updating vtm_{{.Label}} '%s': %v", objectName, err)
+	}
+	_, applyErr := object.Apply()
+	if applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("Error updating vtm_{{.Label}} '%s': %s %s", objectName, applyErr.ErrorText, info)
+	}
+	d.SetId(objectName)
+	return nil
+}
+
+func resource{{.GoName}}ObjectFieldAssignments(d *schema.ResourceData, object *vtm.{{.GoName}}) {
+{{.FieldAssignments}}}
+`+resourceNamedDelete)
+
+var resourceSingletonTemplate = newTemplate("resource_singleton", providerHeader+`
+import (
+{{- if .HasTables}}
+	"encoding/json"
+{{- end}}
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+{{- if .UsesValidation}}
+	"github.com/hashicorp/terraform/helper/validation"
+{{- end}}
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func resource{{.GoName}}() *schema.Resource {
+	return &schema.Resource{
+		Read:   resource{{.GoName}}Read,
+		Create: resource{{.GoName}}Update,
+		Update: resource{{.GoName}}Update,
+		Delete: resource{{.GoName}}Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: getResource{{.GoName}}Schema(),
+	}
+}
+
+func getResource{{.GoName}}Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+{{.ResourceSchema}}	}
+}
+
+func resource{{.GoName}}Read(d *schema.ResourceData, tm interface{}) (readError error) {
+	object, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}()
+	if err != nil {
+		return fmt.Errorf("Failed to read vtm_{{.Label}}: %v", err.ErrorText)
+	}
+
+	fillUnsupportedFields(d, tm, object)
+`+resourceReadRecover+`
+{{.ReadAssignments}}	d.SetId("{{.Label}}")
+	return nil
+}
+
+func resource{{.GoName}}Update(d *schema.ResourceData, tm interface{}) error {
+	object, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}()
+	if err != nil {
+		return fmt.Errorf("Failed to update vtm_{{.Label}}: %v", err)
+	}
+{{.FieldAssignments}}
+	if err := stripUnsupportedFields(tm, object, getResource{{.GoName}}Schema()); err != nil {
+		return fmt.Errorf("Error updating vtm_{{.Label}}: %v", err)
+	}
+	_, applyErr := object.Apply()
+	if applyErr != nil {
+		info := formatErrorInfo(applyErr.ErrorInfo)
+		return fmt.Errorf("Error updating vtm_{{.Label}}: %s %s", applyErr.ErrorText, info)
+	}
+	d.SetId("{{.Label}}")
+	return nil
+}
+
+func resource{{.GoName}}Delete(d *schema.ResourceData, tm interface{}) error {
+	d.SetId("")
+	return nil
+}
+`)
+
+var resourceTextTemplate = newTemplate("resource_text", providerHeader+`
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func resource{{.GoName}}() *schema.Resource {
+	return &schema.Resource{
+		Read:   resource{{.GoName}}Read,
+		Exists: resource{{.GoName}}Exists,
+		Create: resource{{.GoName}}Create,
+		Update: resource{{.GoName}}Update,
+		Delete: resource{{.GoName}}Delete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: getResource{{.GoName}}Schema(),
+	}
+}
+
+func getResource{{.GoName}}Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+`+resourceNameSchema+`{{.ResourceSchema}}	}
+}
+`+resourceNamedRead+resourceReadRecover+`
+	d.Set("content", object)
+	d.SetId(objectName)
+	return nil
+}
+`+resourceNamedExists+`
+func resource{{.GoName}}Create(d *schema.ResourceData, tm interface{}) error {
+	err := resource{{.GoName}}Update(d, tm)
+	if err != nil {
+		return fmt.Errorf("%v", strings.Replace(err.Error(), "update", "create", 1))
+	}
+	return nil
+}
+
+func resource{{.GoName}}Update(d *schema.ResourceData, tm interface{}) error {
+	objectName := d.Get("name").(string)
+	objectContent := d.Get("content").(string)
+	err := tm.(*vtm.VirtualTrafficManager).Set{{.GoName}}(objectName, objectContent)
+	if err != nil {
+		return fmt.Errorf("Failed to create vtm_{{.Label}} '%v': %v", objectName, err.ErrorText)
+	}
+	d.SetId(objectName)
+	return nil
+}
+`+resourceNamedDelete)
+
+var resourceTestTemplate = newTemplate("resource_test", providerHeader+`
+/*
+ * This test covers the following cases:
+ *   - Creation and deletion of a vtm_{{.Name}} object with minimal configuration
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func TestResource{{.GoName}}(t *testing.T) {
+	objName := acctest.RandomWithPrefix("Test{{.GoName}}")
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheck{{.GoName}}Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getBasic{{.GoName}}Config(objName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck{{.GoName}}Exists,
+				),
+			},
+		},
+	})
+}
+
+func testAccCheck{{.GoName}}Exists(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_{{.Name}}" {
+			continue
+		}
+		objectName := tfResource.Primary.Attributes["name"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.Get{{.GoName}}(objectName); err != nil {
+			return fmt.Errorf("{{.GoName}} %s does not exist: %#v", objectName, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheck{{.GoName}}Destroy(s *terraform.State) error {
+	for _, tfResource := range s.RootModule().Resources {
+		if tfResource.Type != "vtm_{{.Name}}" {
+			continue
+		}
+		objectName := tfResource.Primary.Attributes["name"]
+		tm := testAccProvider.Meta().(*vtm.VirtualTrafficManager)
+		if _, err := tm.Get{{.GoName}}(objectName); err == nil {
+			return fmt.Errorf("{{.GoName}} %s still exists", objectName)
+		}
+	}
+
+	return nil
+}
+
+func getBasic{{.GoName}}Config(name string) string {
+{{- if .TestSetup}}
+	tm, _ := getTestVtm()
+{{- range .TestSetup}}
+	{{.}}
+{{- end}}
+{{- end}}
+	return fmt.Sprintf(`+"`"+`
+        resource "vtm_{{.Name}}" "test_vtm_{{.Name}}" {
+			name = "%s"
+{{.TestAttributes}}
+        }`+"`"+`,
+		name,
+	)
+}
+`)
+
+var resourceSingletonTestTemplate = newTemplate("resource_singleton_test", providerHeader+`
+/*
+ * This test covers the following cases:
+ *   - Creation and deletion of a vtm_{{.Name}} object with minimal configuration
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResource{{.GoName}}(t *testing.T) {
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: getBasic{{.GoName}}Config(),
+			},
+		},
+	})
+}
+
+func getBasic{{.GoName}}Config() string {
+	return fmt.Sprintf(`+"`"+`
+        resource "vtm_{{.Name}}" "test_vtm_{{.Name}}" {
+        }`+"`"+`,
+	)
+}
+`)
+
+var dataSourceConfigTemplate = newTemplate("data_source_config", providerHeader+`
+import "github.com/hashicorp/terraform/helper/schema"
+
+func dataSource{{.GoName}}() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSource{{.GoName}}Read,
+		Schema: setAllNotRequired(getResource{{.GoName}}Schema()),
+	}
+}
+
+func dataSource{{.GoName}}Read(d *schema.ResourceData, tm interface{}) error {
+	return resource{{.GoName}}Read(d, tm)
+}
+`)
+
+var dataSourceListTemplate = newTemplate("data_source_list", providerHeader+`
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func dataSource{{.GoName}}List() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSource{{.GoName}}ListRead,
+
+		Schema: map[string]*schema.Schema{
+			"object_list": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"starts_with": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ends_with": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"contains": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"regex_match": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+		},
+	}
+}
+
+func dataSource{{.GoName}}ListRead(d *schema.ResourceData, tm interface{}) error {
+	objectList, err := tm.(*vtm.VirtualTrafficManager).List{{.GoName}}s()
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Failed to read vtm_{{.Name}}_list: %v", err.ErrorText)
+	}
+
+	if starts_with, ok := d.GetOk("starts_with"); ok {
+		objectList = getStringListStartingWith(objectList, starts_with.(string))
+	}
+	if ends_with, ok := d.GetOk("ends_with"); ok {
+		objectList = getStringListEndingWith(objectList, ends_with.(string))
+	}
+	if contains, ok := d.GetOk("contains"); ok {
+		objectList = getStringListContaining(objectList, contains.(string))
+	}
+	var regexErr error
+	if regex_match, ok := d.GetOk("regex_match"); ok {
+		objectList, regexErr = getStringListMatchingRegex(objectList, regex_match.(string))
+		if regexErr != nil {
+			d.SetId("")
+			return regexErr
+		}
+	}
+
+	d.Set("object_list", objectList)
+	d.SetId("{{.Name}}_list")
+	return nil
+}
+`)
+
+var dataSourceTableTemplate = newTemplate("data_source_table", providerHeader+`
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+{{- if .TableUsesValidation}}
+	"github.com/hashicorp/terraform/helper/validation"
+{{- end}}
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func dataSource{{.TableGoName}}Table() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSource{{.TableGoName}}TableRead,
+
+		Schema: map[string]*schema.Schema{
+			// JSON output string
+			"json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+{{.TableSchema}}		},
+	}
+}
+
+func dataSource{{.TableGoName}}TableRead(d *schema.ResourceData, tm interface{}) error {
+	table := &vtm.{{.TableGoName}}{
+{{.TableRow}}	}
+	jsonString, err := json.Marshal(table)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal table to JSON: %s", err)
+	}
+	d.Set("json", string(jsonString))
+	d.SetId("{{.TableGoName}}")
+	return nil
+}
+`)
+
+var dataSourceStatisticsTemplate = newTemplate("data_source_statistics", copyrightHeader+`
+// Data Source Object {{.GoName}}
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+{{- if not .Singleton}}
+	"github.com/hashicorp/terraform/helper/validation"
+{{- end}}
+	vtm "github.com/pulse-vadc/go-vtm/{{.Version}}"
+)
+
+func dataSource{{.GoName}}Statistics() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSource{{.GoName}}StatisticsRead,
+		Schema: map[string]*schema.Schema{
+{{- if not .Singleton}}
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+{{- end}}
+{{.StatisticsSchema}}		},
+	}
+}
+{{if .Singleton}}
+func dataSource{{.GoName}}StatisticsRead(d *schema.ResourceData, tm interface{}) (readError error) {
+	object, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}Statistics()
+	if err != nil {
+		return fmt.Errorf("Failed to read vtm_{{.Label}}: %v", err.ErrorText)
+	}
+{{- else}}
+func dataSource{{.GoName}}StatisticsRead(d *schema.ResourceData, tm interface{}) (readError error) {
+	objectName := d.Get("name").(string)
+	object, err := tm.(*vtm.VirtualTrafficManager).Get{{.GoName}}Statistics(objectName)
+	if err != nil {
+		if err.ErrorId == "resource.not_found" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to read vtm_{{.StatisticsLabel}} '%v': %v", objectName, err.ErrorText)
+	}
+{{- end}}
+
+	fillUnsupportedFields(d, tm, object)
+`+resourceReadRecover+`{{.ReadAssignments}}{{if .Singleton}}	d.SetId("{{.Label}}")
+{{else}}	d.SetId(objectName)
+{{end}}	return nil
+}
+`)
@@ -0,0 +1,932 @@
+// Copyright (C) 2018-2019, Pulse Secure, LLC.
+// Licensed under the terms of the MPL 2.0. See LICENSE file for details.
+
+/*
+ * Package fakevtm is an in-process fake of the vTM REST API, used to run the provider tests of
+ * every version without an appliance.
+ *
+ * The fake implements enough of the API for the provider and the tests to work against it:
+ *   - GET/PUT/DELETE of configuration objects under /api/tm/<version>/config/active, with
+ *     children listings for each collection and resource.not_found errors for missing objects
+ *   - Validation of configuration objects against the go-vtm types, returning error_info payloads
+ *     for unknown properties and properties of the wrong type
+ *   - Full backups under /api/tm/<version>/status/local_tm/backups/full, which hold a copy of the
+ *     configuration and can be downloaded and uploaded as archives (the JSON encoding of the
+ *     backup's properties and configuration).  Restoring a backup makes the whole API unavailable
+ *     for RestoreTime, after which the backup's configuration replaces the current one
+ *   - A state response describing the configured pools and virtual servers, with nodes reported
+ *     as failed on request
+ *   - Canned information and statistics responses under /api/tm/<version>/status/local_tm,
+ *     and under /api/tm/<version>/status/<traffic manager> for each traffic manager in the cluster,
+ *     with configured pools reported as active and nodes as alive, or dead on request
+ *
+ * Objects are returned with every property populated, as a real vTM does.  Properties which have
+ * never been written take the default values given by the provider's resource schemas, or their
+ * zero values where the schema has no default.  SSL private keys are only ever returned as a
+ * base64 encoded SHA-256 hash of the key.
+ *
+ * The go-vtm types and resource schemas differ between provider versions, so each version's tests
+ * describe their API with an Api, and start fakes of it with New.
+ */
+package fakevtm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// The credentials accepted by the fake, its vTM version and the name of its traffic manager.
+const (
+	Username = "admin"
+	Password = "fake-vtm-password"
+	Version  = "19.1"
+	Hostname = "fake-vtm.example.com"
+)
+
+// The length of time for which the API is unavailable while a backup is restored.
+const RestoreTime = 2 * time.Second
+
+// Collection describes the objects of a configuration collection.
+type Collection struct {
+	// The type of the properties of each object, or nil for text objects (rules, extra files, etc.)
+	Properties reflect.Type
+	// The Terraform resource for the objects, whose schema provides their default property values
+	Resource string
+}
+
+// Api describes the REST API of one provider version, by its go-vtm types and resource schemas.
+type Api struct {
+	// REST API versions offered by the fake
+	ApiVersions []string
+	// Configuration collections, keyed by path
+	Collections map[string]Collection
+	// Statistics resources, mapped to the type of their response.  Resources whose path ends in
+	// a slash return statistics for a named object.
+	Statistics map[string]reflect.Type
+	// The types of the information and state responses
+	Information reflect.Type
+	State       reflect.Type
+	// The provider's resources, keyed by name
+	Resources map[string]*schema.Resource
+}
+
+// Configuration objects which are not part of a collection.
+var singletons = map[string]bool{
+	"config/active/appliance/nat":   true,
+	"config/active/global_settings": true,
+	"config/active/security":        true,
+}
+
+// Objects which exist on every vTM.
+var initialObjects = []string{
+	"config/active/appliance/nat",
+	"config/active/global_settings",
+	"config/active/security",
+	"config/active/traffic_managers/" + Hostname,
+}
+
+// Default values of list properties, which are not given by the Terraform schema.
+var listDefaults = map[string][]string{
+	"appliance_ntpservers":                     {"0.zeus.pool.ntp.org", "1.zeus.pool.ntp.org", "2.zeus.pool.ntp.org", "3.zeus.pool.ntp.org"},
+	"cluster_comms_allowed_update_hosts":       {"all"},
+	"fault_tolerance_frontend_check_ips":       {"%gateway%"},
+	"fault_tolerance_ospfv2_neighbor_addrs":    {"%gateway%"},
+	"gzip_include_mime":                        {"text/html", "text/plain"},
+	"rest_api_bind_ips":                        {"*"},
+	"snmp_allow":                               {"all"},
+	"transaction_export_http_header_blacklist": {"Authorization"},
+}
+
+// Properties in the basic section of objects which are only reported as a hash of their value.
+var hashedProperties = map[string]string{
+	"vtm_ssl_client_key": "private",
+	"vtm_ssl_server_key": "private",
+}
+
+// Properties holds the sections of a configuration object, each mapping property names to values.
+type Properties map[string]map[string]json.RawMessage
+
+// The configuration objects and files under config/active, keyed by path.
+type configuration struct {
+	Objects map[string]Properties `json:"objects"`
+	Files   map[string][]byte     `json:"files"`
+}
+
+// The contents of a fake backup archive.
+type backupArchive struct {
+	Properties    Properties    `json:"properties"`
+	Configuration configuration `json:"configuration"`
+}
+
+// Server is a running fake vTM.
+type Server struct {
+	api     Api
+	server  *httptest.Server
+	mutex   sync.Mutex
+	objects map[string]Properties
+	files   map[string][]byte
+	// Statistics returned in place of zeroes, keyed by path
+	statistics map[string]interface{}
+	// Nodes reported as failed by the state response
+	failedNodes map[string]bool
+	// Names of the backups that have been restored, in order
+	restoredBackups []string
+	// The configuration held by each backup, keyed by path
+	backupConfigurations map[string]configuration
+	// The configuration being restored, which replaces the current one at restoreTime
+	restoring   *configuration
+	restoreTime time.Time
+}
+
+/*
+New starts a fake vTM of api listening on the loopback interface. The base URL of its REST API is
+"<server URL>/api", and it accepts the Username and Password credentials.
+*/
+func New(api Api) *Server {
+	fake := &Server{
+		api:     api,
+		objects: make(map[string]Properties),
+		files:   make(map[string][]byte),
+
+		statistics:           make(map[string]interface{}),
+		failedNodes:          make(map[string]bool),
+		backupConfigurations: make(map[string]configuration),
+	}
+	for _, path := range initialObjects {
+		collection := api.Collections[path[:strings.LastIndex(path, "/")]]
+		if singletons[path] == true {
+			collection = api.Collections[path]
+		}
+		fake.objects[path] = fake.getDefaults(collection)
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	return fake
+}
+
+// URL returns the URL of the fake's HTTP server.
+func (fake *Server) URL() string {
+	return fake.server.URL
+}
+
+// BaseUrl returns the base URL of the fake's REST API.
+func (fake *Server) BaseUrl() string {
+	// The tests rewrite the host name and port of the base URL with regexes that do not allow for
+	// an IP address, so a host name is used instead.
+	return strings.Replace(fake.server.URL, "127.0.0.1", "localhost", 1) + "/api"
+}
+
+// Close stops the fake, after which every request to it fails.
+func (fake *Server) Close() {
+	fake.server.Close()
+}
+
+// HasObject reports whether a configuration object exists, for example "config/active/pools/web".
+func (fake *Server) HasObject(path string) bool {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	_, exists := fake.objects[path]
+	return exists
+}
+
+// RestoredBackups returns the names of the backups that have been restored, in order.
+func (fake *Server) RestoredBackups() []string {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]string{}, fake.restoredBackups...)
+}
+
+/*
+SetStatistics sets the statistics returned for a resource under status/local_tm/statistics, for
+example "nodes/node/192.168.0.1:80". The statistics are given as the go-vtm type for the resource,
+and any which are left nil are returned as zero; nil statistics restores the default response.
+*/
+func (fake *Server) SetStatistics(resource string, statistics interface{}) {
+	fake.SetTrafficManagerStatistics("local_tm", resource, statistics)
+}
+
+// SetTrafficManagerStatistics is SetStatistics for the named traffic manager in the cluster.
+func (fake *Server) SetTrafficManagerStatistics(trafficManager, resource string, statistics interface{}) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	path := "status/" + getStatusTarget(trafficManager) + "/statistics/" + resource
+	if statistics == nil {
+		delete(fake.statistics, path)
+	} else {
+		fake.statistics[path] = statistics
+	}
+}
+
+// The local traffic manager can be referred to either as local_tm or by its name.
+func getStatusTarget(trafficManager string) string {
+	if trafficManager == Hostname {
+		return "local_tm"
+	}
+	return trafficManager
+}
+
+// SetNodeFailed sets whether a node is reported as failed, in every pool that contains it.
+func (fake *Server) SetNodeFailed(node string, failed bool) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if failed == true {
+		fake.failedNodes[node] = true
+	} else {
+		delete(fake.failedNodes, node)
+	}
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, errorId, errorText string, errorInfo interface{}) {
+	body := map[string]interface{}{"error_id": errorId, "error_text": errorText}
+	if errorInfo != nil {
+		body["error_info"] = errorInfo
+	}
+	writeJson(w, status, body)
+}
+
+// WriteChildren writes a children listing, as returned for a collection, of the named resources under href.
+func WriteChildren(w http.ResponseWriter, href string, names []string) {
+	sort.Strings(names)
+	children := make([]map[string]string, 0, len(names))
+	for _, name := range names {
+		children = append(children, map[string]string{"name": name, "href": href + name})
+	}
+	writeJson(w, http.StatusOK, map[string]interface{}{"children": children})
+}
+
+func (fake *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if ok != true || username != Username || password != Password {
+		writeError(w, http.StatusUnauthorized, "auth.invalid", "Invalid username or password", nil)
+		return
+	}
+
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	if fake.restoring != nil {
+		if time.Now().Before(fake.restoreTime) {
+			writeError(w, http.StatusServiceUnavailable, "service.unavailable", "A backup is being restored", nil)
+			return
+		}
+		fake.setConfiguration(*fake.restoring)
+		fake.restoring = nil
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "api":
+		WriteChildren(w, "/api/", []string{"tm"})
+		return
+	case path == "api/tm":
+		WriteChildren(w, "/api/tm/", append([]string{}, fake.api.ApiVersions...))
+		return
+	case !strings.HasPrefix(path, "api/tm/"):
+		fake.notFound(w, r)
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(path, "api/tm/"), "/", 2)
+	if len(parts) != 2 {
+		WriteChildren(w, "/"+path+"/", []string{"config", "status"})
+		return
+	}
+	resource := parts[1]
+
+	// Status requests for another member of the cluster are answered as if they were local,
+	// apart from the statistics set for that member.
+	target := "local_tm"
+	if statusParts := strings.SplitN(resource, "/", 3); len(statusParts) == 3 && statusParts[0] == "status" {
+		target = getStatusTarget(statusParts[1])
+		if _, exists := fake.objects["config/active/traffic_managers/"+target]; target != "local_tm" && exists != true {
+			fake.notFound(w, r)
+			return
+		}
+		resource = "status/local_tm/" + statusParts[2]
+	}
+
+	switch {
+	case resource == "status/local_tm/information":
+		fake.serveInformation(w, r)
+	case resource == "status/local_tm/state":
+		fake.serveState(w, r)
+	case strings.HasPrefix(resource, "status/local_tm/statistics/"):
+		fake.serveStatistics(w, r, target, resource)
+	case target != "local_tm":
+		fake.notFound(w, r)
+	default:
+		fake.serveConfiguration(w, r, resource)
+	}
+}
+
+func (fake *Server) notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, "resource.not_found", fmt.Sprintf("The requested resource '%s' could not be found", r.URL.Path), nil)
+}
+
+func (fake *Server) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "request.method_not_allowed", fmt.Sprintf("Method %s is not allowed for '%s'", r.Method, r.URL.Path), nil)
+}
+
+// writeStatus writes a status response of the given type, holding the given values and zeroes elsewhere.
+func writeStatus(w http.ResponseWriter, statusType reflect.Type, values map[string]interface{}) {
+	status := reflect.New(statusType)
+	encoded, _ := json.Marshal(values)
+	json.Unmarshal(encoded, status.Interface())
+	fillValue(status)
+	writeJson(w, http.StatusOK, status.Interface())
+}
+
+func (fake *Server) serveInformation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		fake.methodNotAllowed(w, r)
+		return
+	}
+	// Versions before 6.2 report no platform, which their type ignores.
+	writeStatus(w, fake.api.Information, map[string]interface{}{"information": map[string]interface{}{
+		"platform":   "fake",
+		"tm_version": Version,
+		"uuid":       "00000000-0000-0000-0000-000000000000",
+	}})
+}
+
+func (fake *Server) serveState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		fake.methodNotAllowed(w, r)
+		return
+	}
+	state := fake.getState()
+	state["license"] = "developer"
+	writeStatus(w, fake.api.State, map[string]interface{}{"state": state})
+}
+
+// getState describes the nodes of each pool and the enabled virtual servers, as the state response does.
+func (fake *Server) getState() map[string]interface{} {
+	paths := make([]string, 0, len(fake.objects))
+	for path := range fake.objects {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	pools := []map[string]interface{}{}
+	virtualServers := []map[string]interface{}{}
+	failedPools := make(map[string][]string)
+	failedNodes := []map[string]interface{}{}
+	for _, path := range paths {
+		basic := fake.objects[path]["basic"]
+		name := path[strings.LastIndex(path, "/")+1:]
+		switch {
+		case strings.HasPrefix(path, "config/active/pools/"):
+			var nodesTable []struct {
+				Node  string `json:"node"`
+				State string `json:"state"`
+			}
+			var failurePool string
+			json.Unmarshal(basic["nodes_table"], &nodesTable)
+			json.Unmarshal(basic["failure_pool"], &failurePool)
+			nodes := map[string][]string{"active": {}, "disabled": {}, "draining": {}}
+			for _, node := range nodesTable {
+				if fake.failedNodes[node.Node] == true && node.State != "disabled" {
+					if len(failedPools[node.Node]) == 0 {
+						failedNodes = append(failedNodes, map[string]interface{}{"node": node.Node})
+					}
+					failedPools[node.Node] = append(failedPools[node.Node], name)
+					continue
+				}
+				nodes[node.State] = append(nodes[node.State], node.Node)
+			}
+			pools = append(pools, map[string]interface{}{
+				"name":           name,
+				"active_nodes":   nodes["active"],
+				"disabled_nodes": nodes["disabled"],
+				"draining_nodes": nodes["draining"],
+				"failure_pool":   failurePool,
+			})
+		case strings.HasPrefix(path, "config/active/virtual_servers/"):
+			var enabled bool
+			var pool string
+			var port int
+			json.Unmarshal(basic["enabled"], &enabled)
+			json.Unmarshal(basic["pool"], &pool)
+			json.Unmarshal(basic["port"], &port)
+			if enabled == true {
+				virtualServers = append(virtualServers, map[string]interface{}{"name": name, "pool": pool, "port": port})
+			}
+		}
+	}
+	errorLevel := "ok"
+	for _, failedNode := range failedNodes {
+		failedNode["pools"] = failedPools[failedNode["node"].(string)]
+		errorLevel = "error"
+	}
+	return map[string]interface{}{
+		"error_level":     errorLevel,
+		"failed_nodes":    failedNodes,
+		"pools":           pools,
+		"virtual_servers": virtualServers,
+	}
+}
+
+func (fake *Server) serveStatistics(w http.ResponseWriter, r *http.Request, target, resource string) {
+	if r.Method != "GET" {
+		fake.methodNotAllowed(w, r)
+		return
+	}
+	statisticsPath := strings.TrimPrefix(resource, "status/local_tm/statistics/")
+	statisticsType, ok := fake.api.Statistics[statisticsPath]
+	if ok != true {
+		slash := strings.LastIndex(statisticsPath, "/")
+		statisticsType, ok = fake.api.Statistics[statisticsPath[:slash+1]]
+	}
+	if ok != true {
+		fake.notFound(w, r)
+		return
+	}
+	statistics := reflect.New(statisticsType)
+	if override, ok := fake.statistics["status/"+target+strings.TrimPrefix(resource, "status/local_tm")]; ok == true {
+		encoded, _ := json.Marshal(override)
+		json.Unmarshal(encoded, statistics.Interface())
+	} else if state := fake.getStatisticsState(statisticsPath); state != "" {
+		encoded, _ := json.Marshal(map[string]interface{}{"statistics": map[string]interface{}{"state": state}})
+		json.Unmarshal(encoded, statistics.Interface())
+	}
+	fillValue(statistics)
+	writeJson(w, http.StatusOK, statistics.Interface())
+}
+
+/*
+getStatisticsState returns the state given by the default statistics of a pool, which is "active"
+for configured pools, or of a node, which is "dead" for failed nodes and otherwise "alive".
+*/
+func (fake *Server) getStatisticsState(statisticsPath string) string {
+	switch {
+	case strings.HasPrefix(statisticsPath, "pools/"):
+		if _, exists := fake.objects["config/active/"+statisticsPath]; exists == true {
+			return "active"
+		}
+	case strings.HasPrefix(statisticsPath, "nodes/node/"):
+		if fake.failedNodes[strings.TrimPrefix(statisticsPath, "nodes/node/")] == true {
+			return "dead"
+		}
+		return "alive"
+	}
+	return ""
+}
+
+func (fake *Server) serveConfiguration(w http.ResponseWriter, r *http.Request, resource string) {
+	if collection, ok := fake.api.Collections[resource]; ok == true {
+		if singletons[resource] == true {
+			fake.serveObject(w, r, resource, collection)
+		} else {
+			fake.serveCollection(w, r, resource)
+		}
+		return
+	}
+	if slash := strings.LastIndex(resource, "/"); slash >= 0 {
+		collectionPath := resource[:slash]
+		if collection, ok := fake.api.Collections[collectionPath]; ok == true && singletons[collectionPath] != true {
+			fake.serveObject(w, r, resource, collection)
+			return
+		}
+	}
+	fake.notFound(w, r)
+}
+
+func (fake *Server) serveCollection(w http.ResponseWriter, r *http.Request, collection string) {
+	if r.Method != "GET" {
+		fake.methodNotAllowed(w, r)
+		return
+	}
+	names := []string{}
+	prefix := collection + "/"
+	for path := range fake.objects {
+		if strings.HasPrefix(path, prefix) && !strings.Contains(path[len(prefix):], "/") {
+			names = append(names, path[len(prefix):])
+		}
+	}
+	for path := range fake.files {
+		if strings.HasPrefix(path, prefix) && !strings.Contains(path[len(prefix):], "/") {
+			names = append(names, path[len(prefix):])
+		}
+	}
+	WriteChildren(w, r.URL.Path+"/", names)
+}
+
+func (fake *Server) serveObject(w http.ResponseWriter, r *http.Request, path string, collection Collection) {
+	if collection.Resource == "vtm_backups_full" && fake.serveBackup(w, r, path, collection) == true {
+		return
+	}
+	switch r.Method {
+	case "GET":
+		if collection.Properties == nil {
+			content, ok := fake.files[path]
+			if ok != true {
+				fake.notFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(content)
+			return
+		}
+		properties, ok := fake.objects[path]
+		if ok != true {
+			fake.notFound(w, r)
+			return
+		}
+		fake.writeObject(w, http.StatusOK, properties, collection.Properties)
+
+	case "PUT":
+		body, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			writeError(w, http.StatusBadRequest, "request.read_failed", readErr.Error(), nil)
+			return
+		}
+		if collection.Properties == nil {
+			_, exists := fake.files[path]
+			fake.files[path] = body
+			if exists == true {
+				w.WriteHeader(http.StatusNoContent)
+			} else {
+				w.WriteHeader(http.StatusCreated)
+			}
+			return
+		}
+		fake.putObject(w, path, body, collection)
+
+	case "DELETE":
+		_, isObject := fake.objects[path]
+		_, isFile := fake.files[path]
+		if isObject != true && isFile != true {
+			fake.notFound(w, r)
+			return
+		}
+		delete(fake.objects, path)
+		delete(fake.files, path)
+		delete(fake.backupConfigurations, path)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		fake.methodNotAllowed(w, r)
+	}
+}
+
+// getConfiguration returns a copy of the configuration objects and files.
+func (fake *Server) getConfiguration() configuration {
+	current := configuration{
+		Objects: make(map[string]Properties),
+		Files:   make(map[string][]byte),
+	}
+	for path, properties := range fake.objects {
+		if strings.HasPrefix(path, "config/active/") {
+			current.Objects[path] = copyProperties(properties)
+		}
+	}
+	for path, content := range fake.files {
+		if strings.HasPrefix(path, "config/active/") {
+			current.Files[path] = content
+		}
+	}
+	return current
+}
+
+// setConfiguration replaces the configuration objects and files with a copy of replacement.
+func (fake *Server) setConfiguration(replacement configuration) {
+	for path := range fake.objects {
+		if strings.HasPrefix(path, "config/active/") {
+			delete(fake.objects, path)
+		}
+	}
+	for path := range fake.files {
+		if strings.HasPrefix(path, "config/active/") {
+			delete(fake.files, path)
+		}
+	}
+	for path, properties := range replacement.Objects {
+		fake.objects[path] = copyProperties(properties)
+	}
+	for path, content := range replacement.Files {
+		fake.files[path] = content
+	}
+}
+
+func copyProperties(properties Properties) Properties {
+	copied := make(Properties)
+	for section, fields := range properties {
+		copied[section] = make(map[string]json.RawMessage)
+		for field, value := range fields {
+			copied[section][field] = value
+		}
+	}
+	return copied
+}
+
+// serveBackup handles the requests for backup archives and restores, returning false for any other request.
+func (fake *Server) serveBackup(w http.ResponseWriter, r *http.Request, path string, collection Collection) bool {
+	const archiveType = "application/x-tar"
+	switch {
+	case r.Method == "GET" && r.Header.Get("Accept") == archiveType:
+		properties, ok := fake.objects[path]
+		if ok != true {
+			fake.notFound(w, r)
+			return true
+		}
+		archive, _ := json.Marshal(backupArchive{properties, fake.backupConfigurations[path]})
+		w.Header().Set("Content-Type", archiveType)
+		w.Write(archive)
+		return true
+
+	case r.Method == "PUT" && r.Header.Get("Content-Type") == archiveType:
+		body, _ := ioutil.ReadAll(r.Body)
+		properties := fake.getDefaults(collection)
+		var archived backupArchive
+		if decodeErr := json.Unmarshal(body, &archived); decodeErr != nil || archived.Properties["backup"] == nil {
+			writeError(w, http.StatusBadRequest, "backup.invalid_archive", "The backup archive is invalid", nil)
+			return true
+		}
+		for field, value := range archived.Properties["backup"] {
+			properties["backup"][field] = value
+		}
+		_, exists := fake.objects[path]
+		fake.objects[path] = properties
+		fake.backupConfigurations[path] = archived.Configuration
+		if exists == true {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+		return true
+
+	case r.Method == "PUT":
+		body, _ := ioutil.ReadAll(r.Body)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		request := struct {
+			Properties struct {
+				Backup struct {
+					Restore bool `json:"restore"`
+				} `json:"backup"`
+			} `json:"properties"`
+		}{}
+		if json.Unmarshal(body, &request) != nil || request.Properties.Backup.Restore != true {
+			// A new backup holds a copy of the current configuration.
+			if _, exists := fake.objects[path]; exists != true {
+				fake.backupConfigurations[path] = fake.getConfiguration()
+			}
+			return false
+		}
+		if _, ok := fake.objects[path]; ok != true {
+			fake.notFound(w, r)
+			return true
+		}
+		fake.restoredBackups = append(fake.restoredBackups, path[strings.LastIndex(path, "/")+1:])
+		restored, ok := fake.backupConfigurations[path]
+		if ok != true {
+			restored = fake.getConfiguration()
+		}
+		fake.restoring = &restored
+		fake.restoreTime = time.Now().Add(RestoreTime)
+		w.WriteHeader(http.StatusNoContent)
+		return true
+	}
+	return false
+}
+
+func (fake *Server) putObject(w http.ResponseWriter, path string, body []byte, collection Collection) {
+	request := struct {
+		Properties Properties `json:"properties"`
+	}{}
+	if decodeErr := json.Unmarshal(body, &request); decodeErr != nil {
+		writeError(w, http.StatusBadRequest, "json.invalid", fmt.Sprintf("Invalid JSON document: %v", decodeErr), nil)
+		return
+	}
+	errorInfo := ValidateProperties(request.Properties, collection.Properties)
+	resourceSchema := fake.getSchema(collection)
+	for section, fields := range request.Properties {
+		for field, value := range fields {
+			attributeSchema, ok := resourceSchema[getAttribute(section, field)]
+			if ok != true || errorInfo[section][field] != nil {
+				continue
+			}
+			if table, ok := attributeSchema.Elem.(*schema.Resource); ok == true {
+				filled, duplicates := fillTable(value, table)
+				if duplicates != "" {
+					addError(errorInfo, section, field, "table.duplicate_key", fmt.Sprintf("Table is invalid: duplicates were found for '%s'", duplicates))
+				}
+				fields[field] = filled
+			}
+		}
+	}
+	if len(errorInfo) > 0 {
+		writeError(w, http.StatusBadRequest, "resource.validation_error", "The resource provided is invalid", errorInfo)
+		return
+	}
+
+	status := http.StatusOK
+	properties, exists := fake.objects[path]
+	if exists != true {
+		status = http.StatusCreated
+		properties = fake.getDefaults(collection)
+		fake.objects[path] = properties
+	}
+	for section, fields := range request.Properties {
+		if properties[section] == nil {
+			properties[section] = make(map[string]json.RawMessage)
+		}
+		for field, value := range fields {
+			properties[section][field] = value
+		}
+	}
+	if field, ok := hashedProperties[collection.Resource]; ok == true {
+		var secret string
+		if json.Unmarshal(request.Properties["basic"][field], &secret) == nil && secret != "" {
+			secretHash := sha256.Sum256([]byte(secret))
+			properties["basic"][field], _ = json.Marshal(base64.StdEncoding.EncodeToString(secretHash[:]))
+		}
+	}
+	fake.writeObject(w, status, properties, collection.Properties)
+}
+
+func (fake *Server) writeObject(w http.ResponseWriter, status int, properties Properties, propertiesType reflect.Type) {
+	object := reflect.New(propertiesType)
+	encoded, _ := json.Marshal(properties)
+	json.Unmarshal(encoded, object.Interface())
+	fillValue(object)
+	writeJson(w, status, map[string]interface{}{"properties": object.Interface()})
+}
+
+/*
+getDefaults returns the properties of a new object, with every property which has a default value
+in the Terraform schema for the object set to that value. Properties in the "basic" section are
+named after the property in the schema, and those in other sections after the section and the
+property.
+*/
+func (fake *Server) getDefaults(collection Collection) Properties {
+	properties := make(Properties)
+	resourceSchema := fake.getSchema(collection)
+	for i := 0; i < collection.Properties.NumField(); i++ {
+		section := getJsonName(collection.Properties.Field(i))
+		sectionType := collection.Properties.Field(i).Type
+		properties[section] = make(map[string]json.RawMessage)
+		for j := 0; j < sectionType.NumField(); j++ {
+			field := getJsonName(sectionType.Field(j))
+			attribute := getAttribute(section, field)
+			var value interface{}
+			if listDefault, ok := listDefaults[attribute]; ok == true {
+				value = listDefault
+			} else if attributeSchema, ok := resourceSchema[attribute]; ok == true && attributeSchema.Default != nil {
+				value = attributeSchema.Default
+			} else {
+				continue
+			}
+			properties[section][field], _ = json.Marshal(value)
+		}
+	}
+	return properties
+}
+
+/*
+fillTable sets any properties missing from the rows of a table property to the default values given
+by the Terraform schema for the table. The required properties of the table form its key, and any
+key which appears in more than one row is returned.
+*/
+func fillTable(value json.RawMessage, table *schema.Resource) (json.RawMessage, string) {
+	rows := []map[string]interface{}{}
+	if decodeErr := json.Unmarshal(value, &rows); decodeErr != nil {
+		return value, ""
+	}
+	fields := make([]string, 0, len(table.Schema))
+	for field := range table.Schema {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	keys := make(map[string]bool)
+	duplicates := ""
+	for _, row := range rows {
+		key := ""
+		for _, field := range fields {
+			if _, ok := row[field]; ok != true && table.Schema[field].Default != nil {
+				row[field] = table.Schema[field].Default
+			}
+			if table.Schema[field].Required == true {
+				key += fmt.Sprintf("%v", row[field])
+			}
+		}
+		if key != "" && keys[key] == true {
+			duplicates = key
+		}
+		keys[key] = true
+	}
+	filled, _ := json.Marshal(rows)
+	return filled, duplicates
+}
+
+func (fake *Server) getSchema(collection Collection) map[string]*schema.Schema {
+	if resource, ok := fake.api.Resources[collection.Resource]; ok == true {
+		return resource.Schema
+	}
+	return nil
+}
+
+// getAttribute returns the attribute of a property in the provider's resource schemas, which are
+// in lower case, as for numberofcpus and basic/numberOfCPUs.
+func getAttribute(section, field string) string {
+	if section == "basic" {
+		return strings.ToLower(field)
+	}
+	return strings.ToLower(section + "_" + field)
+}
+
+func getJsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+func addError(errorInfo map[string]map[string]interface{}, section, field, errorId, errorText string) {
+	if errorInfo[section] == nil {
+		errorInfo[section] = make(map[string]interface{})
+	}
+	errorInfo[section][field] = map[string]string{"error_id": errorId, "error_text": errorText}
+}
+
+/*
+ValidateProperties checks the properties of a configuration object against the go-vtm type for
+that object, and returns the error_info payload which a vTM would return for any properties which
+are unknown or have a value of the wrong type.
+*/
+func ValidateProperties(properties Properties, propertiesType reflect.Type) map[string]map[string]interface{} {
+	errorInfo := make(map[string]map[string]interface{})
+
+	sectionTypes := make(map[string]reflect.Type)
+	for i := 0; i < propertiesType.NumField(); i++ {
+		sectionTypes[getJsonName(propertiesType.Field(i))] = propertiesType.Field(i).Type
+	}
+	for section, fields := range properties {
+		sectionType, ok := sectionTypes[section]
+		if ok != true {
+			for field := range fields {
+				addError(errorInfo, section, field, "property.no_such_section", fmt.Sprintf("Unknown section '%s'", section))
+			}
+			continue
+		}
+		fieldTypes := make(map[string]reflect.Type)
+		for i := 0; i < sectionType.NumField(); i++ {
+			fieldTypes[getJsonName(sectionType.Field(i))] = sectionType.Field(i).Type
+		}
+		for field, value := range fields {
+			fieldType, ok := fieldTypes[field]
+			if ok != true {
+				addError(errorInfo, section, field, "property.no_such_property", fmt.Sprintf("Unknown property '%s'", field))
+				continue
+			}
+			decoder := json.NewDecoder(bytes.NewReader(value))
+			decoder.DisallowUnknownFields()
+			if decodeErr := decoder.Decode(reflect.New(fieldType).Interface()); decodeErr != nil {
+				addError(errorInfo, section, field, "type.invalid", fmt.Sprintf("Invalid value: %v", decodeErr))
+			}
+		}
+	}
+	return errorInfo
+}
+
+// fillValue allocates every nil pointer, slice and map reachable from a value, so that it is
+// encoded with every property present.
+func fillValue(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		fillValue(value.Elem())
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath == "" {
+				fillValue(value.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if value.IsNil() {
+			value.Set(reflect.MakeSlice(value.Type(), 0, 0))
+		}
+		for i := 0; i < value.Len(); i++ {
+			fillValue(value.Index(i))
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+	}
+}